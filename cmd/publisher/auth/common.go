@@ -3,7 +3,6 @@ package auth
 import (
 	"bytes"
 	"context"
-	"crypto/ed25519"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
@@ -16,8 +15,9 @@ import (
 type CryptoProvider struct {
 	registryURL string
 	domain      string
-	hexSeed     string
+	signer      Signer
 	authMethod  string
+	requestedScope
 }
 
 // GetToken retrieves the registry JWT token using cryptographic authentication
@@ -26,31 +26,46 @@ func (c *CryptoProvider) GetToken(ctx context.Context) (string, error) {
 		return "", fmt.Errorf("%s domain is required", c.authMethod)
 	}
 
-	if c.hexSeed == "" {
-		return "", fmt.Errorf("%s private key (hex seed) is required", c.authMethod)
+	if c.signer == nil {
+		return "", fmt.Errorf("%s signer is required", c.authMethod)
 	}
 
-	// Decode hex seed to private key
-	seedBytes, err := hex.DecodeString(c.hexSeed)
+	// Request a single-use challenge nonce to sign
+	nonce, err := c.requestChallenge(ctx)
 	if err != nil {
-		return "", fmt.Errorf("invalid hex seed format: %w", err)
+		return "", fmt.Errorf("failed to get %s challenge: %w", c.authMethod, err)
 	}
 
-	if len(seedBytes) != ed25519.SeedSize {
-		return "", fmt.Errorf("invalid seed length: expected %d bytes, got %d", ed25519.SeedSize, len(seedBytes))
+	var payload map[string]any
+	if nonce != "" {
+		message := fmt.Sprintf("mcp-registry-auth:%s:%s:%s", c.authMethod, c.domain, nonce)
+		signature, err := c.signer.Sign(ctx, []byte(message))
+		if err != nil {
+			return "", fmt.Errorf("failed to sign %s challenge: %w", c.authMethod, err)
+		}
+		payload = map[string]any{
+			"domain":       c.domain,
+			"nonce":        nonce,
+			"signed_nonce": hex.EncodeToString(signature),
+		}
+	} else {
+		// Older registries don't issue challenges, so sign the current timestamp instead
+		timestamp := time.Now().UTC().Format(time.RFC3339)
+		signature, err := c.signer.Sign(ctx, []byte(timestamp))
+		if err != nil {
+			return "", fmt.Errorf("failed to sign %s timestamp: %w", c.authMethod, err)
+		}
+		payload = map[string]any{
+			"domain":           c.domain,
+			"timestamp":        timestamp,
+			"signed_timestamp": hex.EncodeToString(signature),
+		}
 	}
 
-	privateKey := ed25519.NewKeyFromSeed(seedBytes)
-
-	// Generate current timestamp
-	timestamp := time.Now().UTC().Format(time.RFC3339)
-
-	// Sign the timestamp
-	signature := ed25519.Sign(privateKey, []byte(timestamp))
-	signedTimestamp := hex.EncodeToString(signature)
+	c.addScope(payload)
 
 	// Exchange signature for registry token
-	registryToken, err := c.exchangeTokenForRegistry(ctx, c.domain, timestamp, signedTimestamp)
+	registryToken, err := c.exchangeTokenForRegistry(ctx, payload)
 	if err != nil {
 		return "", fmt.Errorf("failed to exchange %s signature: %w", c.authMethod, err)
 	}
@@ -68,17 +83,63 @@ func (c *CryptoProvider) Login(_ context.Context) error {
 	return nil
 }
 
-// exchangeTokenForRegistry exchanges signature for a registry JWT token
-func (c *CryptoProvider) exchangeTokenForRegistry(ctx context.Context, domain, timestamp, signedTimestamp string) (string, error) {
+// requestChallenge fetches a challenge nonce from the registry. It returns an empty nonce
+// if the registry does not support challenges.
+func (c *CryptoProvider) requestChallenge(ctx context.Context) (string, error) {
 	if c.registryURL == "" {
 		return "", fmt.Errorf("registry URL is required for token exchange")
 	}
 
-	// Prepare the request body
-	payload := map[string]string{
-		"domain":           domain,
-		"timestamp":        timestamp,
-		"signed_timestamp": signedTimestamp,
+	challengeURL := fmt.Sprintf("%s/v0/auth/challenge", c.registryURL)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, challengeURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return "", nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("challenge request failed with status %d: %s", resp.StatusCode, body)
+	}
+
+	var challenge struct {
+		Nonce string `json:"nonce"`
+	}
+	if err := json.Unmarshal(body, &challenge); err != nil {
+		return "", fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	if challenge.Nonce == "" {
+		return "", fmt.Errorf("registry returned an empty challenge nonce")
+	}
+
+	return challenge.Nonce, nil
+}
+
+// exchangeTokenForRegistry exchanges a signed payload for a registry JWT token
+func (c *CryptoProvider) exchangeTokenForRegistry(ctx context.Context, payload map[string]any) (string, error) {
+	return exchangeForRegistryToken(ctx, c.registryURL, c.authMethod, payload)
+}
+
+// exchangeForRegistryToken posts a token exchange payload to the registry auth endpoint of the
+// given method and returns the registry JWT token
+func exchangeForRegistryToken(ctx context.Context, registryURL, authMethod string, payload map[string]any) (string, error) {
+	if registryURL == "" {
+		return "", fmt.Errorf("registry URL is required for token exchange")
 	}
 
 	jsonData, err := json.Marshal(payload)
@@ -87,7 +148,7 @@ func (c *CryptoProvider) exchangeTokenForRegistry(ctx context.Context, domain, t
 	}
 
 	// Make the token exchange request
-	exchangeURL := fmt.Sprintf("%s/v0/auth/%s", c.registryURL, c.authMethod)
+	exchangeURL := fmt.Sprintf("%s/v0/auth/%s", registryURL, authMethod)
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, exchangeURL, bytes.NewBuffer(jsonData))
 	if err != nil {
 		return "", fmt.Errorf("failed to create request: %w", err)
@@ -119,3 +180,14 @@ func (c *CryptoProvider) exchangeTokenForRegistry(ctx context.Context, domain, t
 
 	return tokenResp.RegistryToken, nil
 }
+
+// exchangeOIDCTokenForRegistry exchanges a CI OIDC token for a registry JWT token at the auth
+// endpoint of the given method, narrowed to the requested scope
+func exchangeOIDCTokenForRegistry(ctx context.Context, registryURL, authMethod, oidcToken string, scope *requestedScope) (string, error) {
+	payload := map[string]any{
+		"oidc_token": oidcToken,
+	}
+	scope.addScope(payload)
+
+	return exchangeForRegistryToken(ctx, registryURL, authMethod, payload)
+}
@@ -5,12 +5,12 @@ type DNSProvider struct {
 }
 
 // NewDNSProvider creates a new DNS-based auth provider
-func NewDNSProvider(registryURL, domain, hexSeed string) Provider {
+func NewDNSProvider(registryURL, domain string, signer Signer) Provider {
 	return &DNSProvider{
 		CryptoProvider: &CryptoProvider{
 			registryURL: registryURL,
 			domain:      domain,
-			hexSeed:     hexSeed,
+			signer:      signer,
 			authMethod:  "dns",
 		},
 	}
@@ -53,6 +53,7 @@ type GitHubATProvider struct {
 	clientID    string
 	forceLogin  bool
 	registryURL string
+	requestedScope
 }
 
 // ServerHealthResponse represents the response from the health endpoint
@@ -71,10 +72,13 @@ func NewGitHubATProvider(forceLogin bool, registryURL string) Provider {
 
 // GetToken retrieves the registry JWT token (exchanges GitHub token if needed)
 func (g *GitHubATProvider) GetToken(ctx context.Context) (string, error) {
-	// Check if we have a valid registry token
-	registryToken, err := readRegistryToken()
-	if err == nil && registryToken != "" {
-		return registryToken, nil
+	// Check if we have a valid registry token. The cache holds unscoped tokens,
+	// so a scoped request always exchanges a new one.
+	if len(g.scope) == 0 {
+		registryToken, err := readRegistryToken()
+		if err == nil && registryToken != "" {
+			return registryToken, nil
+		}
 	}
 
 	// If no valid registry token, exchange GitHub token for registry token
@@ -90,9 +94,11 @@ func (g *GitHubATProvider) GetToken(ctx context.Context) (string, error) {
 	}
 
 	// Store the registry token
-	err = saveRegistryToken(registryToken, expiresAt)
-	if err != nil {
-		return "", fmt.Errorf("failed to save registry token: %w", err)
+	if len(g.scope) == 0 {
+		err = saveRegistryToken(registryToken, expiresAt)
+		if err != nil {
+			return "", fmt.Errorf("failed to save registry token: %w", err)
+		}
 	}
 
 	return registryToken, nil
@@ -344,9 +350,10 @@ func (g *GitHubATProvider) exchangeTokenForRegistry(ctx context.Context, githubT
 	}
 
 	// Prepare the request body
-	payload := map[string]string{
+	payload := map[string]any{
 		"github_token": githubToken,
 	}
+	g.addScope(payload)
 
 	jsonData, err := json.Marshal(payload)
 	if err != nil {
@@ -1,7 +1,6 @@
 package auth
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -12,6 +11,7 @@ import (
 
 type GitHubOIDCProvider struct {
 	registryURL string
+	requestedScope
 }
 
 // NewGitHubOIDCProvider creates a new GitHub OIDC provider
@@ -30,7 +30,7 @@ func (o *GitHubOIDCProvider) GetToken(ctx context.Context) (string, error) {
 	}
 
 	// Exchange OIDC token for registry token
-	registryToken, err := o.exchangeOIDCTokenForRegistry(ctx, oidcToken)
+	registryToken, err := exchangeOIDCTokenForRegistry(ctx, o.registryURL, o.Name(), oidcToken, &o.requestedScope)
 	if err != nil {
 		return "", fmt.Errorf("failed to exchange OIDC token: %w", err)
 	}
@@ -55,56 +55,6 @@ func (o *GitHubOIDCProvider) Name() string {
 	return "github-oidc"
 }
 
-// exchangeOIDCTokenForRegistry exchanges a GitHub OIDC token for a registry JWT token
-func (o *GitHubOIDCProvider) exchangeOIDCTokenForRegistry(ctx context.Context, oidcToken string) (string, error) {
-	if o.registryURL == "" {
-		return "", fmt.Errorf("registry URL is required for token exchange")
-	}
-
-	// Prepare the request body
-	payload := map[string]string{
-		"oidc_token": oidcToken,
-	}
-
-	jsonData, err := json.Marshal(payload)
-	if err != nil {
-		return "", fmt.Errorf("failed to marshal request: %w", err)
-	}
-
-	// Make the token exchange request
-	exchangeURL := o.registryURL + "/v0/auth/github-oidc"
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, exchangeURL, bytes.NewBuffer(jsonData))
-	if err != nil {
-		return "", fmt.Errorf("failed to create request: %w", err)
-	}
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Accept", "application/json")
-
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return "", fmt.Errorf("failed to send request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return "", fmt.Errorf("failed to read response: %w", err)
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("token exchange failed with status %d: %s", resp.StatusCode, body)
-	}
-
-	var tokenResp RegistryTokenResponse
-	err = json.Unmarshal(body, &tokenResp)
-	if err != nil {
-		return "", fmt.Errorf("failed to unmarshal response: %w", err)
-	}
-
-	return tokenResp.RegistryToken, nil
-}
-
 // getOIDCTokenFromGitHub fetches the OIDC token from GitHub Actions endpoint
 func (o *GitHubOIDCProvider) getOIDCTokenFromGitHub(ctx context.Context) (string, error) {
 	// Check for required environment variables
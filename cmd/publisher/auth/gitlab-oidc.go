@@ -0,0 +1,62 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"os"
+)
+
+type GitLabOIDCProvider struct {
+	registryURL string
+	requestedScope
+}
+
+// NewGitLabOIDCProvider creates a new GitLab CI OIDC provider
+func NewGitLabOIDCProvider(registryURL string) Provider {
+	return &GitLabOIDCProvider{
+		registryURL: registryURL,
+	}
+}
+
+// GetToken retrieves the registry JWT token using a GitLab CI ID token
+func (o *GitLabOIDCProvider) GetToken(ctx context.Context) (string, error) {
+	oidcToken, err := getOIDCTokenFromGitLab()
+	if err != nil {
+		return "", fmt.Errorf("failed to get OIDC token from GitLab: %w", err)
+	}
+
+	registryToken, err := exchangeOIDCTokenForRegistry(ctx, o.registryURL, o.Name(), oidcToken, &o.requestedScope)
+	if err != nil {
+		return "", fmt.Errorf("failed to exchange OIDC token: %w", err)
+	}
+
+	return registryToken, nil
+}
+
+// NeedsLogin always returns false for OIDC since the token is provided by GitLab CI
+func (o *GitLabOIDCProvider) NeedsLogin() bool {
+	return false
+}
+
+// Login is not needed for OIDC since tokens are provided by GitLab CI
+func (o *GitLabOIDCProvider) Login(_ context.Context) error {
+	return nil
+}
+
+// Name returns the name of this auth provider
+func (o *GitLabOIDCProvider) Name() string {
+	return "gitlab-oidc"
+}
+
+// getOIDCTokenFromGitLab reads the ID token GitLab CI injects into the job environment.
+// ID_TOKEN is the conventional name for an `id_tokens` entry with `aud: mcp-registry`;
+// CI_JOB_JWT_V2 is the legacy predefined variable on older GitLab versions.
+func getOIDCTokenFromGitLab() (string, error) {
+	for _, name := range []string{"ID_TOKEN", "CI_JOB_JWT_V2"} {
+		if token := os.Getenv(name); token != "" {
+			return token, nil
+		}
+	}
+
+	return "", fmt.Errorf("ID_TOKEN environment variable not found - are you running in GitLab CI with an id_tokens entry named ID_TOKEN and aud: mcp-registry?")
+}
@@ -0,0 +1,36 @@
+package auth_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/cmd/publisher/auth"
+)
+
+func TestGitLabOIDCProvider_GetToken(t *testing.T) {
+	var received map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v0/auth/gitlab-oidc", r.URL.Path)
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		_ = json.NewEncoder(w).Encode(auth.RegistryTokenResponse{RegistryToken: "registry-token"})
+	}))
+	defer server.Close()
+
+	t.Setenv("ID_TOKEN", "gitlab-id-token")
+	provider := auth.NewGitLabOIDCProvider(server.URL)
+	provider.(interface{ SetScope([]auth.ScopePermission) }).SetScope([]auth.ScopePermission{
+		{Action: "publish", Resource: "io.gitlab.my-group/weather"},
+	})
+
+	token, err := provider.GetToken(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "registry-token", token)
+	assert.Equal(t, "gitlab-id-token", received["oidc_token"])
+	assert.Equal(t, []any{map[string]any{"action": "publish", "resource": "io.gitlab.my-group/weather"}}, received["scope"])
+}
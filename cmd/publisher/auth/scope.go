@@ -0,0 +1,60 @@
+package auth
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ScopePermission is a permission requested when exchanging a token, to narrow the registry token
+type ScopePermission struct {
+	Action   string `json:"action"`
+	Resource string `json:"resource"`
+}
+
+// Scoped is implemented by providers that can request a token narrowed to a scope
+type Scoped interface {
+	SetScope(scope []ScopePermission)
+}
+
+// ParseScope parses a comma-separated list of resource patterns, each optionally prefixed
+// with an action, e.g. "io.github.acme/weather,edit:io.github.acme/*". The default action is publish.
+func ParseScope(value string) ([]ScopePermission, error) {
+	var scope []ScopePermission
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		action, resource := "publish", entry
+		if before, after, found := strings.Cut(entry, ":"); found {
+			action, resource = before, after
+		}
+		if action != "publish" && action != "edit" {
+			return nil, fmt.Errorf("invalid scope action %q in %q: must be publish or edit", action, entry)
+		}
+		if resource == "" {
+			return nil, fmt.Errorf("invalid scope %q: resource is required", entry)
+		}
+
+		scope = append(scope, ScopePermission{Action: action, Resource: resource})
+	}
+	return scope, nil
+}
+
+// requestedScope holds the scope a provider sends with its token exchange request
+type requestedScope struct {
+	scope []ScopePermission
+}
+
+// SetScope sets the scope to request
+func (s *requestedScope) SetScope(scope []ScopePermission) {
+	s.scope = scope
+}
+
+// addScope adds the requested scope, if any, to a token exchange payload
+func (s *requestedScope) addScope(payload map[string]any) {
+	if len(s.scope) > 0 {
+		payload["scope"] = s.scope
+	}
+}
@@ -0,0 +1,25 @@
+package auth_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/cmd/publisher/auth"
+)
+
+func TestParseScope(t *testing.T) {
+	scope, err := auth.ParseScope("io.github.acme/weather, edit:io.github.acme/*")
+	require.NoError(t, err)
+	assert.Equal(t, []auth.ScopePermission{
+		{Action: "publish", Resource: "io.github.acme/weather"},
+		{Action: "edit", Resource: "io.github.acme/*"},
+	}, scope)
+
+	_, err = auth.ParseScope("delete:io.github.acme/weather")
+	assert.ErrorContains(t, err, "must be publish or edit")
+
+	_, err = auth.ParseScope("publish:")
+	assert.ErrorContains(t, err, "resource is required")
+}
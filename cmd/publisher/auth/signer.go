@@ -0,0 +1,220 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+)
+
+// Signer signs the DNS/HTTP authentication message, so the login flow never needs
+// to hold raw key material itself
+type Signer interface {
+	// Sign returns the raw signature of message. Ed25519 signers sign the message directly;
+	// ECDSA P-256 and RSA signers sign its SHA-256 digest.
+	Sign(ctx context.Context, message []byte) ([]byte, error)
+}
+
+// KeySigner signs with an in-memory private key
+type KeySigner struct {
+	key crypto.Signer
+}
+
+// NewKeySigner parses either a 64-character hex Ed25519 seed or a PEM-encoded
+// Ed25519, ECDSA P-256 or RSA private key
+func NewKeySigner(privateKey string) (*KeySigner, error) {
+	key, err := parsePrivateKey(privateKey)
+	if err != nil {
+		return nil, err
+	}
+	return &KeySigner{key: key}, nil
+}
+
+// NewKeyFileSigner reads the private key from a file, in any format accepted by NewKeySigner
+func NewKeyFileSigner(path string) (*KeySigner, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read private key file: %w", err)
+	}
+	return NewKeySigner(string(data))
+}
+
+// NewEnvSigner reads the private key from an environment variable, in any format accepted by NewKeySigner
+func NewEnvSigner(name string) (*KeySigner, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return nil, fmt.Errorf("environment variable %s is not set", name)
+	}
+	return NewKeySigner(value)
+}
+
+// Sign signs the message with the private key
+func (s *KeySigner) Sign(_ context.Context, message []byte) ([]byte, error) {
+	if _, ok := s.key.(ed25519.PrivateKey); ok {
+		return s.key.Sign(rand.Reader, message, crypto.Hash(0))
+	}
+	digest := sha256.Sum256(message)
+	return s.key.Sign(rand.Reader, digest[:], crypto.SHA256)
+}
+
+// parsePrivateKey parses either a 64-character hex Ed25519 seed or a PEM-encoded
+// Ed25519, ECDSA P-256 or RSA private key
+func parsePrivateKey(value string) (crypto.Signer, error) {
+	value = strings.TrimSpace(value)
+
+	if !strings.HasPrefix(value, "-----BEGIN") {
+		// Decode hex seed to private key
+		seedBytes, err := hex.DecodeString(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid hex seed format: %w", err)
+		}
+
+		if len(seedBytes) != ed25519.SeedSize {
+			return nil, fmt.Errorf("invalid seed length: expected %d bytes, got %d", ed25519.SeedSize, len(seedBytes))
+		}
+
+		return ed25519.NewKeyFromSeed(seedBytes), nil
+	}
+
+	block, _ := pem.Decode([]byte(value))
+	if block == nil {
+		return nil, fmt.Errorf("invalid PEM private key")
+	}
+
+	var key any
+	var err error
+	switch block.Type {
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block type %q", block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key: %w", err)
+	}
+
+	switch k := key.(type) {
+	case ed25519.PrivateKey:
+		return k, nil
+	case *ecdsa.PrivateKey:
+		if k.Curve != elliptic.P256() {
+			return nil, fmt.Errorf("unsupported ECDSA curve %s: only P-256 is supported", k.Curve.Params().Name)
+		}
+		return k, nil
+	case *rsa.PrivateKey:
+		return k, nil
+	default:
+		return nil, fmt.Errorf("unsupported private key type %T", key)
+	}
+}
+
+// SSHAgentSigner signs with an Ed25519 key held by a running ssh-agent
+type SSHAgentSigner struct {
+	socket string
+	key    string
+}
+
+// NewSSHAgentSigner creates a signer for the ssh-agent Ed25519 key identified by its
+// SHA256 fingerprint (as shown by `ssh-add -l`) or comment. The agent is reached via SSH_AUTH_SOCK.
+func NewSSHAgentSigner(key string) (*SSHAgentSigner, error) {
+	socket := os.Getenv("SSH_AUTH_SOCK")
+	if socket == "" {
+		return nil, errors.New("SSH_AUTH_SOCK is not set: is ssh-agent running?")
+	}
+	return &SSHAgentSigner{socket: socket, key: key}, nil
+}
+
+// Sign asks the agent to sign the message
+func (s *SSHAgentSigner) Sign(ctx context.Context, message []byte) ([]byte, error) {
+	conn, err := (&net.Dialer{}).DialContext(ctx, "unix", s.socket)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to ssh-agent: %w", err)
+	}
+	defer conn.Close()
+
+	client := agent.NewClient(conn)
+	keys, err := client.List()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ssh-agent keys: %w", err)
+	}
+
+	for _, key := range keys {
+		if key.Format != ssh.KeyAlgoED25519 {
+			continue
+		}
+		if key.Comment != s.key && ssh.FingerprintSHA256(key) != s.key {
+			continue
+		}
+
+		signature, err := client.Sign(key, message)
+		if err != nil {
+			return nil, fmt.Errorf("ssh-agent failed to sign: %w", err)
+		}
+		if signature.Format != ssh.KeyAlgoED25519 || len(signature.Blob) != ed25519.SignatureSize {
+			return nil, fmt.Errorf("unexpected ssh-agent signature format %s", signature.Format)
+		}
+		return signature.Blob, nil
+	}
+
+	return nil, fmt.Errorf("no Ed25519 key matching %q found in ssh-agent", s.key)
+}
+
+// CommandSigner signs by running an external program, in the style of git's gpg.program.
+// The message is written to the program's stdin, and it must print the signature to stdout,
+// hex or base64 encoded.
+type CommandSigner struct {
+	command []string
+}
+
+// NewCommandSigner creates a signer for the given command line. Arguments are split on
+// whitespace without shell interpretation.
+func NewCommandSigner(command string) (*CommandSigner, error) {
+	fields := strings.Fields(command)
+	if len(fields) == 0 {
+		return nil, errors.New("sign command is empty")
+	}
+	return &CommandSigner{command: fields}, nil
+}
+
+// Sign runs the command with the message on stdin and decodes the signature it prints
+func (s *CommandSigner) Sign(ctx context.Context, message []byte) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, s.command[0], s.command[1:]...) //nolint:gosec // The command is configured by the user running the CLI
+	cmd.Stdin = bytes.NewReader(message)
+	cmd.Stderr = os.Stderr
+
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("sign command failed: %w", err)
+	}
+
+	encoded := strings.TrimSpace(string(output))
+	if signature, err := hex.DecodeString(encoded); err == nil && len(signature) > 0 {
+		return signature, nil
+	}
+	if signature, err := base64.StdEncoding.DecodeString(encoded); err == nil && len(signature) > 0 {
+		return signature, nil
+	}
+	return nil, errors.New("sign command output is not a hex or base64 encoded signature")
+}
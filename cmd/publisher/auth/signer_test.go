@@ -0,0 +1,157 @@
+package auth_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+
+	"github.com/modelcontextprotocol/registry/cmd/publisher/auth"
+)
+
+func TestKeySigners(t *testing.T) {
+	ctx := context.Background()
+	message := []byte("mcp-registry-auth:dns:example.com:nonce")
+
+	seed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(seed)
+	require.NoError(t, err)
+	edKey := ed25519.NewKeyFromSeed(seed)
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	ecDER, err := x509.MarshalPKCS8PrivateKey(ecKey)
+	require.NoError(t, err)
+	ecPEM := string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: ecDER}))
+
+	t.Run("hex seed", func(t *testing.T) {
+		signer, err := auth.NewKeySigner(hex.EncodeToString(seed))
+		require.NoError(t, err)
+		signature, err := signer.Sign(ctx, message)
+		require.NoError(t, err)
+		assert.True(t, ed25519.Verify(edKey.Public().(ed25519.PublicKey), message, signature))
+	})
+
+	t.Run("PEM key file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "key.pem")
+		require.NoError(t, os.WriteFile(path, []byte(ecPEM), 0600))
+
+		signer, err := auth.NewKeyFileSigner(path)
+		require.NoError(t, err)
+		signature, err := signer.Sign(ctx, message)
+		require.NoError(t, err)
+		digest := sha256.Sum256(message)
+		assert.True(t, ecdsa.VerifyASN1(&ecKey.PublicKey, digest[:], signature))
+	})
+
+	t.Run("environment variable", func(t *testing.T) {
+		t.Setenv("TEST_MCP_SIGNING_KEY", hex.EncodeToString(seed))
+
+		signer, err := auth.NewEnvSigner("TEST_MCP_SIGNING_KEY")
+		require.NoError(t, err)
+		signature, err := signer.Sign(ctx, message)
+		require.NoError(t, err)
+		assert.True(t, ed25519.Verify(edKey.Public().(ed25519.PublicKey), message, signature))
+	})
+
+	t.Run("unset environment variable", func(t *testing.T) {
+		_, err := auth.NewEnvSigner("TEST_MCP_SIGNING_KEY_UNSET")
+		assert.ErrorContains(t, err, "is not set")
+	})
+
+	t.Run("unsupported curve", func(t *testing.T) {
+		p384Key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+		require.NoError(t, err)
+		der, err := x509.MarshalECPrivateKey(p384Key)
+		require.NoError(t, err)
+
+		_, err = auth.NewKeySigner(string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})))
+		assert.ErrorContains(t, err, "only P-256 is supported")
+	})
+}
+
+func TestCommandSigner(t *testing.T) {
+	ctx := context.Background()
+
+	script := filepath.Join(t.TempDir(), "sign.sh")
+	// Echoes the hex-encoded stdin back, so the test can check the message was passed through
+	require.NoError(t, os.WriteFile(script, []byte("#!/bin/sh\nod -An -tx1 | tr -d ' \\n'\n"), 0700)) //nolint:gosec // Test script must be executable
+
+	signer, err := auth.NewCommandSigner(script)
+	require.NoError(t, err)
+	signature, err := signer.Sign(ctx, []byte("hello"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("hello"), signature)
+
+	failing, err := auth.NewCommandSigner("false")
+	require.NoError(t, err)
+	_, err = failing.Sign(ctx, []byte("hello"))
+	assert.ErrorContains(t, err, "sign command failed")
+
+	_, err = auth.NewCommandSigner("  ")
+	assert.Error(t, err)
+}
+
+func TestSSHAgentSigner(t *testing.T) {
+	ctx := context.Background()
+	message := []byte("mcp-registry-auth:http:example.com:nonce")
+
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	keyring := agent.NewKeyring()
+	require.NoError(t, keyring.Add(agent.AddedKey{PrivateKey: privateKey, Comment: "publisher@example.com"}))
+
+	// Unix socket paths are length-limited, so avoid the long t.TempDir() path
+	dir, err := os.MkdirTemp("", "agent")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+	socket := filepath.Join(dir, "agent.sock")
+
+	listener, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				_ = agent.ServeAgent(keyring, conn)
+			}()
+		}
+	}()
+	t.Setenv("SSH_AUTH_SOCK", socket)
+
+	sshPublicKey, err := ssh.NewPublicKey(publicKey)
+	require.NoError(t, err)
+
+	for _, key := range []string{"publisher@example.com", ssh.FingerprintSHA256(sshPublicKey)} {
+		signer, err := auth.NewSSHAgentSigner(key)
+		require.NoError(t, err)
+		signature, err := signer.Sign(ctx, message)
+		require.NoError(t, err)
+		assert.True(t, ed25519.Verify(publicKey, message, signature))
+	}
+
+	signer, err := auth.NewSSHAgentSigner("unknown")
+	require.NoError(t, err)
+	_, err = signer.Sign(ctx, message)
+	assert.ErrorContains(t, err, "no Ed25519 key matching")
+}
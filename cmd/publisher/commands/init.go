@@ -11,6 +11,8 @@ import (
 	"strings"
 	"time"
 
+	"golang.org/x/mod/modfile"
+
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
 	"github.com/modelcontextprotocol/registry/pkg/model"
 )
@@ -149,9 +151,40 @@ func detectDescription() string {
 		}
 	}
 
+	// Try to get from Cargo.toml
+	if desc := getCargoPackageField("description"); desc != "" {
+		return desc
+	}
+
 	return "An MCP server that provides [describe what your server does]"
 }
 
+// getCargoPackageField reads a string field from the [package] table of Cargo.toml
+func getCargoPackageField(key string) string {
+	data, err := os.ReadFile("Cargo.toml")
+	if err != nil {
+		return ""
+	}
+
+	// Simple extraction - could be improved with proper TOML parser
+	inPackage := false
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "[") {
+			inPackage = line == "[package]"
+			continue
+		}
+		if !inPackage {
+			continue
+		}
+		name, value, found := strings.Cut(line, "=")
+		if found && strings.TrimSpace(name) == key {
+			return strings.Trim(strings.TrimSpace(value), "\"'")
+		}
+	}
+	return ""
+}
+
 func detectRepoURL() string {
 	// Try git remote
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -199,6 +232,16 @@ func detectPackageType() string {
 		return model.RegistryTypePyPI
 	}
 
+	// Check for Cargo.toml
+	if _, err := os.Stat("Cargo.toml"); err == nil {
+		return model.RegistryTypeCargo
+	}
+
+	// Check for go.mod
+	if _, err := os.Stat("go.mod"); err == nil {
+		return model.RegistryTypeGo
+	}
+
 	// Check for Dockerfile
 	if _, err := os.Stat("Dockerfile"); err == nil {
 		return model.RegistryTypeOCI
@@ -249,6 +292,20 @@ func detectPackageIdentifier(serverName string, packageType string) string {
 		}
 		return "your-package"
 
+	case model.RegistryTypeCargo:
+		if name := getCargoPackageField("name"); name != "" {
+			return name
+		}
+		return "your-crate"
+
+	case model.RegistryTypeGo:
+		if data, err := os.ReadFile("go.mod"); err == nil {
+			if modulePath := modfile.ModulePath(data); modulePath != "" {
+				return modulePath
+			}
+		}
+		return "github.com/your-org/your-module"
+
 	case model.RegistryTypeOCI:
 		// Use a sensible default
 		if strings.Contains(serverName, "/") {
@@ -304,6 +361,18 @@ func createServerJSON(
 				Type: model.TransportTypeStdio,
 			},
 		}
+	case model.RegistryTypeGo:
+		// Go module versions carry a v prefix
+		pkg = model.Package{
+			RegistryType:         model.RegistryTypeGo,
+			Identifier:           packageIdentifier,
+			Version:              "v" + strings.TrimPrefix(packageVersion, "v"),
+			RunTimeHint:          model.RuntimeHintGo,
+			EnvironmentVariables: envVars,
+			Transport: model.Transport{
+				Type: model.TransportTypeStdio,
+			},
+		}
 	case "url":
 		pkg = model.Package{
 			RegistryType:         "url",
@@ -19,7 +19,7 @@ const (
 
 func LoginCommand(args []string) error {
 	if len(args) < 1 {
-		return errors.New("authentication method required\n\nUsage: mcp-publisher login <method>\n\nMethods:\n  github        Interactive GitHub authentication\n  github-oidc   GitHub Actions OIDC authentication\n  dns           DNS-based authentication (requires --domain and --private-key)\n  http          HTTP-based authentication (requires --domain and --private-key)\n  none          Anonymous authentication (for testing)")
+		return errors.New("authentication method required\n\nUsage: mcp-publisher login <method>\n\nMethods:\n  github        Interactive GitHub authentication\n  github-oidc   GitHub Actions OIDC authentication\n  gitlab-oidc   GitLab CI OIDC authentication\n  dns           DNS-based authentication (requires --domain and a signing key)\n  http          HTTP-based authentication (requires --domain and a signing key)\n  none          Anonymous authentication (for testing)")
 	}
 
 	method := args[0]
@@ -27,14 +27,20 @@ func LoginCommand(args []string) error {
 	// Parse remaining flags based on method
 	loginFlags := flag.NewFlagSet("login", flag.ExitOnError)
 	var domain string
-	var privateKey string
 	var registryURL string
+	var scope string
+	var signerFlags signerOptions
 
 	loginFlags.StringVar(&registryURL, "registry", DefaultRegistryURL, "Registry URL")
+	loginFlags.StringVar(&scope, "scope", "", "Restrict the token to these servers, e.g. 'io.github.acme/weather,edit:io.github.acme/*' (default action: publish)")
 
 	if method == "dns" || method == "http" {
 		loginFlags.StringVar(&domain, "domain", "", "Domain name")
-		loginFlags.StringVar(&privateKey, "private-key", "", "Private key (64-char hex)")
+		loginFlags.StringVar(&signerFlags.privateKey, "private-key", "", "Private key (64-char hex Ed25519 seed, or PEM-encoded Ed25519, ECDSA P-256 or RSA key). Visible in shell history: prefer the options below")
+		loginFlags.StringVar(&signerFlags.privateKeyFile, "private-key-file", "", "Path to a file containing the private key")
+		loginFlags.StringVar(&signerFlags.privateKeyEnv, "private-key-env", "", "Name of an environment variable containing the private key")
+		loginFlags.StringVar(&signerFlags.sshAgentKey, "ssh-agent-key", "", "Sign with the ssh-agent Ed25519 key with this SHA256 fingerprint or comment")
+		loginFlags.StringVar(&signerFlags.signCommand, "sign-command", "", "Sign by running this command with the message on stdin; it must print a hex or base64 signature")
 	}
 
 	if err := loginFlags.Parse(args[1:]); err != nil {
@@ -48,22 +54,44 @@ func LoginCommand(args []string) error {
 		authProvider = auth.NewGitHubATProvider(true, registryURL)
 	case "github-oidc":
 		authProvider = auth.NewGitHubOIDCProvider(registryURL)
+	case "gitlab-oidc":
+		authProvider = auth.NewGitLabOIDCProvider(registryURL)
 	case "dns":
-		if domain == "" || privateKey == "" {
-			return errors.New("dns authentication requires --domain and --private-key")
+		if domain == "" {
+			return errors.New("dns authentication requires --domain")
 		}
-		authProvider = auth.NewDNSProvider(registryURL, domain, privateKey)
+		signer, err := signerFlags.newSigner()
+		if err != nil {
+			return fmt.Errorf("dns authentication: %w", err)
+		}
+		authProvider = auth.NewDNSProvider(registryURL, domain, signer)
 	case "http":
-		if domain == "" || privateKey == "" {
-			return errors.New("http authentication requires --domain and --private-key")
+		if domain == "" {
+			return errors.New("http authentication requires --domain")
+		}
+		signer, err := signerFlags.newSigner()
+		if err != nil {
+			return fmt.Errorf("http authentication: %w", err)
 		}
-		authProvider = auth.NewHTTPProvider(registryURL, domain, privateKey)
+		authProvider = auth.NewHTTPProvider(registryURL, domain, signer)
 	case "none":
 		authProvider = auth.NewNoneProvider(registryURL)
 	default:
 		return fmt.Errorf("unknown authentication method: %s\nFor a list of available methods, run: mcp-publisher login", method)
 	}
 
+	if scope != "" {
+		scoped, ok := authProvider.(auth.Scoped)
+		if !ok {
+			return fmt.Errorf("%s authentication does not support --scope", method)
+		}
+		requested, err := auth.ParseScope(scope)
+		if err != nil {
+			return err
+		}
+		scoped.SetScope(requested)
+	}
+
 	// Perform login
 	ctx := context.Background()
 	_, _ = fmt.Fprintf(os.Stdout, "Logging in with %s...\n", method)
@@ -103,3 +131,38 @@ func LoginCommand(args []string) error {
 	_, _ = fmt.Fprintln(os.Stdout, "✓ Successfully logged in")
 	return nil
 }
+
+// signerOptions holds the mutually exclusive ways of providing a signing key for DNS/HTTP login
+type signerOptions struct {
+	privateKey     string
+	privateKeyFile string
+	privateKeyEnv  string
+	sshAgentKey    string
+	signCommand    string
+}
+
+// newSigner creates the signer selected by exactly one of the signing key flags
+func (o signerOptions) newSigner() (auth.Signer, error) {
+	set := 0
+	for _, value := range []string{o.privateKey, o.privateKeyFile, o.privateKeyEnv, o.sshAgentKey, o.signCommand} {
+		if value != "" {
+			set++
+		}
+	}
+	if set != 1 {
+		return nil, errors.New("specify exactly one of --private-key, --private-key-file, --private-key-env, --ssh-agent-key or --sign-command")
+	}
+
+	switch {
+	case o.privateKeyFile != "":
+		return auth.NewKeyFileSigner(o.privateKeyFile)
+	case o.privateKeyEnv != "":
+		return auth.NewEnvSigner(o.privateKeyEnv)
+	case o.sshAgentKey != "":
+		return auth.NewSSHAgentSigner(o.sshAgentKey)
+	case o.signCommand != "":
+		return auth.NewCommandSigner(o.signCommand)
+	default:
+		return auth.NewKeySigner(o.privateKey)
+	}
+}
@@ -8,14 +8,23 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
 	"github.com/modelcontextprotocol/registry/pkg/model"
 )
 
+const (
+	// publishPollInterval is how often the status of a pending publish is checked
+	publishPollInterval = 2 * time.Second
+	// publishWaitTimeout is how long to wait for the registry to validate a pending publish
+	publishWaitTimeout = 15 * time.Minute
+)
+
 func PublishCommand(args []string) error {
 	// Check for server.json file
 	serverFile := "server.json"
@@ -82,6 +91,18 @@ Migrate to the current schema format for new servers.
 		return fmt.Errorf("publish failed: %w", err)
 	}
 
+	// Registries that validate publishes asynchronously accept them as pending
+	if response.Meta.Official != nil && response.Meta.Official.Status == model.StatusPending {
+		_, _ = fmt.Fprintln(os.Stdout, "Waiting for the registry to validate the server...")
+		status, err := waitForPublish(registryURL, response.Meta.Official.PublishID, token)
+		if err != nil {
+			return fmt.Errorf("publish failed: %w", err)
+		}
+		if status.Status == model.StatusRejected {
+			return fmt.Errorf("publish rejected: %s", status.Reason)
+		}
+	}
+
 	_, _ = fmt.Fprintln(os.Stdout, "✓ Successfully published")
 	_, _ = fmt.Fprintf(os.Stdout, "✓ Server %s version %s\n", response.Server.Name, response.Server.Version)
 
@@ -129,7 +150,7 @@ func publishToRegistry(registryURL string, serverData []byte, token string) (*ap
 		return nil, fmt.Errorf("error reading response: %w", err)
 	}
 
-	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted {
 		return nil, fmt.Errorf("server returned status %d: %s", resp.StatusCode, body)
 	}
 
@@ -140,3 +161,50 @@ func publishToRegistry(registryURL string, serverData []byte, token string) (*ap
 
 	return &serverResponse, nil
 }
+
+// waitForPublish polls the status of a pending publish until the registry has published or rejected it
+func waitForPublish(registryURL, publishID, token string) (*apiv0.PublishStatus, error) {
+	if !strings.HasSuffix(registryURL, "/") {
+		registryURL += "/"
+	}
+	statusURL := registryURL + "v0/publish/" + url.PathEscape(publishID)
+
+	ctx, cancel := context.WithTimeout(context.Background(), publishWaitTimeout)
+	defer cancel()
+
+	client := &http.Client{}
+	for {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, statusURL, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error creating request: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("error checking publish status: %w", err)
+		}
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("error reading response: %w", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("server returned status %d: %s", resp.StatusCode, body)
+		}
+
+		var status apiv0.PublishStatus
+		if err := json.Unmarshal(body, &status); err != nil {
+			return nil, err
+		}
+		if status.Status != model.StatusPending {
+			return &status, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("still pending after %s, check its status at %s", publishWaitTimeout, statusURL)
+		case <-time.After(publishPollInterval):
+		}
+	}
+}
@@ -2,6 +2,8 @@ package commands_test
 
 import (
 	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"strings"
@@ -131,3 +133,80 @@ func TestPublishCommand_DeprecatedSchema(t *testing.T) {
 		})
 	}
 }
+
+func TestPublishCommand_PendingPublish(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      apiv0.PublishStatus
+		errorSubstr string
+	}{
+		{
+			name:   "waits until published",
+			status: apiv0.PublishStatus{ID: "publish-1", Status: model.StatusActive},
+		},
+		{
+			name:        "reports rejection reason",
+			status:      apiv0.PublishStatus{ID: "publish-1", Status: model.StatusRejected, Reason: "NPM package '@example/test-server' not found"},
+			errorSubstr: "publish rejected: NPM package '@example/test-server' not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Header.Get("Authorization") != "Bearer test-token" {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				switch {
+				case r.Method == http.MethodPost && r.URL.Path == "/v0/publish":
+					var server apiv0.ServerJSON
+					_ = json.NewDecoder(r.Body).Decode(&server)
+					w.WriteHeader(http.StatusAccepted)
+					_ = json.NewEncoder(w).Encode(apiv0.ServerResponse{
+						Server: server,
+						Meta: apiv0.ResponseMeta{Official: &apiv0.RegistryExtensions{
+							Status:    model.StatusPending,
+							PublishID: "publish-1",
+						}},
+					})
+				case r.Method == http.MethodGet && r.URL.Path == "/v0/publish/publish-1":
+					_ = json.NewEncoder(w).Encode(tt.status)
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer registry.Close()
+
+			tempDir := t.TempDir()
+			t.Setenv("HOME", tempDir)
+			t.Chdir(tempDir)
+
+			tokenData, _ := json.Marshal(map[string]string{"token": "test-token", "registry": registry.URL})
+			if err := os.WriteFile(filepath.Join(tempDir, commands.TokenFileName), tokenData, 0o600); err != nil {
+				t.Fatalf("Failed to write token: %v", err)
+			}
+			serverData, _ := json.Marshal(apiv0.ServerJSON{
+				Schema:      model.CurrentSchemaURL,
+				Name:        "com.example/test-server",
+				Description: "A test server",
+				Version:     "1.0.0",
+			})
+			if err := os.WriteFile(filepath.Join(tempDir, "server.json"), serverData, 0o600); err != nil {
+				t.Fatalf("Failed to write server.json: %v", err)
+			}
+
+			err := commands.PublishCommand([]string{})
+
+			if tt.errorSubstr == "" {
+				if err != nil {
+					t.Errorf("Expected publish to succeed, got: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errorSubstr) {
+				t.Errorf("Expected error containing '%s', got: %v", tt.errorSubstr, err)
+			}
+		})
+	}
+}
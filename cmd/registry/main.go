@@ -12,11 +12,14 @@ import (
 	"time"
 
 	"github.com/modelcontextprotocol/registry/internal/api"
+	v0auth "github.com/modelcontextprotocol/registry/internal/api/handlers/v0/auth"
 	"github.com/modelcontextprotocol/registry/internal/config"
 	"github.com/modelcontextprotocol/registry/internal/database"
 	"github.com/modelcontextprotocol/registry/internal/importer"
+	"github.com/modelcontextprotocol/registry/internal/ratelimit"
 	"github.com/modelcontextprotocol/registry/internal/service"
 	"github.com/modelcontextprotocol/registry/internal/telemetry"
+	"github.com/modelcontextprotocol/registry/internal/validators"
 )
 
 // Version info for the MCP Registry application
@@ -56,16 +59,34 @@ func main() {
 	// Initialize configuration
 	cfg := config.NewConfig()
 
+	shutdownTelemetry, metrics, err := telemetry.InitMetrics(cfg.Version)
+	if err != nil {
+		log.Printf("Failed to initialize metrics: %v", err)
+		return
+	}
+
+	defer func() {
+		if err := shutdownTelemetry(context.Background()); err != nil {
+			log.Printf("Failed to shutdown telemetry: %v", err)
+		}
+	}()
+
+	if err := validators.ConfigureRegistries(cfg, metrics, v0auth.NewPublisherKeys(cfg).VerifySignature); err != nil {
+		log.Printf("Failed to configure package registries: %v", err)
+		return
+	}
+
 	// Create a context with timeout for PostgreSQL connection
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
 	// Connect to PostgreSQL
-	db, err = database.NewPostgreSQL(ctx, cfg.DatabaseURL)
+	pg, err := database.NewPostgreSQL(ctx, cfg.DatabaseURL)
 	if err != nil {
 		log.Printf("Failed to connect to PostgreSQL: %v", err)
 		return
 	}
+	db = pg
 
 	// Store the PostgreSQL instance for later cleanup
 	defer func() {
@@ -78,6 +99,27 @@ func main() {
 
 	registryService = service.NewRegistryService(db, cfg)
 
+	// Background jobs stop when the server shuts down
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+
+	// Validate pending publishes in the background
+	if cfg.AsyncPublishValidation && cfg.PublishValidationWorkers > 0 {
+		go service.NewPublishWorker(db, cfg).Run(jobsCtx)
+	}
+
+	// Re-validate the packages of published servers periodically
+	if cfg.PackageRevalidationInterval > 0 {
+		log.Printf("Re-validating published packages every %s", cfg.PackageRevalidationInterval)
+		go service.NewPackageRevalidator(db, cfg).Run(jobsCtx)
+	}
+
+	// Probe the remotes of published servers periodically
+	if cfg.RemoteProbeInterval > 0 {
+		log.Printf("Probing remotes every %s", cfg.RemoteProbeInterval)
+		go service.NewRemoteProber(db, cfg).Run(jobsCtx)
+	}
+
 	// Import seed data if seed source is provided
 	if cfg.SeedFrom != "" {
 		log.Printf("Importing data from %s...", cfg.SeedFrom)
@@ -90,20 +132,24 @@ func main() {
 		}
 	}
 
-	shutdownTelemetry, metrics, err := telemetry.InitMetrics(cfg.Version)
-	if err != nil {
-		log.Printf("Failed to initialize metrics: %v", err)
-		return
-	}
-
-	defer func() {
-		if err := shutdownTelemetry(context.Background()); err != nil {
-			log.Printf("Failed to shutdown telemetry: %v", err)
+	// Initialize rate limiter for publish and auth endpoints
+	var limiter ratelimit.Limiter
+	if cfg.RateLimitEnabled {
+		switch cfg.RateLimitBackend {
+		case "postgres":
+			limiter = ratelimit.NewPostgresLimiter(pg)
+		case "memory":
+			limiter = ratelimit.NewMemoryLimiter()
+		default:
+			log.Printf("Unknown rate limit backend %q", cfg.RateLimitBackend)
+			return
 		}
-	}()
+		log.Printf("Rate limiting enabled with %s backend", cfg.RateLimitBackend)
+	}
 
-	// Initialize HTTP server
-	server := api.NewServer(cfg, registryService, metrics)
+	// Initialize HTTP server. Used auth nonces are stored in PostgreSQL so replays are
+	// detected across replicas.
+	server := api.NewServer(cfg, registryService, metrics, limiter, pg)
 
 	// Start server in a goroutine so it doesn't block signal handling
 	go func() {
@@ -0,0 +1,44 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+)
+
+// ChallengeResponse represents a signature challenge issued to a client
+type ChallengeResponse struct {
+	Nonce     string `json:"nonce" doc:"Single-use nonce to sign for DNS or HTTP authentication"`
+	ExpiresAt int64  `json:"expires_at" doc:"Unix timestamp after which the nonce can no longer be used"`
+}
+
+// RegisterChallengeEndpoint registers the signature challenge endpoint used by DNS and HTTP authentication
+func RegisterChallengeEndpoint(api huma.API, pathPrefix string, cfg *config.Config, nonceStore auth.NonceStore) {
+	nonces := auth.NewNonceManager(cfg, nonceStore)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-auth-challenge" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/auth/challenge",
+		Summary:     "Get a signature challenge",
+		Description: "Issue a single-use nonce for DNS or HTTP authentication. Clients sign 'mcp-registry-auth:<method>:<domain>:<nonce>' with their private key.",
+		Tags:        []string{"auth"},
+	}, func(_ context.Context, _ *struct{}) (*v0.Response[ChallengeResponse], error) {
+		nonce, expiresAt, err := nonces.Issue()
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to issue challenge", err)
+		}
+
+		return &v0.Response[ChallengeResponse]{
+			Body: ChallengeResponse{
+				Nonce:     nonce,
+				ExpiresAt: expiresAt.Unix(),
+			},
+		}, nil
+	})
+}
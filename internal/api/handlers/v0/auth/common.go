@@ -2,23 +2,35 @@ package auth
 
 import (
 	"context"
+	"crypto"
+	"crypto/ecdsa"
 	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
 	"encoding/base64"
 	"encoding/hex"
 	"fmt"
+	"math/big"
 	"regexp"
 	"strings"
 	"time"
 
+	"github.com/danielgtaylor/huma/v2"
 	"github.com/modelcontextprotocol/registry/internal/auth"
 	"github.com/modelcontextprotocol/registry/internal/config"
 )
 
-// SignatureTokenExchangeInput represents the common input structure for token exchange
+// SignatureTokenExchangeInput represents the common input structure for token exchange.
+// Clients sign a challenge nonce (preferred), or a bare timestamp in the legacy flow.
 type SignatureTokenExchangeInput struct {
-	Domain          string `json:"domain" doc:"Domain name" example:"example.com" required:"true"`
-	Timestamp       string `json:"timestamp" doc:"RFC3339 timestamp" example:"2023-01-01T00:00:00Z" required:"true"`
-	SignedTimestamp string `json:"signed_timestamp" doc:"Hex-encoded Ed25519 signature of timestamp" example:"abcdef1234567890" required:"true"`
+	Domain          string            `json:"domain" doc:"Domain name" example:"example.com" required:"true"`
+	Nonce           string            `json:"nonce,omitempty" doc:"Challenge nonce obtained from the auth challenge endpoint" required:"false"`
+	SignedNonce     string            `json:"signed_nonce,omitempty" doc:"Hex-encoded signature of the challenge message 'mcp-registry-auth:<method>:<domain>:<nonce>' (Ed25519, ECDSA P-256 with SHA-256, or RSA with SHA-256)" required:"false"`
+	Timestamp       string            `json:"timestamp,omitempty" doc:"RFC3339 timestamp (legacy flow)" example:"2023-01-01T00:00:00Z" required:"false"`
+	SignedTimestamp string            `json:"signed_timestamp,omitempty" doc:"Hex-encoded signature of timestamp (legacy flow)" example:"abcdef1234567890" required:"false"`
+	Scope           []auth.Permission `json:"scope,omitempty" doc:"Optional scope to narrow the token to, e.g. [{\"action\":\"publish\",\"resource\":\"com.example/my-server\"}]" required:"false"`
 }
 
 // KeyFetcher defines a function type for fetching keys from external sources
@@ -28,6 +40,7 @@ type KeyFetcher func(ctx context.Context, domain string) ([]string, error)
 type CoreAuthHandler struct {
 	config     *config.Config
 	jwtManager *auth.JWTManager
+	nonces     *auth.NonceManager
 }
 
 // NewCoreAuthHandler creates a new core authentication handler
@@ -35,9 +48,29 @@ func NewCoreAuthHandler(cfg *config.Config) *CoreAuthHandler {
 	return &CoreAuthHandler{
 		config:     cfg,
 		jwtManager: auth.NewJWTManager(cfg),
+		nonces:     auth.NewNonceManager(cfg, auth.NewMemoryNonceStore()),
 	}
 }
 
+// SetNonceStore sets the store used to track used challenge nonces
+func (h *CoreAuthHandler) SetNonceStore(store auth.NonceStore) {
+	h.nonces = auth.NewNonceManager(h.config, store)
+}
+
+// validateScope validates a scope requested during token exchange, which the issued token is narrowed to
+func validateScope(scope []auth.Permission) error {
+	if err := auth.ValidateScope(scope); err != nil {
+		return huma.Error400BadRequest("Invalid scope", err)
+	}
+	return nil
+}
+
+// SignatureChallengeMessage builds the message a client signs for the nonce challenge flow.
+// Binding the auth method and domain prevents a signature being reused for another exchange.
+func SignatureChallengeMessage(authMethod auth.Method, domain, nonce string) string {
+	return fmt.Sprintf("mcp-registry-auth:%s:%s:%s", authMethod, domain, nonce)
+}
+
 // ValidateDomainAndTimestamp validates the domain format and timestamp
 func ValidateDomainAndTimestamp(domain, timestamp string) (*time.Time, error) {
 	if !IsValidDomain(domain) {
@@ -58,28 +91,69 @@ func ValidateDomainAndTimestamp(domain, timestamp string) (*time.Time, error) {
 	return &ts, nil
 }
 
+const (
+	// minSignatureSize is the smallest supported signature (Ed25519, or raw ECDSA P-256 r||s)
+	minSignatureSize = ed25519.SignatureSize
+	// maxSignatureSize is the largest supported signature (RSA 8192)
+	maxSignatureSize = 1024
+	// minRSAKeyBits is the smallest RSA modulus accepted in key records
+	minRSAKeyBits = 2048
+)
+
 func DecodeAndValidateSignature(signedTimestamp string) ([]byte, error) {
 	signature, err := hex.DecodeString(signedTimestamp)
 	if err != nil {
 		return nil, fmt.Errorf("invalid signature format, must be hex: %w", err)
 	}
 
-	if len(signature) != ed25519.SignatureSize {
-		return nil, fmt.Errorf("invalid signature length: expected %d, got %d", ed25519.SignatureSize, len(signature))
+	if len(signature) < minSignatureSize || len(signature) > maxSignatureSize {
+		return nil, fmt.Errorf("invalid signature length: expected between %d and %d bytes, got %d", minSignatureSize, maxSignatureSize, len(signature))
 	}
 
 	return signature, nil
 }
 
-func VerifySignatureWithKeys(publicKeys []ed25519.PublicKey, messageBytes []byte, signature []byte) bool {
+// VerifySignatureWithKeys reports whether the signature over messageBytes is valid for any of the keys.
+// Ed25519 signs the message directly; ECDSA P-256 (ASN.1 DER or raw r||s) and RSA (PKCS#1 v1.5 or PSS)
+// sign its SHA-256 digest.
+func VerifySignatureWithKeys(publicKeys []crypto.PublicKey, messageBytes []byte, signature []byte) bool {
+	digest := sha256.Sum256(messageBytes)
+
 	for _, publicKey := range publicKeys {
-		if ed25519.Verify(publicKey, messageBytes, signature) {
-			return true
+		switch key := publicKey.(type) {
+		case ed25519.PublicKey:
+			if len(signature) == ed25519.SignatureSize && ed25519.Verify(key, messageBytes, signature) {
+				return true
+			}
+		case *ecdsa.PublicKey:
+			if verifyECDSASignature(key, digest[:], signature) {
+				return true
+			}
+		case *rsa.PublicKey:
+			if rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature) == nil ||
+				rsa.VerifyPSS(key, crypto.SHA256, digest[:], signature, nil) == nil {
+				return true
+			}
 		}
 	}
 	return false
 }
 
+// verifyECDSASignature accepts both ASN.1 DER signatures and the raw r||s form produced by many HSMs
+func verifyECDSASignature(key *ecdsa.PublicKey, digest, signature []byte) bool {
+	if ecdsa.VerifyASN1(key, digest, signature) {
+		return true
+	}
+
+	size := (key.Curve.Params().BitSize + 7) / 8
+	if len(signature) != 2*size {
+		return false
+	}
+	r := new(big.Int).SetBytes(signature[:size])
+	s := new(big.Int).SetBytes(signature[size:])
+	return ecdsa.Verify(key, digest, r, s)
+}
+
 // BuildPermissions builds permissions for a domain with optional subdomain support
 func BuildPermissions(domain string, includeSubdomains bool) []auth.Permission {
 	reverseDomain := ReverseString(domain)
@@ -102,8 +176,8 @@ func BuildPermissions(domain string, includeSubdomains bool) []auth.Permission {
 	return permissions
 }
 
-// CreateJWTClaimsAndToken creates JWT claims and generates a token response
-func (h *CoreAuthHandler) CreateJWTClaimsAndToken(ctx context.Context, authMethod auth.Method, domain string, permissions []auth.Permission) (*auth.TokenResponse, error) {
+// CreateJWTClaimsAndToken creates JWT claims and generates a token response narrowed to the requested scope, if any
+func (h *CoreAuthHandler) CreateJWTClaimsAndToken(ctx context.Context, authMethod auth.Method, domain string, permissions, scope []auth.Permission) (*auth.TokenResponse, error) {
 	// Create JWT claims
 	jwtClaims := auth.JWTClaims{
 		AuthMethod:        authMethod,
@@ -112,7 +186,7 @@ func (h *CoreAuthHandler) CreateJWTClaimsAndToken(ctx context.Context, authMetho
 	}
 
 	// Generate Registry JWT token
-	tokenResponse, err := h.jwtManager.GenerateTokenResponse(ctx, jwtClaims)
+	tokenResponse, err := h.jwtManager.GenerateScopedTokenResponse(ctx, jwtClaims, scope)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate JWT token: %w", err)
 	}
@@ -121,19 +195,66 @@ func (h *CoreAuthHandler) CreateJWTClaimsAndToken(ctx context.Context, authMetho
 }
 
 // ExchangeToken is a shared method for token exchange that takes a key fetcher function,
-// subdomain inclusion flag, and auth method
+// subdomain inclusion flag, and auth method. This is the legacy flow where the client signs
+// a bare timestamp; it can be disabled with DisableLegacySignatureAuth.
 func (h *CoreAuthHandler) ExchangeToken(
 	ctx context.Context,
 	domain, timestamp, signedTimestamp string,
 	keyFetcher KeyFetcher,
 	includeSubdomains bool,
-	authMethod auth.Method) (*auth.TokenResponse, error) {
+	authMethod auth.Method,
+	scope []auth.Permission) (*auth.TokenResponse, error) {
+	if h.config.DisableLegacySignatureAuth {
+		return nil, fmt.Errorf("timestamp-based authentication is disabled: sign a challenge nonce instead (upgrade mcp-publisher)")
+	}
+
 	_, err := ValidateDomainAndTimestamp(domain, timestamp)
 	if err != nil {
 		return nil, err
 	}
 
-	signature, err := DecodeAndValidateSignature(signedTimestamp)
+	return h.verifySignatureAndIssueToken(ctx, domain, []byte(timestamp), signedTimestamp, keyFetcher, includeSubdomains, authMethod, scope)
+}
+
+// ExchangeNonceToken is a shared method for token exchange where the client signs a
+// server-issued challenge nonce bound to the domain. Each nonce can only be used once.
+func (h *CoreAuthHandler) ExchangeNonceToken(
+	ctx context.Context,
+	domain, nonce, signedNonce string,
+	keyFetcher KeyFetcher,
+	includeSubdomains bool,
+	authMethod auth.Method,
+	scope []auth.Permission) (*auth.TokenResponse, error) {
+	if !IsValidDomain(domain) {
+		return nil, fmt.Errorf("invalid domain format")
+	}
+
+	message := []byte(SignatureChallengeMessage(authMethod, domain, nonce))
+	tokenResponse, err := h.verifySignatureAndIssueToken(ctx, domain, message, signedNonce, keyFetcher, includeSubdomains, authMethod, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only consume the nonce once the signature is verified, so invalid attempts cannot burn it
+	if err := h.nonces.Consume(ctx, nonce); err != nil {
+		return nil, fmt.Errorf("nonce validation failed: %w", err)
+	}
+
+	return tokenResponse, nil
+}
+
+// verifySignatureAndIssueToken verifies the signature over message against the domain's keys
+// and issues a Registry JWT for the domain, narrowed to the requested scope
+func (h *CoreAuthHandler) verifySignatureAndIssueToken(
+	ctx context.Context,
+	domain string,
+	messageBytes []byte,
+	signatureHex string,
+	keyFetcher KeyFetcher,
+	includeSubdomains bool,
+	authMethod auth.Method,
+	scope []auth.Permission) (*auth.TokenResponse, error) {
+	signature, err := DecodeAndValidateSignature(signatureHex)
 	if err != nil {
 		return nil, err
 	}
@@ -150,45 +271,96 @@ func (h *CoreAuthHandler) ExchangeToken(
 			return nil, fmt.Errorf("failed to parse public key")
 		case auth.MethodDNS:
 			return nil, fmt.Errorf("no valid MCP public keys found in DNS TXT records")
-		case auth.MethodGitHubAT, auth.MethodGitHubOIDC, auth.MethodOIDC, auth.MethodNone:
+		case auth.MethodGitHubAT, auth.MethodGitHubOIDC, auth.MethodGitLabOIDC, auth.MethodOIDC, auth.MethodNone:
 			return nil, fmt.Errorf("no valid MCP public keys found using %s authentication", authMethod)
 		}
 	}
 
-	messageBytes := []byte(timestamp)
 	if !VerifySignatureWithKeys(publicKeys, messageBytes, signature) {
 		return nil, fmt.Errorf("signature verification failed")
 	}
 
 	permissions := BuildPermissions(domain, includeSubdomains)
 
-	return h.CreateJWTClaimsAndToken(ctx, authMethod, domain, permissions)
+	return h.CreateJWTClaimsAndToken(ctx, authMethod, domain, permissions, scope)
 }
 
-func ParseMCPKeysFromStrings(inputs []string) []ed25519.PublicKey {
-	var publicKeys []ed25519.PublicKey
-	mcpPattern := regexp.MustCompile(`v=MCPv1;\s*k=ed25519;\s*p=([A-Za-z0-9+/=]+)`)
+var mcpKeyPattern = regexp.MustCompile(`v=MCPv1;\s*k=(ed25519|ecdsap256|rsa);\s*p=([A-Za-z0-9+/=]+)`)
+
+// ParseMCPKeysFromStrings extracts public keys from MCP key records of the form
+// "v=MCPv1; k=<type>; p=<base64 key>". Supported types are ed25519 (raw 32-byte key),
+// ecdsap256 and rsa (DER-encoded SubjectPublicKeyInfo). Invalid records are skipped.
+func ParseMCPKeysFromStrings(inputs []string) []crypto.PublicKey {
+	var publicKeys []crypto.PublicKey
 
 	for _, input := range inputs {
-		matches := mcpPattern.FindStringSubmatch(input)
-		if len(matches) == 2 {
-			// Decode base64 public key
-			publicKeyBytes, err := base64.StdEncoding.DecodeString(matches[1])
-			if err != nil {
-				continue // Skip invalid keys
-			}
+		matches := mcpKeyPattern.FindStringSubmatch(input)
+		if len(matches) != 3 {
+			continue
+		}
 
-			if len(publicKeyBytes) != ed25519.PublicKeySize {
-				continue // Skip invalid key sizes
-			}
+		// Decode base64 public key
+		publicKeyBytes, err := base64.StdEncoding.DecodeString(matches[2])
+		if err != nil {
+			continue // Skip invalid keys
+		}
 
-			publicKeys = append(publicKeys, ed25519.PublicKey(publicKeyBytes))
+		publicKey, err := parseMCPPublicKey(matches[1], publicKeyBytes)
+		if err != nil {
+			continue // Skip invalid keys
 		}
+
+		publicKeys = append(publicKeys, publicKey)
 	}
 
 	return publicKeys
 }
 
+// parseMCPPublicKey decodes the public key bytes for the given key record type
+func parseMCPPublicKey(keyType string, publicKeyBytes []byte) (crypto.PublicKey, error) {
+	switch keyType {
+	case "ed25519":
+		if len(publicKeyBytes) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("invalid ed25519 key size %d", len(publicKeyBytes))
+		}
+		return ed25519.PublicKey(publicKeyBytes), nil
+
+	case "ecdsap256":
+		// Also accept a bare uncompressed point, as exported by some KMS products
+		if key, err := ecdsa.ParseUncompressedPublicKey(elliptic.P256(), publicKeyBytes); err == nil {
+			return key, nil
+		}
+		parsed, err := x509.ParsePKIXPublicKey(publicKeyBytes)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ecdsap256 key: %w", err)
+		}
+		key, ok := parsed.(*ecdsa.PublicKey)
+		if !ok || key.Curve != elliptic.P256() {
+			return nil, fmt.Errorf("ecdsap256 key is not a P-256 key")
+		}
+		return key, nil
+
+	case "rsa":
+		parsed, err := x509.ParsePKIXPublicKey(publicKeyBytes)
+		if err != nil {
+			// Also accept a PKCS#1 RSAPublicKey
+			if parsed, err = x509.ParsePKCS1PublicKey(publicKeyBytes); err != nil {
+				return nil, fmt.Errorf("invalid rsa key: %w", err)
+			}
+		}
+		key, ok := parsed.(*rsa.PublicKey)
+		if !ok {
+			return nil, fmt.Errorf("rsa key is not an RSA key")
+		}
+		if key.N.BitLen() < minRSAKeyBits {
+			return nil, fmt.Errorf("rsa key must be at least %d bits", minRSAKeyBits)
+		}
+		return key, nil
+	}
+
+	return nil, fmt.Errorf("unsupported key type %s", keyType)
+}
+
 // ReverseString reverses a domain string (example.com -> com.example)
 func ReverseString(domain string) string {
 	parts := strings.Split(domain, ".")
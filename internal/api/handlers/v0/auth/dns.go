@@ -11,6 +11,7 @@ import (
 	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
 	"github.com/modelcontextprotocol/registry/internal/auth"
 	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/dnssec"
 )
 
 // DNSTokenExchangeInput represents the input for DNS-based authentication
@@ -37,22 +38,36 @@ type DNSAuthHandler struct {
 	resolver DNSResolver
 }
 
-// NewDNSAuthHandler creates a new DNS authentication handler
+// NewDNSAuthHandler creates a new DNS authentication handler. If DNSSEC is enabled,
+// TXT records are only trusted if the configured resolvers validated them.
 func NewDNSAuthHandler(cfg *config.Config) *DNSAuthHandler {
 	return &DNSAuthHandler{
 		CoreAuthHandler: *NewCoreAuthHandler(cfg),
-		resolver:        &DefaultDNSResolver{},
+		resolver:        newDNSResolver(cfg),
 	}
 }
 
+// newDNSResolver returns the resolver for key TXT records, validating DNSSEC if enabled
+func newDNSResolver(cfg *config.Config) DNSResolver {
+	if !cfg.DNSAuthDNSSEC {
+		return &DefaultDNSResolver{}
+	}
+	dnssecResolver, err := dnssec.NewResolver(cfg.DNSAuthResolvers)
+	if err != nil {
+		panic(fmt.Sprintf("Failed to initialize DNSSEC resolver: %v", err))
+	}
+	return dnssecResolver
+}
+
 // SetResolver sets a custom DNS resolver (used for testing)
 func (h *DNSAuthHandler) SetResolver(resolver DNSResolver) {
 	h.resolver = resolver
 }
 
 // RegisterDNSEndpoint registers the DNS authentication endpoint
-func RegisterDNSEndpoint(api huma.API, pathPrefix string, cfg *config.Config) {
+func RegisterDNSEndpoint(api huma.API, pathPrefix string, cfg *config.Config, nonceStore auth.NonceStore) {
 	handler := NewDNSAuthHandler(cfg)
+	handler.SetNonceStore(nonceStore)
 
 	// DNS authentication endpoint
 	huma.Register(api, huma.Operation{
@@ -60,10 +75,20 @@ func RegisterDNSEndpoint(api huma.API, pathPrefix string, cfg *config.Config) {
 		Method:      http.MethodPost,
 		Path:        pathPrefix + "/auth/dns",
 		Summary:     "Exchange DNS signature for Registry JWT",
-		Description: "Authenticate using DNS TXT record public key and signed timestamp",
+		Description: "Authenticate using DNS TXT record public key and a signed challenge nonce (or signed timestamp in the legacy flow)",
 		Tags:        []string{"auth"},
 	}, func(ctx context.Context, input *DNSTokenExchangeInput) (*v0.Response[auth.TokenResponse], error) {
-		response, err := handler.ExchangeToken(ctx, input.Body.Domain, input.Body.Timestamp, input.Body.SignedTimestamp)
+		if err := validateScope(input.Body.Scope); err != nil {
+			return nil, err
+		}
+
+		var response *auth.TokenResponse
+		var err error
+		if input.Body.Nonce != "" {
+			response, err = handler.ExchangeNonceToken(ctx, input.Body.Domain, input.Body.Nonce, input.Body.SignedNonce, input.Body.Scope)
+		} else {
+			response, err = handler.ExchangeToken(ctx, input.Body.Domain, input.Body.Timestamp, input.Body.SignedTimestamp, input.Body.Scope)
+		}
 		if err != nil {
 			return nil, huma.Error401Unauthorized("DNS authentication failed", err)
 		}
@@ -74,20 +99,26 @@ func RegisterDNSEndpoint(api huma.API, pathPrefix string, cfg *config.Config) {
 	})
 }
 
-// ExchangeToken exchanges DNS signature for a Registry JWT token
-func (h *DNSAuthHandler) ExchangeToken(ctx context.Context, domain, timestamp, signedTimestamp string) (*auth.TokenResponse, error) {
-	keyFetcher := func(ctx context.Context, domain string) ([]string, error) {
-		// Lookup DNS TXT records
-		// DNS implies a hierarchy where subdomains are treated as part of the parent domain,
-		// therefore we grant permissions for all subdomains (e.g., com.example.*)
-		// This is in line with other DNS-based authentication methods e.g. ACME DNS-01 challenges
-		txtRecords, err := h.resolver.LookupTXT(ctx, domain)
-		if err != nil {
-			return nil, fmt.Errorf("failed to lookup DNS TXT records: %w", err)
-		}
-		return txtRecords, nil
-	}
+// ExchangeToken exchanges a DNS signature of a timestamp for a Registry JWT token (legacy flow)
+func (h *DNSAuthHandler) ExchangeToken(ctx context.Context, domain, timestamp, signedTimestamp string, scope []auth.Permission) (*auth.TokenResponse, error) {
+	// DNS implies a hierarchy where subdomains are treated as part of the parent domain,
+	// therefore we grant permissions for all subdomains (e.g., com.example.*)
+	// This is in line with other DNS-based authentication methods e.g. ACME DNS-01 challenges
+	allowSubdomains := true
+	return h.CoreAuthHandler.ExchangeToken(ctx, domain, timestamp, signedTimestamp, h.fetchKeys, allowSubdomains, auth.MethodDNS, scope)
+}
 
+// ExchangeNonceToken exchanges a DNS signature of a challenge nonce for a Registry JWT token
+func (h *DNSAuthHandler) ExchangeNonceToken(ctx context.Context, domain, nonce, signedNonce string, scope []auth.Permission) (*auth.TokenResponse, error) {
 	allowSubdomains := true
-	return h.CoreAuthHandler.ExchangeToken(ctx, domain, timestamp, signedTimestamp, keyFetcher, allowSubdomains, auth.MethodDNS)
+	return h.CoreAuthHandler.ExchangeNonceToken(ctx, domain, nonce, signedNonce, h.fetchKeys, allowSubdomains, auth.MethodDNS, scope)
+}
+
+// fetchKeys looks up the DNS TXT records for the domain
+func (h *DNSAuthHandler) fetchKeys(ctx context.Context, domain string) ([]string, error) {
+	txtRecords, err := h.resolver.LookupTXT(ctx, domain)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lookup DNS TXT records: %w", err)
+	}
+	return txtRecords, nil
 }
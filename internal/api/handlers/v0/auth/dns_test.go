@@ -2,10 +2,18 @@ package auth_test
 
 import (
 	"context"
+	"crypto"
+	"crypto/ecdsa"
 	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
 	"encoding/base64"
 	"encoding/hex"
 	"fmt"
+	"net"
 	"strings"
 	"testing"
 	"time"
@@ -155,7 +163,7 @@ func TestDNSAuthHandler_ExchangeToken(t *testing.T) {
 			}
 
 			// Call the handler
-			result, err := handler.ExchangeToken(context.Background(), tt.domain, tt.timestamp, signedTimestamp)
+			result, err := handler.ExchangeToken(context.Background(), tt.domain, tt.timestamp, signedTimestamp, nil)
 
 			if tt.expectError {
 				assert.Error(t, err)
@@ -195,6 +203,245 @@ func TestDNSAuthHandler_ExchangeToken(t *testing.T) {
 	}
 }
 
+func TestDNSAuthHandler_ExchangeNonceToken(t *testing.T) {
+	ctx := context.Background()
+	cfg := &config.Config{
+		JWTPrivateKey: "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+	}
+	handler := auth.NewDNSAuthHandler(cfg)
+	nonces := intauth.NewNonceManager(cfg, intauth.NewMemoryNonceStore())
+
+	publicKey, privateKey, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	handler.SetResolver(&MockDNSResolver{
+		txtRecords: map[string][]string{
+			testDomain: {fmt.Sprintf("v=MCPv1; k=ed25519; p=%s", base64.StdEncoding.EncodeToString(publicKey))},
+		},
+	})
+
+	sign := func(method intauth.Method, domain, nonce string) string {
+		return hex.EncodeToString(ed25519.Sign(privateKey, []byte(auth.SignatureChallengeMessage(method, domain, nonce))))
+	}
+
+	t.Run("signed nonce is exchanged once", func(t *testing.T) {
+		nonce, _, err := nonces.Issue()
+		require.NoError(t, err)
+		signature := sign(intauth.MethodDNS, testDomain, nonce)
+
+		result, err := handler.ExchangeNonceToken(ctx, testDomain, nonce, signature, nil)
+		require.NoError(t, err)
+		assert.NotEmpty(t, result.RegistryToken)
+
+		_, err = handler.ExchangeNonceToken(ctx, testDomain, nonce, signature, nil)
+		assert.ErrorIs(t, err, intauth.ErrUsedNonce)
+	})
+
+	t.Run("invalid signature does not consume the nonce", func(t *testing.T) {
+		nonce, _, err := nonces.Issue()
+		require.NoError(t, err)
+
+		_, err = handler.ExchangeNonceToken(ctx, testDomain, nonce, sign(intauth.MethodDNS, "other.com", nonce), nil)
+		assert.ErrorContains(t, err, "signature verification failed")
+
+		_, err = handler.ExchangeNonceToken(ctx, testDomain, nonce, sign(intauth.MethodDNS, testDomain, nonce), nil)
+		assert.NoError(t, err)
+	})
+
+	t.Run("signature for another method is rejected", func(t *testing.T) {
+		nonce, _, err := nonces.Issue()
+		require.NoError(t, err)
+
+		_, err = handler.ExchangeNonceToken(ctx, testDomain, nonce, sign(intauth.MethodHTTP, testDomain, nonce), nil)
+		assert.ErrorContains(t, err, "signature verification failed")
+	})
+
+	t.Run("forged nonce is rejected", func(t *testing.T) {
+		nonce := "deadbeef.9999999999.cafe"
+		_, err := handler.ExchangeNonceToken(ctx, testDomain, nonce, sign(intauth.MethodDNS, testDomain, nonce), nil)
+		assert.ErrorIs(t, err, intauth.ErrInvalidNonce)
+	})
+
+	t.Run("legacy timestamp flow can be disabled", func(t *testing.T) {
+		legacyDisabled := auth.NewDNSAuthHandler(&config.Config{
+			JWTPrivateKey:              cfg.JWTPrivateKey,
+			DisableLegacySignatureAuth: true,
+		})
+		timestamp := time.Now().UTC().Format(time.RFC3339)
+		signedTimestamp := hex.EncodeToString(ed25519.Sign(privateKey, []byte(timestamp)))
+
+		_, err := legacyDisabled.ExchangeToken(ctx, testDomain, timestamp, signedTimestamp, nil)
+		assert.ErrorContains(t, err, "timestamp-based authentication is disabled")
+	})
+}
+
+func TestDNSAuthHandler_KeyTypes(t *testing.T) {
+	ctx := context.Background()
+	cfg := &config.Config{
+		JWTPrivateKey: "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+	}
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	ecDER, err := x509.MarshalPKIXPublicKey(&ecKey.PublicKey)
+	require.NoError(t, err)
+	ecPoint, err := ecKey.PublicKey.Bytes()
+	require.NoError(t, err)
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	rsaDER, err := x509.MarshalPKIXPublicKey(&rsaKey.PublicKey)
+	require.NoError(t, err)
+
+	smallRSAKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+	smallRSADER, err := x509.MarshalPKIXPublicKey(&smallRSAKey.PublicKey)
+	require.NoError(t, err)
+
+	p384Key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+	p384DER, err := x509.MarshalPKIXPublicKey(&p384Key.PublicKey)
+	require.NoError(t, err)
+
+	record := func(keyType string, der []byte) string {
+		return fmt.Sprintf("v=MCPv1; k=%s; p=%s", keyType, base64.StdEncoding.EncodeToString(der))
+	}
+	digestOf := func(message string) []byte {
+		digest := sha256.Sum256([]byte(message))
+		return digest[:]
+	}
+
+	tests := []struct {
+		name          string
+		record        string
+		sign          func(message string) []byte
+		errorContains string
+	}{
+		{
+			name:   "ECDSA P-256 with DER signature",
+			record: record("ecdsap256", ecDER),
+			sign: func(message string) []byte {
+				signature, err := ecdsa.SignASN1(rand.Reader, ecKey, digestOf(message))
+				require.NoError(t, err)
+				return signature
+			},
+		},
+		{
+			name:   "ECDSA P-256 uncompressed point with raw r||s signature",
+			record: record("ecdsap256", ecPoint),
+			sign: func(message string) []byte {
+				r, s, err := ecdsa.Sign(rand.Reader, ecKey, digestOf(message))
+				require.NoError(t, err)
+				signature := make([]byte, 64)
+				r.FillBytes(signature[:32])
+				s.FillBytes(signature[32:])
+				return signature
+			},
+		},
+		{
+			name:   "RSA PKCS#1 v1.5",
+			record: record("rsa", rsaDER),
+			sign: func(message string) []byte {
+				signature, err := rsa.SignPKCS1v15(rand.Reader, rsaKey, crypto.SHA256, digestOf(message))
+				require.NoError(t, err)
+				return signature
+			},
+		},
+		{
+			name:   "RSA PSS",
+			record: record("rsa", rsaDER),
+			sign: func(message string) []byte {
+				signature, err := rsa.SignPSS(rand.Reader, rsaKey, crypto.SHA256, digestOf(message), nil)
+				require.NoError(t, err)
+				return signature
+			},
+		},
+		{
+			name:   "RSA key below 2048 bits is ignored",
+			record: record("rsa", smallRSADER),
+			sign: func(message string) []byte {
+				signature, err := rsa.SignPKCS1v15(rand.Reader, smallRSAKey, crypto.SHA256, digestOf(message))
+				require.NoError(t, err)
+				return signature
+			},
+			errorContains: "no valid MCP public keys found",
+		},
+		{
+			name:   "non P-256 curve is ignored",
+			record: record("ecdsap256", p384DER),
+			sign: func(message string) []byte {
+				signature, err := ecdsa.SignASN1(rand.Reader, p384Key, digestOf(message))
+				require.NoError(t, err)
+				return signature
+			},
+			errorContains: "no valid MCP public keys found",
+		},
+		{
+			name:   "key type mismatch is ignored",
+			record: record("ecdsap256", rsaDER),
+			sign: func(message string) []byte {
+				signature, err := rsa.SignPKCS1v15(rand.Reader, rsaKey, crypto.SHA256, digestOf(message))
+				require.NoError(t, err)
+				return signature
+			},
+			errorContains: "no valid MCP public keys found",
+		},
+		{
+			name:   "signature from another key is rejected",
+			record: record("ecdsap256", ecDER),
+			sign: func(message string) []byte {
+				signature, err := ecdsa.SignASN1(rand.Reader, p384Key, digestOf(message))
+				require.NoError(t, err)
+				return signature
+			},
+			errorContains: "signature verification failed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := auth.NewDNSAuthHandler(cfg)
+			handler.SetResolver(&MockDNSResolver{
+				txtRecords: map[string][]string{testDomain: {tt.record}},
+			})
+
+			timestamp := time.Now().UTC().Format(time.RFC3339)
+			result, err := handler.ExchangeToken(ctx, testDomain, timestamp, hex.EncodeToString(tt.sign(timestamp)), nil)
+			if tt.errorContains != "" {
+				assert.ErrorContains(t, err, tt.errorContains)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotEmpty(t, result.RegistryToken)
+		})
+	}
+}
+
+func TestNewDNSAuthHandler_DNSSEC(t *testing.T) {
+	t.Run("invalid resolver configuration panics", func(t *testing.T) {
+		assert.Panics(t, func() {
+			auth.NewDNSAuthHandler(&config.Config{DNSAuthDNSSEC: true, DNSAuthResolvers: []string{"udp://1.1.1.1"}})
+		})
+	})
+
+	t.Run("lookups go through the configured resolvers", func(t *testing.T) {
+		// Nothing listens on this port, so the lookup fails without falling back to the system resolver
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		addr := listener.Addr().String()
+		require.NoError(t, listener.Close())
+
+		handler := auth.NewDNSAuthHandler(&config.Config{
+			JWTPrivateKey:    "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+			DNSAuthDNSSEC:    true,
+			DNSAuthResolvers: []string{"tcp://" + addr},
+		})
+		timestamp := time.Now().UTC().Format(time.RFC3339)
+		_, err = handler.ExchangeToken(context.Background(), testDomain, timestamp, strings.Repeat("ab", 64), nil)
+		assert.ErrorContains(t, err, "failed to lookup DNS TXT records")
+		assert.ErrorContains(t, err, addr)
+	})
+}
+
 func TestDNSAuthHandler_Permissions(t *testing.T) {
 	cfg := &config.Config{
 		JWTPrivateKey: "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
@@ -293,7 +540,7 @@ func TestDNSAuthHandler_Permissions(t *testing.T) {
 			signedTimestamp := hex.EncodeToString(signature)
 
 			// Exchange token
-			result, err := handler.ExchangeToken(context.Background(), tt.domain, timestamp, signedTimestamp)
+			result, err := handler.ExchangeToken(context.Background(), tt.domain, timestamp, signedTimestamp, nil)
 			require.NoError(t, err)
 			require.NotNil(t, result)
 
@@ -384,7 +631,7 @@ func TestDNSAuthHandler_PermissionValidation(t *testing.T) {
 	signature := ed25519.Sign(privateKey, []byte(timestamp))
 	signedTimestamp := hex.EncodeToString(signature)
 
-	result, err := handler.ExchangeToken(context.Background(), domain, timestamp, signedTimestamp)
+	result, err := handler.ExchangeToken(context.Background(), domain, timestamp, signedTimestamp, nil)
 	require.NoError(t, err)
 
 	claims, err := jwtManager.ValidateToken(context.Background(), result.RegistryToken)
@@ -18,7 +18,8 @@ import (
 // GitHubTokenExchangeInput represents the input for GitHub token exchange
 type GitHubTokenExchangeInput struct {
 	Body struct {
-		GitHubToken string `json:"github_token" doc:"GitHub OAuth token" required:"true"`
+		GitHubToken string            `json:"github_token" doc:"GitHub OAuth token" required:"true"`
+		Scope       []auth.Permission `json:"scope,omitempty" doc:"Optional scope to narrow the token to, e.g. [{\"action\":\"publish\",\"resource\":\"io.github.octo-org/my-server\"}]" required:"false"`
 	}
 }
 
@@ -56,7 +57,11 @@ func RegisterGitHubATEndpoint(api huma.API, pathPrefix string, cfg *config.Confi
 		Description: "Exchange a GitHub OAuth access token for a short-lived Registry JWT token",
 		Tags:        []string{"auth"},
 	}, func(ctx context.Context, input *GitHubTokenExchangeInput) (*v0.Response[auth.TokenResponse], error) {
-		response, err := handler.ExchangeToken(ctx, input.Body.GitHubToken)
+		if err := validateScope(input.Body.Scope); err != nil {
+			return nil, err
+		}
+
+		response, err := handler.ExchangeToken(ctx, input.Body.GitHubToken, input.Body.Scope)
 		if err != nil {
 			return nil, huma.Error401Unauthorized("Token exchange failed", err)
 		}
@@ -68,7 +73,7 @@ func RegisterGitHubATEndpoint(api huma.API, pathPrefix string, cfg *config.Confi
 }
 
 // ExchangeToken exchanges a GitHub OAuth token for a Registry JWT token
-func (h *GitHubHandler) ExchangeToken(ctx context.Context, githubToken string) (*auth.TokenResponse, error) {
+func (h *GitHubHandler) ExchangeToken(ctx context.Context, githubToken string, scope []auth.Permission) (*auth.TokenResponse, error) {
 	// Get GitHub user information
 	user, err := h.getGitHubUser(ctx, githubToken)
 	if err != nil {
@@ -92,7 +97,7 @@ func (h *GitHubHandler) ExchangeToken(ctx context.Context, githubToken string) (
 	}
 
 	// Generate Registry JWT token
-	tokenResponse, err := h.jwtManager.GenerateTokenResponse(ctx, claims)
+	tokenResponse, err := h.jwtManager.GenerateScopedTokenResponse(ctx, claims, scope)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate JWT token: %w", err)
 	}
@@ -66,7 +66,7 @@ func TestGitHubHandler_ExchangeToken(t *testing.T) {
 
 		// Test token exchange
 		ctx := context.Background()
-		response, err := handler.ExchangeToken(ctx, "valid-github-token")
+		response, err := handler.ExchangeToken(ctx, "valid-github-token", nil)
 
 		require.NoError(t, err)
 		assert.NotNil(t, response)
@@ -114,7 +114,7 @@ func TestGitHubHandler_ExchangeToken(t *testing.T) {
 
 		// Test token exchange
 		ctx := context.Background()
-		response, err := handler.ExchangeToken(ctx, "valid-github-token")
+		response, err := handler.ExchangeToken(ctx, "valid-github-token", nil)
 
 		require.NoError(t, err)
 		assert.NotNil(t, response)
@@ -152,7 +152,7 @@ func TestGitHubHandler_ExchangeToken(t *testing.T) {
 
 		// Test token exchange
 		ctx := context.Background()
-		response, err := handler.ExchangeToken(ctx, "invalid-token")
+		response, err := handler.ExchangeToken(ctx, "invalid-token", nil)
 
 		require.Error(t, err)
 		assert.Nil(t, response)
@@ -176,7 +176,7 @@ func TestGitHubHandler_ExchangeToken(t *testing.T) {
 
 		// Test token exchange
 		ctx := context.Background()
-		response, err := handler.ExchangeToken(ctx, "valid-token")
+		response, err := handler.ExchangeToken(ctx, "valid-token", nil)
 
 		require.Error(t, err)
 		assert.Nil(t, response)
@@ -207,7 +207,7 @@ func TestGitHubHandler_ExchangeToken(t *testing.T) {
 
 		// Test token exchange
 		ctx := context.Background()
-		response, err := handler.ExchangeToken(ctx, "valid-token")
+		response, err := handler.ExchangeToken(ctx, "valid-token", nil)
 
 		require.Error(t, err)
 		assert.Nil(t, response)
@@ -239,7 +239,7 @@ func TestGitHubHandler_ExchangeToken(t *testing.T) {
 
 		// Test token exchange
 		ctx := context.Background()
-		response, err := handler.ExchangeToken(ctx, "valid-token")
+		response, err := handler.ExchangeToken(ctx, "valid-token", nil)
 
 		require.NoError(t, err)
 		assert.NotNil(t, response)
@@ -280,7 +280,7 @@ func TestGitHubHandler_ExchangeToken(t *testing.T) {
 
 		// Test token exchange
 		ctx := context.Background()
-		response, err := handler.ExchangeToken(ctx, "valid-token")
+		response, err := handler.ExchangeToken(ctx, "valid-token", nil)
 
 		require.NoError(t, err)
 		assert.NotNil(t, response)
@@ -309,7 +309,7 @@ func TestGitHubHandler_ExchangeToken(t *testing.T) {
 
 		// Test token exchange
 		ctx := context.Background()
-		response, err := handler.ExchangeToken(ctx, "valid-token")
+		response, err := handler.ExchangeToken(ctx, "valid-token", nil)
 
 		require.Error(t, err)
 		assert.Nil(t, response)
@@ -550,7 +550,7 @@ func TestValidGitHubNames(t *testing.T) {
 
 			// Test token exchange
 			ctx := context.Background()
-			response, err := handler.ExchangeToken(ctx, "valid-token")
+			response, err := handler.ExchangeToken(ctx, "valid-token", nil)
 			require.NoError(t, err)
 
 			// Validate the JWT token and check permissions
@@ -613,7 +613,7 @@ func TestConcurrentTokenExchange(t *testing.T) {
 	for i := 0; i < concurrency; i++ {
 		go func() {
 			ctx := context.Background()
-			_, err := handler.ExchangeToken(ctx, fmt.Sprintf("token-%d", i))
+			_, err := handler.ExchangeToken(ctx, fmt.Sprintf("token-%d", i), nil)
 			errors <- err
 		}()
 	}
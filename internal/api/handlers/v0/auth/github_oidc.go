@@ -2,12 +2,8 @@ package auth
 
 import (
 	"context"
-	"crypto/rsa"
-	"encoding/base64"
-	"encoding/json"
+	"crypto"
 	"fmt"
-	"io"
-	"math/big"
 	"net/http"
 	"strings"
 
@@ -21,7 +17,8 @@ import (
 // GitHubOIDCTokenExchangeInput represents the input for GitHub OIDC token exchange
 type GitHubOIDCTokenExchangeInput struct {
 	Body struct {
-		OIDCToken string `json:"oidc_token" doc:"GitHub Actions OIDC token" required:"true"`
+		OIDCToken string            `json:"oidc_token" doc:"GitHub Actions OIDC token" required:"true"`
+		Scope     []auth.Permission `json:"scope,omitempty" doc:"Optional scope to narrow the token to, e.g. [{\"action\":\"publish\",\"resource\":\"io.github.octo-org/my-server\"}]" required:"false"`
 	}
 }
 
@@ -31,20 +28,6 @@ type GitHubOIDCClaims struct {
 	RepositoryOwner string `json:"repository_owner"` // e.g., "octo-org"
 }
 
-// JWKS represents a JSON Web Key Set
-type JWKS struct {
-	Keys []JWK `json:"keys"`
-}
-
-// JWK represents a JSON Web Key
-type JWK struct {
-	KTY string `json:"kty"`
-	KID string `json:"kid"`
-	Use string `json:"use"`
-	N   string `json:"n"`
-	E   string `json:"e"`
-}
-
 // OIDCValidator defines the interface for OIDC token validation
 type OIDCValidator interface {
 	ValidateToken(ctx context.Context, token string, audience string) (*GitHubOIDCClaims, error)
@@ -52,23 +35,23 @@ type OIDCValidator interface {
 
 // GitHubOIDCValidator validates GitHub OIDC tokens
 type GitHubOIDCValidator struct {
-	jwksURL string
-	issuer  string
+	jwks   *jwksCache
+	issuer string
 }
 
 // NewGitHubOIDCValidator creates a new GitHub OIDC validator
 func NewGitHubOIDCValidator() *GitHubOIDCValidator {
 	return &GitHubOIDCValidator{
-		jwksURL: "https://token.actions.githubusercontent.com/.well-known/jwks",
-		issuer:  "https://token.actions.githubusercontent.com",
+		jwks:   newJWKSCache("https://token.actions.githubusercontent.com/.well-known/jwks"),
+		issuer: "https://token.actions.githubusercontent.com",
 	}
 }
 
 // NewMockOIDCValidator creates a mock validator for testing
 func NewMockOIDCValidator(jwksURL, issuer string) *GitHubOIDCValidator {
 	return &GitHubOIDCValidator{
-		jwksURL: jwksURL,
-		issuer:  issuer,
+		jwks:   newJWKSCache(jwksURL),
+		issuer: issuer,
 	}
 }
 
@@ -93,7 +76,7 @@ func (v *GitHubOIDCValidator) ValidateToken(ctx context.Context, tokenString str
 
 			return publicKey, nil
 		},
-		jwt.WithValidMethods([]string{"RS256"}),
+		jwt.WithValidMethods(jwksSigningMethods),
 		jwt.WithExpirationRequired(),
 	)
 
@@ -135,74 +118,9 @@ func (v *GitHubOIDCValidator) ValidateToken(ctx context.Context, tokenString str
 	return claims, nil
 }
 
-// fetchJWKS fetches the JSON Web Key Set from GitHub
-func (v *GitHubOIDCValidator) fetchJWKS(ctx context.Context) (*JWKS, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, v.jwksURL, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch JWKS: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("JWKS endpoint returned status %d: %s", resp.StatusCode, body)
-	}
-
-	var jwks JWKS
-	if err := json.NewDecoder(resp.Body).Decode(&jwks); err != nil {
-		return nil, fmt.Errorf("failed to decode JWKS: %w", err)
-	}
-
-	return &jwks, nil
-}
-
-// getPublicKey extracts the RSA public key for the given key ID
-func (v *GitHubOIDCValidator) getPublicKey(ctx context.Context, kid string) (*rsa.PublicKey, error) {
-	// Fetch JWKS from GitHub
-	jwks, err := v.fetchJWKS(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch JWKS: %w", err)
-	}
-
-	for _, key := range jwks.Keys {
-		if key.KID == kid {
-			return v.parseRSAPublicKey(key)
-		}
-	}
-	return nil, fmt.Errorf("key with ID %s not found", kid)
-}
-
-// parseRSAPublicKey converts JWK to RSA public key
-func (v *GitHubOIDCValidator) parseRSAPublicKey(jwk JWK) (*rsa.PublicKey, error) {
-	if jwk.KTY != "RSA" {
-		return nil, fmt.Errorf("invalid key type: expected RSA, got %s", jwk.KTY)
-	}
-
-	// Decode modulus (n)
-	nBytes, err := base64.RawURLEncoding.DecodeString(jwk.N)
-	if err != nil {
-		return nil, fmt.Errorf("failed to decode modulus: %w", err)
-	}
-
-	// Decode exponent (e)
-	eBytes, err := base64.RawURLEncoding.DecodeString(jwk.E)
-	if err != nil {
-		return nil, fmt.Errorf("failed to decode exponent: %w", err)
-	}
-
-	// Convert to big integers
-	n := new(big.Int).SetBytes(nBytes)
-	e := new(big.Int).SetBytes(eBytes)
-
-	return &rsa.PublicKey{
-		N: n,
-		E: int(e.Int64()),
-	}, nil
+// getPublicKey returns the public key for the given key ID from the cached JWKS
+func (v *GitHubOIDCValidator) getPublicKey(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	return v.jwks.getPublicKey(ctx, kid)
 }
 
 // GitHubOIDCHandler handles GitHub OIDC authentication
@@ -239,7 +157,11 @@ func RegisterGitHubOIDCEndpoint(api huma.API, pathPrefix string, cfg *config.Con
 		Description: "Exchange a GitHub Actions OIDC token for a short-lived Registry JWT token",
 		Tags:        []string{"auth"},
 	}, func(ctx context.Context, input *GitHubOIDCTokenExchangeInput) (*v0.Response[auth.TokenResponse], error) {
-		response, err := handler.ExchangeToken(ctx, input.Body.OIDCToken)
+		if err := validateScope(input.Body.Scope); err != nil {
+			return nil, err
+		}
+
+		response, err := handler.ExchangeToken(ctx, input.Body.OIDCToken, input.Body.Scope)
 		if err != nil {
 			return nil, huma.Error401Unauthorized("Token exchange failed", err)
 		}
@@ -251,7 +173,7 @@ func RegisterGitHubOIDCEndpoint(api huma.API, pathPrefix string, cfg *config.Con
 }
 
 // ExchangeToken exchanges a GitHub OIDC token for a Registry JWT token
-func (h *GitHubOIDCHandler) ExchangeToken(ctx context.Context, oidcToken string) (*auth.TokenResponse, error) {
+func (h *GitHubOIDCHandler) ExchangeToken(ctx context.Context, oidcToken string, scope []auth.Permission) (*auth.TokenResponse, error) {
 	// Validate OIDC token with audience "mcp-registry"
 	claims, err := h.validator.ValidateToken(ctx, oidcToken, "mcp-registry")
 	if err != nil {
@@ -269,7 +191,7 @@ func (h *GitHubOIDCHandler) ExchangeToken(ctx context.Context, oidcToken string)
 	}
 
 	// Generate Registry JWT token
-	tokenResponse, err := h.jwtManager.GenerateTokenResponse(ctx, jwtClaims)
+	tokenResponse, err := h.jwtManager.GenerateScopedTokenResponse(ctx, jwtClaims, scope)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate JWT token: %w", err)
 	}
@@ -89,7 +89,7 @@ func TestGitHubOIDCHandler_ExchangeToken(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			handler.SetValidator(tt.mockValidator)
 
-			response, err := handler.ExchangeToken(context.Background(), "test-oidc-token")
+			response, err := handler.ExchangeToken(context.Background(), "test-oidc-token", nil)
 
 			if tt.expectError {
 				assert.Error(t, err)
@@ -189,7 +189,7 @@ func TestBuildPermissionsFromOIDC(t *testing.T) {
 			}
 			handler.SetValidator(mockValidator)
 
-			response, err := handler.ExchangeToken(context.Background(), "test-token")
+			response, err := handler.ExchangeToken(context.Background(), "test-token", nil)
 
 			if tt.expectedPerms == nil {
 				// For invalid names, we expect empty permissions but successful token generation
@@ -0,0 +1,196 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/golang-jwt/jwt/v5"
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+)
+
+// GitLabOIDCTokenExchangeInput represents the input for GitLab OIDC token exchange
+type GitLabOIDCTokenExchangeInput struct {
+	Body struct {
+		OIDCToken string            `json:"oidc_token" doc:"GitLab CI ID token with audience 'mcp-registry'" required:"true"`
+		Scope     []auth.Permission `json:"scope,omitempty" doc:"Optional scope to narrow the token to, e.g. [{\"action\":\"publish\",\"resource\":\"io.gitlab.my-group/my-server\"}]" required:"false"`
+	}
+}
+
+// GitLabOIDCClaims represents the claims we need from a GitLab CI ID token
+type GitLabOIDCClaims struct {
+	jwt.RegisteredClaims
+	NamespacePath string `json:"namespace_path"` // e.g., "my-group/my-subgroup"
+	ProjectPath   string `json:"project_path"`   // e.g., "my-group/my-subgroup/my-project"
+}
+
+// GitLabOIDCTokenValidator defines the interface for GitLab ID token validation
+type GitLabOIDCTokenValidator interface {
+	ValidateToken(ctx context.Context, token string, audience string) (*GitLabOIDCClaims, error)
+}
+
+// GitLabOIDCValidator validates GitLab CI ID tokens
+type GitLabOIDCValidator struct {
+	jwks   *jwksCache
+	issuer string
+}
+
+// NewGitLabOIDCValidator creates a new GitLab OIDC validator for the given GitLab instance
+func NewGitLabOIDCValidator(issuer string) *GitLabOIDCValidator {
+	issuer = strings.TrimSuffix(issuer, "/")
+	return &GitLabOIDCValidator{
+		jwks:   newJWKSCache(issuer + "/oauth/discovery/keys"),
+		issuer: issuer,
+	}
+}
+
+// ValidateToken validates a GitLab CI ID token
+func (v *GitLabOIDCValidator) ValidateToken(ctx context.Context, tokenString string, audience string) (*GitLabOIDCClaims, error) {
+	token, err := jwt.ParseWithClaims(
+		tokenString,
+		&GitLabOIDCClaims{},
+		func(token *jwt.Token) (any, error) {
+			kid, ok := token.Header["kid"].(string)
+			if !ok {
+				return nil, fmt.Errorf("missing kid in token header")
+			}
+
+			publicKey, err := v.jwks.getPublicKey(ctx, kid)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get public key: %w", err)
+			}
+
+			return publicKey, nil
+		},
+		jwt.WithValidMethods(jwksSigningMethods),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuer(v.issuer),
+		jwt.WithAudience(audience),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse token: %w", err)
+	}
+	if !token.Valid {
+		return nil, fmt.Errorf("invalid token")
+	}
+
+	claims, ok := token.Claims.(*GitLabOIDCClaims)
+	if !ok {
+		return nil, fmt.Errorf("invalid token claims")
+	}
+
+	if claims.NamespacePath == "" {
+		return nil, fmt.Errorf("namespace_path claim is required")
+	}
+
+	return claims, nil
+}
+
+// GitLabOIDCHandler handles GitLab CI OIDC authentication
+type GitLabOIDCHandler struct {
+	config     *config.Config
+	jwtManager *auth.JWTManager
+	validator  GitLabOIDCTokenValidator
+}
+
+// NewGitLabOIDCHandler creates a new GitLab OIDC handler
+func NewGitLabOIDCHandler(cfg *config.Config) *GitLabOIDCHandler {
+	return &GitLabOIDCHandler{
+		config:     cfg,
+		jwtManager: auth.NewJWTManager(cfg),
+		validator:  NewGitLabOIDCValidator(cfg.GitLabOIDCIssuer),
+	}
+}
+
+// SetValidator sets a custom GitLab token validator (used for testing)
+func (h *GitLabOIDCHandler) SetValidator(validator GitLabOIDCTokenValidator) {
+	h.validator = validator
+}
+
+// RegisterGitLabOIDCEndpoint registers the GitLab OIDC authentication endpoint
+func RegisterGitLabOIDCEndpoint(api huma.API, pathPrefix string, cfg *config.Config) {
+	handler := NewGitLabOIDCHandler(cfg)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "exchange-gitlab-oidc-token" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/auth/gitlab-oidc",
+		Summary:     "Exchange GitLab CI ID token for Registry JWT",
+		Description: "Exchange a GitLab CI/CD ID token for a short-lived Registry JWT token",
+		Tags:        []string{"auth"},
+	}, func(ctx context.Context, input *GitLabOIDCTokenExchangeInput) (*v0.Response[auth.TokenResponse], error) {
+		if err := validateScope(input.Body.Scope); err != nil {
+			return nil, err
+		}
+
+		response, err := handler.ExchangeToken(ctx, input.Body.OIDCToken, input.Body.Scope)
+		if err != nil {
+			return nil, huma.Error401Unauthorized("Token exchange failed", err)
+		}
+
+		return &v0.Response[auth.TokenResponse]{
+			Body: *response,
+		}, nil
+	})
+}
+
+// ExchangeToken exchanges a GitLab CI ID token for a Registry JWT token
+func (h *GitLabOIDCHandler) ExchangeToken(ctx context.Context, oidcToken string, scope []auth.Permission) (*auth.TokenResponse, error) {
+	claims, err := h.validator.ValidateToken(ctx, oidcToken, "mcp-registry")
+	if err != nil {
+		return nil, fmt.Errorf("failed to validate OIDC token: %w", err)
+	}
+
+	permissions, err := h.buildPermissions(claims)
+	if err != nil {
+		return nil, err
+	}
+
+	jwtClaims := auth.JWTClaims{
+		AuthMethod:        auth.MethodGitLabOIDC,
+		AuthMethodSubject: claims.Subject, // e.g. "project_path:my-group/my-project:ref_type:branch:ref:main"
+		Permissions:       permissions,
+	}
+
+	tokenResponse, err := h.jwtManager.GenerateScopedTokenResponse(ctx, jwtClaims, scope)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate JWT token: %w", err)
+	}
+
+	return tokenResponse, nil
+}
+
+func (h *GitLabOIDCHandler) buildPermissions(claims *GitLabOIDCClaims) ([]auth.Permission, error) {
+	namespace, err := gitLabNamespace(claims.NamespacePath)
+	if err != nil {
+		return nil, err
+	}
+
+	// Like GitHub OIDC, we grant the whole namespace rather than the project, so a monorepo can publish multiple servers
+	return []auth.Permission{
+		{
+			Action:          auth.PermissionActionPublish,
+			ResourcePattern: fmt.Sprintf("io.gitlab.%s/*", namespace),
+		},
+	}, nil
+}
+
+var gitLabNamespaceSegmentRegex = regexp.MustCompile(`^[a-zA-Z0-9-]+$`)
+
+// gitLabNamespace converts a GitLab namespace path (e.g. "group/subgroup") into a registry
+// namespace segment (e.g. "group.subgroup"). Segments containing characters other than
+// alphanumerics and hyphens are rejected, so dotted group names cannot collide with subgroups.
+func gitLabNamespace(namespacePath string) (string, error) {
+	segments := strings.Split(namespacePath, "/")
+	for _, segment := range segments {
+		if !gitLabNamespaceSegmentRegex.MatchString(segment) {
+			return "", fmt.Errorf("GitLab namespace %q cannot be published to: group %q may only contain letters, digits and hyphens", namespacePath, segment)
+		}
+	}
+	return strings.Join(segments, "."), nil
+}
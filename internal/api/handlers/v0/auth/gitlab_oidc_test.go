@@ -0,0 +1,207 @@
+package auth_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/modelcontextprotocol/registry/internal/api/handlers/v0/auth"
+	internalauth "github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type MockGitLabOIDCValidator struct {
+	claims *auth.GitLabOIDCClaims
+	err    error
+}
+
+func (m *MockGitLabOIDCValidator) ValidateToken(_ context.Context, _ string, _ string) (*auth.GitLabOIDCClaims, error) {
+	return m.claims, m.err
+}
+
+func TestGitLabOIDCHandler_ExchangeToken(t *testing.T) {
+	cfg := &config.Config{
+		JWTPrivateKey:    "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+		GitLabOIDCIssuer: "https://gitlab.com",
+	}
+	handler := auth.NewGitLabOIDCHandler(cfg)
+
+	tests := []struct {
+		name          string
+		validator     *MockGitLabOIDCValidator
+		expectError   bool
+		expectedError string
+		expectedPerms []internalauth.Permission
+	}{
+		{
+			name: "top-level group",
+			validator: &MockGitLabOIDCValidator{claims: &auth.GitLabOIDCClaims{
+				RegisteredClaims: jwt.RegisteredClaims{Subject: "project_path:my-group/my-project:ref_type:branch:ref:main"},
+				NamespacePath:    "my-group",
+			}},
+			expectedPerms: []internalauth.Permission{
+				{Action: internalauth.PermissionActionPublish, ResourcePattern: "io.gitlab.my-group/*"},
+			},
+		},
+		{
+			name: "subgroup",
+			validator: &MockGitLabOIDCValidator{claims: &auth.GitLabOIDCClaims{
+				RegisteredClaims: jwt.RegisteredClaims{Subject: "project_path:my-group/sub/my-project:ref_type:branch:ref:main"},
+				NamespacePath:    "my-group/sub",
+			}},
+			expectedPerms: []internalauth.Permission{
+				{Action: internalauth.PermissionActionPublish, ResourcePattern: "io.gitlab.my-group.sub/*"},
+			},
+		},
+		{
+			name: "namespace with dots is rejected",
+			validator: &MockGitLabOIDCValidator{claims: &auth.GitLabOIDCClaims{
+				RegisteredClaims: jwt.RegisteredClaims{Subject: "project_path:my.group/my-project:ref_type:branch:ref:main"},
+				NamespacePath:    "my.group",
+			}},
+			expectError:   true,
+			expectedError: `group "my.group" may only contain letters, digits and hyphens`,
+		},
+		{
+			name: "subgroup with underscores is rejected",
+			validator: &MockGitLabOIDCValidator{claims: &auth.GitLabOIDCClaims{
+				RegisteredClaims: jwt.RegisteredClaims{Subject: "project_path:my-group/my_sub/my-project:ref_type:branch:ref:main"},
+				NamespacePath:    "my-group/my_sub",
+			}},
+			expectError:   true,
+			expectedError: `group "my_sub" may only contain letters, digits and hyphens`,
+		},
+		{
+			name:        "validation failure",
+			validator:   &MockGitLabOIDCValidator{err: fmt.Errorf("token validation failed")},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler.SetValidator(tt.validator)
+
+			response, err := handler.ExchangeToken(context.Background(), "test-token", nil)
+			if tt.expectError {
+				assert.Error(t, err)
+				if tt.expectedError != "" {
+					assert.ErrorContains(t, err, tt.expectedError)
+				}
+				assert.Nil(t, response)
+				return
+			}
+			require.NoError(t, err)
+
+			jwtManager := internalauth.NewJWTManager(cfg)
+			claims, err := jwtManager.ValidateToken(context.Background(), response.RegistryToken)
+			require.NoError(t, err)
+			assert.Equal(t, internalauth.MethodGitLabOIDC, claims.AuthMethod)
+			assert.Equal(t, tt.validator.claims.Subject, claims.AuthMethodSubject)
+			assert.ElementsMatch(t, tt.expectedPerms, claims.Permissions)
+		})
+	}
+}
+
+func TestGitLabOIDCHandler_ExchangeScopedToken(t *testing.T) {
+	cfg := &config.Config{
+		JWTPrivateKey:    "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+		GitLabOIDCIssuer: "https://gitlab.com",
+	}
+	handler := auth.NewGitLabOIDCHandler(cfg)
+	handler.SetValidator(&MockGitLabOIDCValidator{claims: &auth.GitLabOIDCClaims{
+		RegisteredClaims: jwt.RegisteredClaims{Subject: "project_path:my-group/my-project:ref_type:branch:ref:main"},
+		NamespacePath:    "my-group",
+	}})
+
+	scope := []internalauth.Permission{{Action: internalauth.PermissionActionPublish, ResourcePattern: "io.gitlab.my-group/weather"}}
+	response, err := handler.ExchangeToken(context.Background(), "test-token", scope)
+	require.NoError(t, err)
+
+	claims, err := internalauth.NewJWTManager(cfg).ValidateToken(context.Background(), response.RegistryToken)
+	require.NoError(t, err)
+	assert.Equal(t, scope, claims.Permissions)
+
+	_, err = handler.ExchangeToken(context.Background(), "test-token", []internalauth.Permission{
+		{Action: internalauth.PermissionActionPublish, ResourcePattern: "io.gitlab.other-group/*"},
+	})
+	assert.ErrorIs(t, err, internalauth.ErrScopeNotPermitted)
+}
+
+func TestGitLabOIDCValidator_ValidateToken(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	mux.HandleFunc("/oauth/discovery/keys", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(auth.JWKS{Keys: []auth.JWK{{
+			KTY: "RSA",
+			KID: "test-kid",
+			Use: "sig",
+			N:   base64.RawURLEncoding.EncodeToString(privateKey.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(privateKey.E)).Bytes()),
+		}}})
+	})
+
+	sign := func(claims auth.GitLabOIDCClaims) string {
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+		token.Header["kid"] = "test-kid"
+		signed, err := token.SignedString(privateKey)
+		require.NoError(t, err)
+		return signed
+	}
+
+	validClaims := auth.GitLabOIDCClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    server.URL,
+			Subject:   "project_path:my-group/my-project:ref_type:branch:ref:main",
+			Audience:  jwt.ClaimStrings{"mcp-registry"},
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(5 * time.Minute)),
+		},
+		NamespacePath: "my-group",
+		ProjectPath:   "my-group/my-project",
+	}
+
+	validator := auth.NewGitLabOIDCValidator(server.URL)
+
+	t.Run("valid token", func(t *testing.T) {
+		claims, err := validator.ValidateToken(context.Background(), sign(validClaims), "mcp-registry")
+		require.NoError(t, err)
+		assert.Equal(t, "my-group", claims.NamespacePath)
+	})
+
+	t.Run("wrong audience", func(t *testing.T) {
+		claims := validClaims
+		claims.Audience = jwt.ClaimStrings{"https://gitlab.com"}
+		_, err := validator.ValidateToken(context.Background(), sign(claims), "mcp-registry")
+		assert.Error(t, err)
+	})
+
+	t.Run("wrong issuer", func(t *testing.T) {
+		claims := validClaims
+		claims.Issuer = "https://gitlab.example.com"
+		_, err := validator.ValidateToken(context.Background(), sign(claims), "mcp-registry")
+		assert.Error(t, err)
+	})
+
+	t.Run("missing namespace", func(t *testing.T) {
+		claims := validClaims
+		claims.NamespacePath = ""
+		_, err := validator.ValidateToken(context.Background(), sign(claims), "mcp-registry")
+		assert.ErrorContains(t, err, "namespace_path")
+	})
+}
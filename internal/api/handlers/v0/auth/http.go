@@ -108,8 +108,9 @@ func (h *HTTPAuthHandler) SetFetcher(fetcher HTTPKeyFetcher) {
 }
 
 // RegisterHTTPEndpoint registers the HTTP authentication endpoint
-func RegisterHTTPEndpoint(api huma.API, pathPrefix string, cfg *config.Config) {
+func RegisterHTTPEndpoint(api huma.API, pathPrefix string, cfg *config.Config, nonceStore auth.NonceStore) {
 	handler := NewHTTPAuthHandler(cfg)
+	handler.SetNonceStore(nonceStore)
 
 	// HTTP authentication endpoint
 	huma.Register(api, huma.Operation{
@@ -117,10 +118,20 @@ func RegisterHTTPEndpoint(api huma.API, pathPrefix string, cfg *config.Config) {
 		Method:      http.MethodPost,
 		Path:        pathPrefix + "/auth/http",
 		Summary:     "Exchange HTTP signature for Registry JWT",
-		Description: "Authenticate using HTTP-hosted public key and signed timestamp",
+		Description: "Authenticate using HTTP-hosted public key and a signed challenge nonce (or signed timestamp in the legacy flow)",
 		Tags:        []string{"auth"},
 	}, func(ctx context.Context, input *HTTPTokenExchangeInput) (*v0.Response[auth.TokenResponse], error) {
-		response, err := handler.ExchangeToken(ctx, input.Body.Domain, input.Body.Timestamp, input.Body.SignedTimestamp)
+		if err := validateScope(input.Body.Scope); err != nil {
+			return nil, err
+		}
+
+		var response *auth.TokenResponse
+		var err error
+		if input.Body.Nonce != "" {
+			response, err = handler.ExchangeNonceToken(ctx, input.Body.Domain, input.Body.Nonce, input.Body.SignedNonce, input.Body.Scope)
+		} else {
+			response, err = handler.ExchangeToken(ctx, input.Body.Domain, input.Body.Timestamp, input.Body.SignedTimestamp, input.Body.Scope)
+		}
 		if err != nil {
 			return nil, huma.Error401Unauthorized("HTTP authentication failed", err)
 		}
@@ -131,16 +142,23 @@ func RegisterHTTPEndpoint(api huma.API, pathPrefix string, cfg *config.Config) {
 	})
 }
 
-// ExchangeToken exchanges HTTP signature for a Registry JWT token
-func (h *HTTPAuthHandler) ExchangeToken(ctx context.Context, domain, timestamp, signedTimestamp string) (*auth.TokenResponse, error) {
-	keyFetcher := func(ctx context.Context, domain string) ([]string, error) {
-		keyResponse, err := h.fetcher.FetchKey(ctx, domain)
-		if err != nil {
-			return nil, fmt.Errorf("failed to fetch public key: %w", err)
-		}
-		return []string{keyResponse}, nil
-	}
+// ExchangeToken exchanges an HTTP signature of a timestamp for a Registry JWT token (legacy flow)
+func (h *HTTPAuthHandler) ExchangeToken(ctx context.Context, domain, timestamp, signedTimestamp string, scope []auth.Permission) (*auth.TokenResponse, error) {
+	allowSubdomains := false
+	return h.CoreAuthHandler.ExchangeToken(ctx, domain, timestamp, signedTimestamp, h.fetchKeys, allowSubdomains, auth.MethodHTTP, scope)
+}
 
+// ExchangeNonceToken exchanges an HTTP signature of a challenge nonce for a Registry JWT token
+func (h *HTTPAuthHandler) ExchangeNonceToken(ctx context.Context, domain, nonce, signedNonce string, scope []auth.Permission) (*auth.TokenResponse, error) {
 	allowSubdomains := false
-	return h.CoreAuthHandler.ExchangeToken(ctx, domain, timestamp, signedTimestamp, keyFetcher, allowSubdomains, auth.MethodHTTP)
+	return h.CoreAuthHandler.ExchangeNonceToken(ctx, domain, nonce, signedNonce, h.fetchKeys, allowSubdomains, auth.MethodHTTP, scope)
+}
+
+// fetchKeys fetches the public key from the domain's well-known endpoint
+func (h *HTTPAuthHandler) fetchKeys(ctx context.Context, domain string) ([]string, error) {
+	keyResponse, err := h.fetcher.FetchKey(ctx, domain)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch public key: %w", err)
+	}
+	return []string{keyResponse}, nil
 }
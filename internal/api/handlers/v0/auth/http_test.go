@@ -218,7 +218,7 @@ func TestHTTPAuthHandler_ExchangeToken(t *testing.T) {
 			}
 
 			// Call the handler
-			result, err := handler.ExchangeToken(context.Background(), tt.domain, tt.timestamp, signedTimestamp)
+			result, err := handler.ExchangeToken(context.Background(), tt.domain, tt.timestamp, signedTimestamp, nil)
 
 			if tt.expectError {
 				assert.Error(t, err)
@@ -483,7 +483,7 @@ func TestHTTPAuthHandler_Permissions(t *testing.T) {
 			signedTimestamp := hex.EncodeToString(signature)
 
 			// Exchange token
-			result, err := handler.ExchangeToken(context.Background(), tt.domain, timestamp, signedTimestamp)
+			result, err := handler.ExchangeToken(context.Background(), tt.domain, timestamp, signedTimestamp, nil)
 			require.NoError(t, err)
 			require.NotNil(t, result)
 
@@ -571,7 +571,7 @@ func TestHTTPAuthHandler_PermissionValidation(t *testing.T) {
 	signature := ed25519.Sign(privateKey, []byte(timestamp))
 	signedTimestamp := hex.EncodeToString(signature)
 
-	result, err := handler.ExchangeToken(context.Background(), domain, timestamp, signedTimestamp)
+	result, err := handler.ExchangeToken(context.Background(), domain, timestamp, signedTimestamp, nil)
 	require.NoError(t, err)
 
 	claims, err := jwtManager.ValidateToken(context.Background(), result.RegistryToken)
@@ -683,10 +683,10 @@ func TestHTTPvsDNS_PermissionDifferences(t *testing.T) {
 	signature := ed25519.Sign(privateKey, []byte(timestamp))
 	signedTimestamp := hex.EncodeToString(signature)
 
-	httpResult, err := httpHandler.ExchangeToken(context.Background(), domain, timestamp, signedTimestamp)
+	httpResult, err := httpHandler.ExchangeToken(context.Background(), domain, timestamp, signedTimestamp, nil)
 	require.NoError(t, err)
 
-	dnsResult, err := dnsHandler.ExchangeToken(context.Background(), domain, timestamp, signedTimestamp)
+	dnsResult, err := dnsHandler.ExchangeToken(context.Background(), domain, timestamp, signedTimestamp, nil)
 	require.NoError(t, err)
 
 	// Validate both tokens
@@ -0,0 +1,285 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// jwksDefaultTTL is used when the JWKS response has no usable Cache-Control max-age
+	jwksDefaultTTL = time.Hour
+	// jwksMaxTTL caps how long keys are trusted without refetching, whatever the server says
+	jwksMaxTTL = 24 * time.Hour
+	// jwksMinRefetchInterval limits refetches triggered by unknown key IDs, so tokens
+	// with bogus kids cannot be used to hammer the JWKS endpoint
+	jwksMinRefetchInterval = time.Minute
+	// jwksFetchTimeout bounds a single JWKS request
+	jwksFetchTimeout = 10 * time.Second
+)
+
+// JWKS represents a JSON Web Key Set
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWK represents a JSON Web Key
+type JWK struct {
+	KTY string `json:"kty"`
+	KID string `json:"kid"`
+	Use string `json:"use"`
+	// RSA parameters
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+	// ECDSA parameters
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+}
+
+// jwksSigningMethods are the JWT algorithms that keys from a JWKS can verify
+var jwksSigningMethods = []string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512"}
+
+// jwksCache fetches and caches the public keys of a JSON Web Key Set.
+// Keys are cached for the max-age advertised by the endpoint; an unknown key ID forces
+// a refresh (at most once per jwksMinRefetchInterval) to pick up rotated keys, and
+// stale keys keep being served if a refresh fails.
+type jwksCache struct {
+	url    string
+	client *http.Client
+	now    func() time.Time
+
+	mu        sync.RWMutex
+	keys      map[string]crypto.PublicKey
+	fetchedAt time.Time
+	expiresAt time.Time
+
+	// fetchMu ensures only one fetch is in flight at a time
+	fetchMu sync.Mutex
+}
+
+// newJWKSCache creates a new cache for the JWKS at the given URL
+func newJWKSCache(url string) *jwksCache {
+	return &jwksCache{
+		url:    url,
+		client: &http.Client{Timeout: jwksFetchTimeout},
+		now:    time.Now,
+	}
+}
+
+// getPublicKey returns the public key with the given key ID
+func (c *jwksCache) getPublicKey(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	key, known, fresh := c.lookup(kid)
+	if known && fresh {
+		return key, nil
+	}
+
+	if err := c.refresh(ctx, !known); err != nil {
+		if known {
+			log.Printf("Failed to refresh JWKS from %s, using cached keys: %v", c.url, err)
+			return key, nil
+		}
+		return nil, err
+	}
+
+	key, known, _ = c.lookup(kid)
+	if !known {
+		return nil, fmt.Errorf("key with ID %s not found", kid)
+	}
+	return key, nil
+}
+
+// lookup returns the cached key for kid, whether it is known, and whether the cache is fresh
+func (c *jwksCache) lookup(kid string) (crypto.PublicKey, bool, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	key, ok := c.keys[kid]
+	return key, ok, c.now().Before(c.expiresAt)
+}
+
+// refresh refetches the JWKS if it has expired, or if unknownKID is set and the last
+// fetch is older than jwksMinRefetchInterval
+func (c *jwksCache) refresh(ctx context.Context, unknownKID bool) error {
+	requestedAt := c.now()
+
+	c.fetchMu.Lock()
+	defer c.fetchMu.Unlock()
+
+	c.mu.RLock()
+	fetchedAt, expiresAt := c.fetchedAt, c.expiresAt
+	c.mu.RUnlock()
+
+	// Another caller refreshed the keys while we were waiting
+	if !fetchedAt.Before(requestedAt) {
+		return nil
+	}
+
+	now := c.now()
+	if unknownKID {
+		if now.Sub(fetchedAt) < jwksMinRefetchInterval {
+			return nil
+		}
+	} else if now.Before(expiresAt) {
+		return nil
+	}
+
+	jwks, ttl, err := c.fetch(ctx)
+	if err != nil {
+		return err
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(jwks.Keys))
+	for _, jwk := range jwks.Keys {
+		key, err := parsePublicKey(jwk)
+		if err != nil {
+			// Skip keys we cannot use rather than rejecting the whole set
+			continue
+		}
+		keys[jwk.KID] = key
+	}
+
+	fetchedAt = c.now()
+	c.mu.Lock()
+	c.keys = keys
+	c.fetchedAt = fetchedAt
+	c.expiresAt = fetchedAt.Add(ttl)
+	c.mu.Unlock()
+
+	return nil
+}
+
+// fetch fetches the JWKS and returns it along with how long it may be cached
+func (c *jwksCache) fetch(ctx context.Context) (*JWKS, time.Duration, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url, nil)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to fetch JWKS: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, 0, fmt.Errorf("JWKS endpoint returned status %d: %s", resp.StatusCode, body)
+	}
+
+	var jwks JWKS
+	if err := json.NewDecoder(resp.Body).Decode(&jwks); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode JWKS: %w", err)
+	}
+
+	return &jwks, cacheTTL(resp.Header.Get("Cache-Control")), nil
+}
+
+// cacheTTL derives the cache lifetime from a Cache-Control header
+func cacheTTL(cacheControl string) time.Duration {
+	ttl := jwksDefaultTTL
+	for _, directive := range strings.Split(cacheControl, ",") {
+		directive = strings.ToLower(strings.TrimSpace(directive))
+		switch {
+		case directive == "no-store" || directive == "no-cache":
+			// Still cache briefly so every validation does not hit the endpoint
+			return jwksMinRefetchInterval
+		case strings.HasPrefix(directive, "max-age="):
+			seconds, err := strconv.Atoi(strings.TrimPrefix(directive, "max-age="))
+			if err == nil && seconds >= 0 {
+				ttl = time.Duration(seconds) * time.Second
+			}
+		}
+	}
+
+	return min(max(ttl, jwksMinRefetchInterval), jwksMaxTTL)
+}
+
+// parsePublicKey converts a JWK to an RSA or ECDSA public key
+func parsePublicKey(jwk JWK) (crypto.PublicKey, error) {
+	switch jwk.KTY {
+	case "RSA":
+		return parseRSAPublicKey(jwk)
+	case "EC":
+		return parseECDSAPublicKey(jwk)
+	default:
+		return nil, fmt.Errorf("unsupported key type: %s", jwk.KTY)
+	}
+}
+
+// parseRSAPublicKey converts JWK to RSA public key
+func parseRSAPublicKey(jwk JWK) (*rsa.PublicKey, error) {
+	if jwk.KTY != "RSA" {
+		return nil, fmt.Errorf("invalid key type: expected RSA, got %s", jwk.KTY)
+	}
+
+	// Decode modulus (n)
+	nBytes, err := base64.RawURLEncoding.DecodeString(jwk.N)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode modulus: %w", err)
+	}
+
+	// Decode exponent (e)
+	eBytes, err := base64.RawURLEncoding.DecodeString(jwk.E)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode exponent: %w", err)
+	}
+
+	// Convert to big integers
+	n := new(big.Int).SetBytes(nBytes)
+	e := new(big.Int).SetBytes(eBytes)
+
+	return &rsa.PublicKey{
+		N: n,
+		E: int(e.Int64()),
+	}, nil
+}
+
+// parseECDSAPublicKey converts JWK to ECDSA public key
+func parseECDSAPublicKey(jwk JWK) (*ecdsa.PublicKey, error) {
+	var curve elliptic.Curve
+	switch jwk.Crv {
+	case "P-256":
+		curve = elliptic.P256()
+	case "P-384":
+		curve = elliptic.P384()
+	case "P-521":
+		curve = elliptic.P521()
+	default:
+		return nil, fmt.Errorf("unsupported curve: %s", jwk.Crv)
+	}
+
+	xBytes, err := base64.RawURLEncoding.DecodeString(jwk.X)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode x coordinate: %w", err)
+	}
+	yBytes, err := base64.RawURLEncoding.DecodeString(jwk.Y)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode y coordinate: %w", err)
+	}
+
+	size := (curve.Params().BitSize + 7) / 8
+	if len(xBytes) != size || len(yBytes) != size {
+		return nil, fmt.Errorf("invalid coordinate length for curve %s", jwk.Crv)
+	}
+
+	// Uncompressed point encoding: 0x04 || x || y
+	point := make([]byte, 0, 1+2*size)
+	point = append(point, 0x04)
+	point = append(point, xBytes...)
+	point = append(point, yBytes...)
+
+	return ecdsa.ParseUncompressedPublicKey(curve, point)
+}
@@ -0,0 +1,174 @@
+package auth_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/modelcontextprotocol/registry/internal/api/handlers/v0/auth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// jwksTestServer serves a mutable JWKS and counts fetches
+type jwksTestServer struct {
+	*httptest.Server
+	mu      sync.Mutex
+	keys    []auth.JWK
+	fail    bool
+	fetches atomic.Int32
+}
+
+func newJWKSTestServer(t *testing.T) *jwksTestServer {
+	t.Helper()
+	s := &jwksTestServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		s.fetches.Add(1)
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if s.fail {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Cache-Control", "public, max-age=3600")
+		_ = json.NewEncoder(w).Encode(auth.JWKS{Keys: s.keys})
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *jwksTestServer) setKeys(keys ...auth.JWK) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keys = keys
+}
+
+func (s *jwksTestServer) setFail(fail bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.fail = fail
+}
+
+func rsaJWK(t *testing.T, kid string) (*rsa.PrivateKey, auth.JWK) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	return key, auth.JWK{
+		KTY: "RSA",
+		KID: kid,
+		Use: "sig",
+		N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+	}
+}
+
+func ecJWK(t *testing.T, kid string) (*ecdsa.PrivateKey, auth.JWK) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	point, err := key.PublicKey.Bytes()
+	require.NoError(t, err)
+	return key, auth.JWK{
+		KTY: "EC",
+		KID: kid,
+		Use: "sig",
+		Crv: "P-256",
+		X:   base64.RawURLEncoding.EncodeToString(point[1:33]),
+		Y:   base64.RawURLEncoding.EncodeToString(point[33:]),
+	}
+}
+
+func signGitHubOIDCToken(t *testing.T, method jwt.SigningMethod, key any, kid, issuer string) string {
+	t.Helper()
+	token := jwt.NewWithClaims(method, auth.GitHubOIDCClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    issuer,
+			Subject:   "repo:octo-org/octo-repo:ref:refs/heads/main",
+			Audience:  jwt.ClaimStrings{"mcp-registry"},
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(5 * time.Minute)),
+		},
+		RepositoryOwner: "octo-org",
+	})
+	token.Header["kid"] = kid
+	signed, err := token.SignedString(key)
+	require.NoError(t, err)
+	return signed
+}
+
+func TestGitHubOIDCValidator_JWKSCaching(t *testing.T) {
+	ctx := context.Background()
+	server := newJWKSTestServer(t)
+	rsaKey, rsaJWK := rsaJWK(t, "rsa-1")
+	server.setKeys(rsaJWK)
+
+	validator := auth.NewMockOIDCValidator(server.URL, "https://token.actions.githubusercontent.com")
+	token := signGitHubOIDCToken(t, jwt.SigningMethodRS256, rsaKey, "rsa-1", "https://token.actions.githubusercontent.com")
+
+	t.Run("keys are cached between validations", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			_, err := validator.ValidateToken(ctx, token, "mcp-registry")
+			require.NoError(t, err)
+		}
+		assert.Equal(t, int32(1), server.fetches.Load())
+	})
+
+	t.Run("cached keys survive an unavailable endpoint", func(t *testing.T) {
+		server.setFail(true)
+		defer server.setFail(false)
+
+		_, err := validator.ValidateToken(ctx, token, "mcp-registry")
+		require.NoError(t, err)
+		assert.Equal(t, int32(1), server.fetches.Load())
+	})
+
+	t.Run("unknown kid refetch is rate limited", func(t *testing.T) {
+		ecKey, ecJWK := ecJWK(t, "ec-1")
+		server.setKeys(rsaJWK, ecJWK)
+		ecToken := signGitHubOIDCToken(t, jwt.SigningMethodES256, ecKey, "ec-1", "https://token.actions.githubusercontent.com")
+
+		// The last fetch was just now, so the rotated key is not picked up yet
+		_, err := validator.ValidateToken(ctx, ecToken, "mcp-registry")
+		assert.ErrorContains(t, err, "key with ID ec-1 not found")
+		assert.Equal(t, int32(1), server.fetches.Load())
+	})
+}
+
+func TestGitHubOIDCValidator_KeyRollover(t *testing.T) {
+	ctx := context.Background()
+	server := newJWKSTestServer(t)
+	ecKey, ecJWK := ecJWK(t, "ec-1")
+	server.setKeys(ecJWK)
+
+	validator := auth.NewMockOIDCValidator(server.URL, "https://token.actions.githubusercontent.com")
+
+	// The first token with an unknown kid triggers a fetch, and ECDSA keys are supported
+	token := signGitHubOIDCToken(t, jwt.SigningMethodES256, ecKey, "ec-1", "https://token.actions.githubusercontent.com")
+	claims, err := validator.ValidateToken(ctx, token, "mcp-registry")
+	require.NoError(t, err)
+	assert.Equal(t, "octo-org", claims.RepositoryOwner)
+
+	// Concurrent validations share a single cached fetch
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := validator.ValidateToken(ctx, token, "mcp-registry")
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), server.fetches.Load())
+}
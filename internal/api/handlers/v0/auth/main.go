@@ -2,25 +2,37 @@ package auth
 
 import (
 	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/auth"
 	"github.com/modelcontextprotocol/registry/internal/config"
 )
 
-// RegisterAuthEndpoints registers all authentication endpoints with a custom path prefix
-func RegisterAuthEndpoints(api huma.API, pathPrefix string, cfg *config.Config) {
+// RegisterAuthEndpoints registers all authentication endpoints with a custom path prefix.
+// nonceStore tracks used challenge nonces; if nil, an in-memory store is used.
+func RegisterAuthEndpoints(api huma.API, pathPrefix string, cfg *config.Config, nonceStore auth.NonceStore) {
+	if nonceStore == nil {
+		nonceStore = auth.NewMemoryNonceStore()
+	}
+
 	// Register GitHub access token authentication endpoint
 	RegisterGitHubATEndpoint(api, pathPrefix, cfg)
 
 	// Register GitHub OIDC authentication endpoint
 	RegisterGitHubOIDCEndpoint(api, pathPrefix, cfg)
 
+	// Register GitLab CI OIDC authentication endpoint
+	RegisterGitLabOIDCEndpoint(api, pathPrefix, cfg)
+
 	// Register configurable OIDC authentication endpoints
 	RegisterOIDCEndpoints(api, pathPrefix, cfg)
 
+	// Register signature challenge endpoint for DNS and HTTP authentication
+	RegisterChallengeEndpoint(api, pathPrefix, cfg, nonceStore)
+
 	// Register DNS-based authentication endpoint
-	RegisterDNSEndpoint(api, pathPrefix, cfg)
+	RegisterDNSEndpoint(api, pathPrefix, cfg, nonceStore)
 
 	// Register HTTP-based authentication endpoint
-	RegisterHTTPEndpoint(api, pathPrefix, cfg)
+	RegisterHTTPEndpoint(api, pathPrefix, cfg, nonceStore)
 
 	// Register anonymous authentication endpoint
 	RegisterNoneEndpoint(api, pathPrefix, cfg)
@@ -9,6 +9,7 @@ import (
 
 	"github.com/coreos/go-oidc/v3/oidc"
 	"github.com/danielgtaylor/huma/v2"
+	"github.com/golang-jwt/jwt/v5"
 	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
 	"github.com/modelcontextprotocol/registry/internal/auth"
 	"github.com/modelcontextprotocol/registry/internal/config"
@@ -17,7 +18,8 @@ import (
 // OIDCTokenExchangeInput represents the input for OIDC token exchange
 type OIDCTokenExchangeInput struct {
 	Body struct {
-		OIDCToken string `json:"oidc_token" doc:"OIDC ID token from any provider" required:"true"`
+		OIDCToken string            `json:"oidc_token" doc:"OIDC ID token from any provider" required:"true"`
+		Scope     []auth.Permission `json:"scope,omitempty" doc:"Optional scope to narrow the token to, e.g. [{\"action\":\"publish\",\"resource\":\"com.example/my-server\"}]" required:"false"`
 	}
 }
 
@@ -111,11 +113,17 @@ func (v *StandardOIDCValidator) ValidateToken(ctx context.Context, tokenString s
 	return oidcClaims, nil
 }
 
+// oidcIssuer is a trusted identity provider with its validator
+type oidcIssuer struct {
+	config    config.OIDCIssuerConfig
+	validator GenericOIDCValidator
+}
+
 // OIDCHandler handles configurable OIDC authentication
 type OIDCHandler struct {
 	config     *config.Config
 	jwtManager *auth.JWTManager
-	validator  GenericOIDCValidator
+	issuers    []*oidcIssuer
 }
 
 // NewOIDCHandler creates a new OIDC handler
@@ -123,25 +131,91 @@ func NewOIDCHandler(cfg *config.Config) *OIDCHandler {
 	if !cfg.OIDCEnabled {
 		panic("OIDC is not enabled - should not create OIDC handler")
 	}
-	if cfg.OIDCIssuer == "" {
+
+	issuerConfigs, err := oidcIssuerConfigs(cfg)
+	if err != nil {
+		panic(fmt.Sprintf("Invalid OIDC configuration: %v", err))
+	}
+	if len(issuerConfigs) == 0 {
 		panic("OIDC issuer is required when OIDC is enabled")
 	}
 
-	validator, err := NewStandardOIDCValidator(cfg.OIDCIssuer, cfg.OIDCClientID)
-	if err != nil {
-		panic(fmt.Sprintf("Failed to initialize OIDC validator: %v", err))
+	if err := validateOIDCRules(cfg.OIDCRules, issuerConfigs); err != nil {
+		panic(fmt.Sprintf("Invalid OIDC permission rules: %v", err))
+	}
+
+	issuers := make([]*oidcIssuer, 0, len(issuerConfigs))
+	for _, issuerConfig := range issuerConfigs {
+		validator, err := NewStandardOIDCValidator(issuerConfig.Issuer, issuerConfig.ClientID)
+		if err != nil {
+			panic(fmt.Sprintf("Failed to initialize OIDC validator for %s: %v", issuerConfig.Issuer, err))
+		}
+		issuers = append(issuers, &oidcIssuer{config: issuerConfig, validator: validator})
 	}
 
 	return &OIDCHandler{
 		config:     cfg,
 		jwtManager: auth.NewJWTManager(cfg),
-		validator:  validator,
+		issuers:    issuers,
+	}
+}
+
+// oidcIssuerConfigs combines the issuer configured through environment variables with
+// the issuers from the OIDC config file
+func oidcIssuerConfigs(cfg *config.Config) ([]config.OIDCIssuerConfig, error) {
+	var issuers []config.OIDCIssuerConfig
+
+	if cfg.OIDCIssuer != "" {
+		issuer := config.OIDCIssuerConfig{
+			Issuer:             cfg.OIDCIssuer,
+			ClientID:           cfg.OIDCClientID,
+			PublishPermissions: splitPermissionPatterns(cfg.OIDCPublishPerms),
+			EditPermissions:    splitPermissionPatterns(cfg.OIDCEditPerms),
+		}
+		if cfg.OIDCExtraClaims != "" {
+			if err := json.Unmarshal([]byte(cfg.OIDCExtraClaims), &issuer.ExtraClaims); err != nil {
+				return nil, fmt.Errorf("invalid extra claims configuration: %w", err)
+			}
+		}
+		issuers = append(issuers, issuer)
+	}
+
+	for _, issuer := range cfg.OIDCIssuers {
+		if issuer.Issuer == cfg.OIDCIssuer {
+			return nil, fmt.Errorf("OIDC issuer %s is configured both in the environment and the config file", issuer.Issuer)
+		}
+		issuers = append(issuers, issuer)
+	}
+
+	return issuers, nil
+}
+
+// splitPermissionPatterns splits a comma-separated list of permission patterns
+func splitPermissionPatterns(value string) []string {
+	var patterns []string
+	for _, pattern := range strings.Split(value, ",") {
+		pattern = strings.TrimSpace(pattern)
+		if pattern != "" {
+			patterns = append(patterns, pattern)
+		}
 	}
+	return patterns
 }
 
-// SetValidator sets a custom OIDC validator (used for testing)
+// SetValidator sets a custom OIDC validator for all issuers (used for testing)
 func (h *OIDCHandler) SetValidator(validator GenericOIDCValidator) {
-	h.validator = validator
+	for _, issuer := range h.issuers {
+		issuer.validator = validator
+	}
+}
+
+// SetIssuerValidator sets a custom OIDC validator for one issuer (used for testing)
+func (h *OIDCHandler) SetIssuerValidator(issuerURL string, validator GenericOIDCValidator) {
+	for _, issuer := range h.issuers {
+		if issuer.config.Issuer == issuerURL {
+			issuer.validator = validator
+		}
+	}
 }
 
 // RegisterOIDCEndpoints registers all OIDC authentication endpoints
@@ -161,7 +235,11 @@ func RegisterOIDCEndpoints(api huma.API, pathPrefix string, cfg *config.Config)
 		Description: "Exchange an OIDC ID token from any configured provider for a short-lived Registry JWT token",
 		Tags:        []string{"auth"},
 	}, func(ctx context.Context, input *OIDCTokenExchangeInput) (*v0.Response[auth.TokenResponse], error) {
-		response, err := handler.ExchangeToken(ctx, input.Body.OIDCToken)
+		if err := validateScope(input.Body.Scope); err != nil {
+			return nil, err
+		}
+
+		response, err := handler.ExchangeToken(ctx, input.Body.OIDCToken, input.Body.Scope)
 		if err != nil {
 			return nil, huma.Error401Unauthorized("Token exchange failed", err)
 		}
@@ -173,20 +251,25 @@ func RegisterOIDCEndpoints(api huma.API, pathPrefix string, cfg *config.Config)
 }
 
 // ExchangeToken exchanges an OIDC ID token for a Registry JWT token
-func (h *OIDCHandler) ExchangeToken(ctx context.Context, oidcToken string) (*auth.TokenResponse, error) {
+func (h *OIDCHandler) ExchangeToken(ctx context.Context, oidcToken string, scope []auth.Permission) (*auth.TokenResponse, error) {
+	issuer, err := h.issuerForToken(oidcToken)
+	if err != nil {
+		return nil, err
+	}
+
 	// Validate OIDC token
-	claims, err := h.validator.ValidateToken(ctx, oidcToken)
+	claims, err := issuer.validator.ValidateToken(ctx, oidcToken)
 	if err != nil {
 		return nil, fmt.Errorf("failed to validate OIDC token: %w", err)
 	}
 
 	// Validate extra claims if configured
-	if err := h.validateExtraClaims(claims); err != nil {
+	if err := validateExtraClaims(issuer.config.ExtraClaims, claims); err != nil {
 		return nil, fmt.Errorf("extra claims validation failed: %w", err)
 	}
 
 	// Build permissions based on claims and configuration
-	permissions := h.buildPermissions(claims)
+	permissions := h.buildPermissions(issuer, claims)
 
 	// Create JWT claims
 	jwtClaims := auth.JWTClaims{
@@ -196,7 +279,7 @@ func (h *OIDCHandler) ExchangeToken(ctx context.Context, oidcToken string) (*aut
 	}
 
 	// Generate Registry JWT token
-	tokenResponse, err := h.jwtManager.GenerateTokenResponse(ctx, jwtClaims)
+	tokenResponse, err := h.jwtManager.GenerateScopedTokenResponse(ctx, jwtClaims, scope)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate JWT token: %w", err)
 	}
@@ -204,19 +287,29 @@ func (h *OIDCHandler) ExchangeToken(ctx context.Context, oidcToken string) (*aut
 	return tokenResponse, nil
 }
 
-// validateExtraClaims validates additional claims based on configuration
-func (h *OIDCHandler) validateExtraClaims(claims *OIDCClaims) error {
-	if h.config.OIDCExtraClaims == "" {
-		return nil // No extra validation required
+// issuerForToken selects the issuer whose validator should verify the token, based on its
+// (not yet verified) iss claim. The validator then checks the signature and issuer.
+func (h *OIDCHandler) issuerForToken(oidcToken string) (*oidcIssuer, error) {
+	if len(h.issuers) == 1 {
+		return h.issuers[0], nil
+	}
+
+	var unverified jwt.RegisteredClaims
+	if _, _, err := jwt.NewParser().ParseUnverified(oidcToken, &unverified); err != nil {
+		return nil, fmt.Errorf("failed to parse OIDC token: %w", err)
 	}
 
-	// Parse extra claims configuration
-	var extraClaimsRules []map[string]any
-	if err := json.Unmarshal([]byte(h.config.OIDCExtraClaims), &extraClaimsRules); err != nil {
-		return fmt.Errorf("invalid extra claims configuration: %w", err)
+	for _, issuer := range h.issuers {
+		if issuer.config.Issuer == unverified.Issuer {
+			return issuer, nil
+		}
 	}
 
-	// Validate each rule
+	return nil, fmt.Errorf("OIDC issuer %q is not trusted", unverified.Issuer)
+}
+
+// validateExtraClaims validates that the claims contain all required claim values
+func validateExtraClaims(extraClaimsRules []map[string]any, claims *OIDCClaims) error {
 	for _, rule := range extraClaimsRules {
 		for key, expectedValue := range rule {
 			actualValue, exists := claims.ExtraClaims[key]
@@ -234,33 +327,25 @@ func (h *OIDCHandler) validateExtraClaims(claims *OIDCClaims) error {
 }
 
 // buildPermissions builds permissions based on OIDC claims and configuration
-func (h *OIDCHandler) buildPermissions(_ *OIDCClaims) []auth.Permission {
+func (h *OIDCHandler) buildPermissions(issuer *oidcIssuer, claims *OIDCClaims) []auth.Permission {
 	var permissions []auth.Permission
 
-	// Parse permission patterns from configuration
-	if h.config.OIDCPublishPerms != "" {
-		for _, pattern := range strings.Split(h.config.OIDCPublishPerms, ",") {
-			pattern = strings.TrimSpace(pattern)
-			if pattern != "" {
-				permissions = append(permissions, auth.Permission{
-					Action:          auth.PermissionActionPublish,
-					ResourcePattern: pattern,
-				})
-			}
-		}
+	for _, pattern := range issuer.config.PublishPermissions {
+		permissions = append(permissions, auth.Permission{
+			Action:          auth.PermissionActionPublish,
+			ResourcePattern: pattern,
+		})
 	}
 
-	if h.config.OIDCEditPerms != "" {
-		for _, pattern := range strings.Split(h.config.OIDCEditPerms, ",") {
-			pattern = strings.TrimSpace(pattern)
-			if pattern != "" {
-				permissions = append(permissions, auth.Permission{
-					Action:          auth.PermissionActionEdit,
-					ResourcePattern: pattern,
-				})
-			}
-		}
+	for _, pattern := range issuer.config.EditPermissions {
+		permissions = append(permissions, auth.Permission{
+			Action:          auth.PermissionActionEdit,
+			ResourcePattern: pattern,
+		})
 	}
 
+	// Add permissions from claim-based rules
+	permissions = append(permissions, buildRulePermissions(h.config.OIDCRules, claims)...)
+
 	return permissions
 }
@@ -0,0 +1,189 @@
+package auth
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+)
+
+var (
+	// permissionTemplateRegex matches claim references such as {claims.team}
+	permissionTemplateRegex = regexp.MustCompile(`\{claims\.([A-Za-z0-9_:.-]+)\}`)
+	// templateValueRegex restricts substituted claim values, so a claim cannot inject
+	// wildcards, dots or slashes into a permission pattern
+	templateValueRegex = regexp.MustCompile(`^[a-zA-Z0-9-]+$`)
+)
+
+// buildRulePermissions evaluates the configured rules against the claims and returns the granted permissions
+func buildRulePermissions(rules []config.OIDCPermissionRule, claims *OIDCClaims) []auth.Permission {
+	var permissions []auth.Permission
+	for _, rule := range rules {
+		if !ruleMatches(rule, claims) {
+			continue
+		}
+		for _, pattern := range rule.Publish {
+			for _, resource := range expandPermissionTemplate(pattern, claims) {
+				permissions = append(permissions, auth.Permission{Action: auth.PermissionActionPublish, ResourcePattern: resource})
+			}
+		}
+		for _, pattern := range rule.Edit {
+			for _, resource := range expandPermissionTemplate(pattern, claims) {
+				permissions = append(permissions, auth.Permission{Action: auth.PermissionActionEdit, ResourcePattern: resource})
+			}
+		}
+	}
+	return permissions
+}
+
+// ruleMatches reports whether all populated match criteria of the rule hold for the claims
+func ruleMatches(rule config.OIDCPermissionRule, claims *OIDCClaims) bool {
+	if rule.Issuer != "" && rule.Issuer != claims.Issuer {
+		return false
+	}
+
+	match := rule.Match
+	if !match.HasCriteria() {
+		// Rules without criteria are rejected at startup unless explicitly catch-all
+		return match.Any
+	}
+
+	if len(match.Groups) > 0 {
+		groupsClaim := match.GroupsClaim
+		if groupsClaim == "" {
+			groupsClaim = "groups"
+		}
+		if !slices.ContainsFunc(claimValues(claims, groupsClaim), func(group string) bool {
+			return slices.Contains(match.Groups, group)
+		}) {
+			return false
+		}
+	}
+
+	if len(match.EmailDomains) > 0 && !emailDomainMatches(claims, match.EmailDomains) {
+		return false
+	}
+
+	if match.Subject != "" {
+		if ok, err := path.Match(match.Subject, claims.Subject); err != nil || !ok {
+			return false
+		}
+	}
+
+	for name, expected := range match.Claims {
+		if !slices.Contains(claimValues(claims, name), expected) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// emailDomainMatches checks that the token carries a verified email in one of the domains
+func emailDomainMatches(claims *OIDCClaims, domains []string) bool {
+	email, _ := claims.ExtraClaims["email"].(string)
+	_, domain, found := strings.Cut(email, "@")
+	if !found {
+		return false
+	}
+
+	// Only trust the email if the provider asserts it has been verified
+	if verified, _ := claims.ExtraClaims["email_verified"].(bool); !verified {
+		return false
+	}
+
+	return slices.ContainsFunc(domains, func(d string) bool {
+		return strings.EqualFold(d, domain)
+	})
+}
+
+// claimValues returns a claim as a list of strings. String claims yield one value,
+// list claims yield their string elements; other types yield nothing.
+func claimValues(claims *OIDCClaims, name string) []string {
+	switch name {
+	case "sub":
+		return []string{claims.Subject}
+	case "iss":
+		return []string{claims.Issuer}
+	}
+
+	switch v := claims.ExtraClaims[name].(type) {
+	case string:
+		return []string{v}
+	case []any:
+		var values []string
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	case []string:
+		return v
+	}
+	return nil
+}
+
+// expandPermissionTemplate substitutes claim references in a permission pattern.
+// A pattern referencing a list-valued claim expands to one pattern per value. Values that
+// are missing or contain characters other than alphanumerics and hyphens are dropped.
+func expandPermissionTemplate(pattern string, claims *OIDCClaims) []string {
+	refs := permissionTemplateRegex.FindAllStringSubmatch(pattern, -1)
+	if len(refs) == 0 {
+		return []string{pattern}
+	}
+
+	results := []string{pattern}
+	for _, ref := range refs {
+		placeholder, claimName := ref[0], ref[1]
+
+		var values []string
+		for _, value := range claimValues(claims, claimName) {
+			if templateValueRegex.MatchString(value) {
+				values = append(values, value)
+			}
+		}
+
+		var expanded []string
+		for _, partial := range results {
+			for _, value := range values {
+				expanded = append(expanded, strings.ReplaceAll(partial, placeholder, value))
+			}
+		}
+		results = expanded
+	}
+
+	return slices.Compact(slices.Sorted(slices.Values(results)))
+}
+
+// validateOIDCRules checks rules for missing match criteria, issuers and template syntax problems at startup
+func validateOIDCRules(rules []config.OIDCPermissionRule, issuers []config.OIDCIssuerConfig) error {
+	for i, rule := range rules {
+		if rule.Issuer == "" {
+			if len(issuers) > 1 {
+				return fmt.Errorf("OIDC rule %d requires issuer when several issuers are configured", i)
+			}
+		} else if !slices.ContainsFunc(issuers, func(issuer config.OIDCIssuerConfig) bool { return issuer.Issuer == rule.Issuer }) {
+			return fmt.Errorf("OIDC rule %d: issuer %s is not configured", i, rule.Issuer)
+		}
+		if rule.Match.HasCriteria() == rule.Match.Any {
+			return fmt.Errorf("OIDC rule %d must set either match criteria or any", i)
+		}
+		for _, pattern := range slices.Concat(rule.Publish, rule.Edit) {
+			stripped := permissionTemplateRegex.ReplaceAllString(pattern, "")
+			if strings.ContainsAny(stripped, "{}") {
+				return fmt.Errorf("OIDC rule %d: invalid permission template %q", i, pattern)
+			}
+		}
+		if rule.Match.Subject != "" {
+			if _, err := path.Match(rule.Match.Subject, ""); err != nil {
+				return fmt.Errorf("OIDC rule %d: invalid sub pattern %q: %w", i, rule.Match.Subject, err)
+			}
+		}
+	}
+	return nil
+}
@@ -2,10 +2,15 @@ package auth_test
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/modelcontextprotocol/registry/internal/api/handlers/v0/auth"
+	internalauth "github.com/modelcontextprotocol/registry/internal/auth"
 	"github.com/modelcontextprotocol/registry/internal/config"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -91,7 +96,7 @@ func TestOIDCHandler_ExchangeToken(t *testing.T) {
 			}
 
 			ctx := context.Background()
-			response, err := handler.ExchangeToken(ctx, tt.token)
+			response, err := handler.ExchangeToken(ctx, tt.token, nil)
 
 			if tt.expectedError {
 				assert.Error(t, err)
@@ -105,3 +110,279 @@ func TestOIDCHandler_ExchangeToken(t *testing.T) {
 		})
 	}
 }
+
+func TestOIDCHandler_PermissionRules(t *testing.T) {
+	issuer := newOIDCDiscoveryServer(t)
+	cfg := &config.Config{
+		OIDCEnabled:   true,
+		OIDCIssuer:    issuer.URL,
+		OIDCClientID:  "test-client-id",
+		JWTPrivateKey: "deadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeef",
+		OIDCRules: []config.OIDCPermissionRule{
+			{
+				Match:   config.OIDCRuleMatch{Groups: []string{"mcp-publishers"}},
+				Publish: []string{"com.acme.{claims.team}/*"},
+			},
+			{
+				Match: config.OIDCRuleMatch{EmailDomains: []string{"acme.com"}, Subject: "user-*"},
+				Edit:  []string{"com.acme/*"},
+			},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		claims        *auth.OIDCClaims
+		expectedPerms []internalauth.Permission
+	}{
+		{
+			name: "group member gets templated namespaces",
+			claims: &auth.OIDCClaims{
+				Subject: "service-1",
+				Issuer:  "https://accounts.google.com",
+				ExtraClaims: map[string]any{
+					"groups": []any{"mcp-publishers", "everyone"},
+					"team":   []any{"platform", "data"},
+				},
+			},
+			expectedPerms: []internalauth.Permission{
+				{Action: internalauth.PermissionActionPublish, ResourcePattern: "com.acme.data/*"},
+				{Action: internalauth.PermissionActionPublish, ResourcePattern: "com.acme.platform/*"},
+			},
+		},
+		{
+			name: "claim values that could widen the pattern are dropped",
+			claims: &auth.OIDCClaims{
+				Subject: "service-1",
+				Issuer:  "https://accounts.google.com",
+				ExtraClaims: map[string]any{
+					"groups": []any{"mcp-publishers"},
+					"team":   "*",
+				},
+			},
+			expectedPerms: nil,
+		},
+		{
+			name: "verified email domain and subject pattern",
+			claims: &auth.OIDCClaims{
+				Subject: "user-42",
+				Issuer:  "https://accounts.google.com",
+				ExtraClaims: map[string]any{
+					"email":          "jo@acme.com",
+					"email_verified": true,
+				},
+			},
+			expectedPerms: []internalauth.Permission{
+				{Action: internalauth.PermissionActionEdit, ResourcePattern: "com.acme/*"},
+			},
+		},
+		{
+			name: "unverified email does not match",
+			claims: &auth.OIDCClaims{
+				Subject: "user-42",
+				Issuer:  "https://accounts.google.com",
+				ExtraClaims: map[string]any{
+					"email":          "jo@acme.com",
+					"email_verified": false,
+				},
+			},
+			expectedPerms: nil,
+		},
+		{
+			name: "email without verification claim does not match",
+			claims: &auth.OIDCClaims{
+				Subject: "user-42",
+				Issuer:  "https://accounts.google.com",
+				ExtraClaims: map[string]any{
+					"email": "jo@acme.com",
+				},
+			},
+			expectedPerms: nil,
+		},
+	}
+
+	handler := auth.NewOIDCHandler(cfg)
+	jwtManager := internalauth.NewJWTManager(cfg)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler.SetValidator(&MockGenericOIDCValidator{
+				validateFunc: func(_ context.Context, _ string) (*auth.OIDCClaims, error) {
+					return tt.claims, nil
+				},
+			})
+
+			response, err := handler.ExchangeToken(context.Background(), "test-token", nil)
+			require.NoError(t, err)
+
+			claims, err := jwtManager.ValidateToken(context.Background(), response.RegistryToken)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedPerms, claims.Permissions)
+		})
+	}
+}
+
+func TestOIDCHandler_RulesWithoutMatchCriteria(t *testing.T) {
+	issuer := newOIDCDiscoveryServer(t)
+	newConfig := func(match config.OIDCRuleMatch) *config.Config {
+		return &config.Config{
+			OIDCEnabled:   true,
+			OIDCIssuer:    issuer.URL,
+			OIDCClientID:  "test-client-id",
+			JWTPrivateKey: "deadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeef",
+			OIDCRules:     []config.OIDCPermissionRule{{Match: match, Publish: []string{"com.acme/*"}}},
+		}
+	}
+
+	assert.PanicsWithValue(t, "Invalid OIDC permission rules: OIDC rule 0 must set either match criteria or any", func() {
+		auth.NewOIDCHandler(newConfig(config.OIDCRuleMatch{}))
+	}, "an empty match block would grant the permissions to every token")
+	assert.Panics(t, func() {
+		auth.NewOIDCHandler(newConfig(config.OIDCRuleMatch{Any: true, Groups: []string{"mcp-publishers"}}))
+	})
+	assert.NotPanics(t, func() {
+		auth.NewOIDCHandler(newConfig(config.OIDCRuleMatch{Any: true}))
+	})
+}
+
+func TestOIDCHandler_RulesWithoutIssuer(t *testing.T) {
+	acme := newOIDCDiscoveryServer(t)
+	globex := newOIDCDiscoveryServer(t)
+	newConfig := func(ruleIssuer string) *config.Config {
+		return &config.Config{
+			OIDCEnabled:   true,
+			OIDCIssuer:    acme.URL,
+			OIDCClientID:  "acme-registry",
+			JWTPrivateKey: "deadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeef",
+			OIDCIssuers:   []config.OIDCIssuerConfig{{Issuer: globex.URL, ClientID: "globex-registry"}},
+			OIDCRules: []config.OIDCPermissionRule{{
+				Issuer:  ruleIssuer,
+				Match:   config.OIDCRuleMatch{Groups: []string{"mcp-publishers"}},
+				Publish: []string{"com.acme/*"},
+			}},
+		}
+	}
+
+	assert.PanicsWithValue(t, "Invalid OIDC permission rules: OIDC rule 0 requires issuer when several issuers are configured", func() {
+		auth.NewOIDCHandler(newConfig(""))
+	})
+	assert.PanicsWithValue(t, "Invalid OIDC permission rules: OIDC rule 0: issuer https://other.example.com is not configured", func() {
+		auth.NewOIDCHandler(newConfig("https://other.example.com"))
+	})
+	assert.NotPanics(t, func() {
+		auth.NewOIDCHandler(newConfig(acme.URL))
+	})
+}
+
+// newOIDCDiscoveryServer serves a minimal OIDC discovery document so validators can be created offline
+func newOIDCDiscoveryServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"issuer":                                server.URL,
+			"jwks_uri":                              server.URL + "/jwks",
+			"authorization_endpoint":                server.URL + "/authorize",
+			"token_endpoint":                        server.URL + "/token",
+			"id_token_signing_alg_values_supported": []string{"RS256"},
+		})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestOIDCHandler_MultipleIssuers(t *testing.T) {
+	acme := newOIDCDiscoveryServer(t)
+	globex := newOIDCDiscoveryServer(t)
+
+	cfg := &config.Config{
+		OIDCEnabled:   true,
+		JWTPrivateKey: "deadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeef",
+		OIDCIssuers: []config.OIDCIssuerConfig{
+			{
+				Issuer:             acme.URL,
+				ClientID:           "acme-registry",
+				ExtraClaims:        []map[string]any{{"hd": "acme.com"}},
+				PublishPermissions: []string{"com.acme/*"},
+			},
+			{
+				Issuer:             globex.URL,
+				ClientID:           "globex-registry",
+				PublishPermissions: []string{"com.globex/*"},
+			},
+		},
+		OIDCRules: []config.OIDCPermissionRule{
+			{
+				Issuer:  acme.URL,
+				Match:   config.OIDCRuleMatch{Groups: []string{"mcp-publishers"}},
+				Publish: []string{"com.acme.tools/*"},
+			},
+		},
+	}
+
+	handler := auth.NewOIDCHandler(cfg)
+	for _, issuerURL := range []string{acme.URL, globex.URL} {
+		handler.SetIssuerValidator(issuerURL, &MockGenericOIDCValidator{
+			validateFunc: func(_ context.Context, _ string) (*auth.OIDCClaims, error) {
+				return &auth.OIDCClaims{
+					Subject:     "user-1",
+					Issuer:      issuerURL,
+					ExtraClaims: map[string]any{"hd": "acme.com", "groups": []any{"mcp-publishers"}},
+				}, nil
+			},
+		})
+	}
+
+	// Tokens are routed by their iss claim before the issuer's validator verifies them
+	unsignedToken := func(issuer string) string {
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{Issuer: issuer}).SignedString([]byte("test"))
+		require.NoError(t, err)
+		return token
+	}
+
+	jwtManager := internalauth.NewJWTManager(cfg)
+
+	tests := []struct {
+		name          string
+		issuer        string
+		expectedPerms []internalauth.Permission
+		expectedError string
+	}{
+		{
+			name:   "first issuer",
+			issuer: acme.URL,
+			expectedPerms: []internalauth.Permission{
+				{Action: internalauth.PermissionActionPublish, ResourcePattern: "com.acme/*"},
+				{Action: internalauth.PermissionActionPublish, ResourcePattern: "com.acme.tools/*"},
+			},
+		},
+		{
+			// Members of a group with the same name at another organization do not match its rules
+			name:   "second issuer",
+			issuer: globex.URL,
+			expectedPerms: []internalauth.Permission{
+				{Action: internalauth.PermissionActionPublish, ResourcePattern: "com.globex/*"},
+			},
+		},
+		{
+			name:          "unknown issuer",
+			issuer:        "https://evil.example.com",
+			expectedError: "not trusted",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := handler.ExchangeToken(context.Background(), unsignedToken(tt.issuer), nil)
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+
+			claims, err := jwtManager.ValidateToken(context.Background(), response.RegistryToken)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedPerms, claims.Permissions)
+		})
+	}
+}
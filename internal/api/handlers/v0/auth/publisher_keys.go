@@ -0,0 +1,65 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+)
+
+// PublisherKeys looks up the keys publishers registered for DNS or HTTP authentication, so that
+// artifacts they sign can be attributed to their namespace
+type PublisherKeys struct {
+	resolver DNSResolver
+	fetcher  HTTPKeyFetcher
+}
+
+// NewPublisherKeys creates a lookup of publisher keys using the same DNS and HTTP sources as login
+func NewPublisherKeys(cfg *config.Config) *PublisherKeys {
+	return &PublisherKeys{
+		resolver: newDNSResolver(cfg),
+		fetcher:  NewDefaultHTTPKeyFetcher(),
+	}
+}
+
+// SetResolver sets a custom DNS resolver (used for testing)
+func (k *PublisherKeys) SetResolver(resolver DNSResolver) {
+	k.resolver = resolver
+}
+
+// SetFetcher sets a custom HTTP key fetcher (used for testing)
+func (k *PublisherKeys) SetFetcher(fetcher HTTPKeyFetcher) {
+	k.fetcher = fetcher
+}
+
+// VerifySignature reports whether signature is a valid signature over message by a key registered
+// for the namespace of serverName. As with login, DNS keys also cover subdomains while HTTP keys
+// only cover their own domain.
+func (k *PublisherKeys) VerifySignature(ctx context.Context, serverName string, message, signature []byte) error {
+	namespace, _, _ := strings.Cut(serverName, "/")
+	domain := ReverseString(namespace)
+	if !strings.Contains(domain, ".") || !IsValidDomain(domain) {
+		return fmt.Errorf("namespace '%s' does not correspond to a domain", namespace)
+	}
+
+	var keyStrings []string
+	for parent := domain; strings.Contains(parent, "."); _, parent, _ = strings.Cut(parent, ".") {
+		if records, err := k.resolver.LookupTXT(ctx, parent); err == nil {
+			keyStrings = append(keyStrings, records...)
+		}
+	}
+	if key, err := k.fetcher.FetchKey(ctx, domain); err == nil {
+		keyStrings = append(keyStrings, key)
+	}
+
+	publicKeys := ParseMCPKeysFromStrings(keyStrings)
+	if len(publicKeys) == 0 {
+		return fmt.Errorf("no MCP public keys found for %s in DNS TXT records or at https://%s/.well-known/mcp-registry-auth", domain, domain)
+	}
+
+	if !VerifySignatureWithKeys(publicKeys, message, signature) {
+		return fmt.Errorf("signature verification failed")
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package auth_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/api/handlers/v0/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+)
+
+func TestPublisherKeys_VerifySignature(t *testing.T) {
+	ctx := context.Background()
+
+	dnsPublicKey, dnsPrivateKey, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	httpPublicKey, httpPrivateKey, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+
+	keys := auth.NewPublisherKeys(&config.Config{})
+	keys.SetResolver(&MockDNSResolver{
+		txtRecords: map[string][]string{
+			testDomain: {fmt.Sprintf("v=MCPv1; k=ed25519; p=%s", base64.StdEncoding.EncodeToString(dnsPublicKey))},
+		},
+	})
+	keys.SetFetcher(&MockHTTPKeyFetcher{
+		keyResponses: map[string]string{
+			"http.example.org": fmt.Sprintf("v=MCPv1; k=ed25519; p=%s", base64.StdEncoding.EncodeToString(httpPublicKey)),
+		},
+	})
+
+	message := []byte("fe333e598595000ae021bd27117db32ec69af6987f507ba7a63c90638ff633ce")
+	dnsSignature := ed25519.Sign(dnsPrivateKey, message)
+	httpSignature := ed25519.Sign(httpPrivateKey, message)
+
+	tests := []struct {
+		name         string
+		serverName   string
+		signature    []byte
+		errorMessage string
+	}{
+		{
+			name:       "DNS key of the namespace domain",
+			serverName: "com.example/weather",
+			signature:  dnsSignature,
+		},
+		{
+			name:       "DNS key of a parent domain",
+			serverName: "com.example.api/weather",
+			signature:  dnsSignature,
+		},
+		{
+			name:       "HTTP key of the namespace domain",
+			serverName: "org.example.http/weather",
+			signature:  httpSignature,
+		},
+		{
+			name:         "HTTP key does not cover subdomains",
+			serverName:   "org.example.http.api/weather",
+			signature:    httpSignature,
+			errorMessage: "no MCP public keys found",
+		},
+		{
+			name:         "signature by another namespace's key",
+			serverName:   "com.example/weather",
+			signature:    httpSignature,
+			errorMessage: "signature verification failed",
+		},
+		{
+			name:         "namespace without keys",
+			serverName:   "net.example/weather",
+			signature:    dnsSignature,
+			errorMessage: "no MCP public keys found",
+		},
+		{
+			name:         "namespace that is not a domain",
+			serverName:   "weather/weather",
+			signature:    dnsSignature,
+			errorMessage: "does not correspond to a domain",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := keys.VerifySignature(ctx, tt.serverName, message, tt.signature)
+			if tt.errorMessage != "" {
+				assert.ErrorContains(t, err, tt.errorMessage)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -2,14 +2,18 @@ package v0
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"strings"
 
 	"github.com/danielgtaylor/huma/v2"
 	"github.com/modelcontextprotocol/registry/internal/auth"
 	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
 	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/modelcontextprotocol/registry/internal/validators"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
 )
 
 // PublishServerInput represents the input for publishing a server
@@ -18,6 +22,19 @@ type PublishServerInput struct {
 	Body          apiv0.ServerJSON `body:""`
 }
 
+// PublishServerOutput is the published server, or the pending server with status 202 when the
+// registry validates publishes asynchronously
+type PublishServerOutput struct {
+	Status int
+	Body   apiv0.ServerResponse
+}
+
+// PublishStatusInput represents the input for polling the outcome of a publish
+type PublishStatusInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token (obtained from /v0/auth/token/github)" required:"true"`
+	ID            string `path:"id" doc:"Publish ID returned by the publish endpoint" example:"0b0f8e8c-3b36-4f0d-9f59-6d4f4a9f3b2e"`
+}
+
 // RegisterPublishEndpoint registers the publish endpoint with a custom path prefix
 func RegisterPublishEndpoint(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
 	// Create JWT manager for token validation
@@ -33,7 +50,7 @@ func RegisterPublishEndpoint(api huma.API, pathPrefix string, registry service.R
 		Security: []map[string][]string{
 			{"bearer": {}},
 		},
-	}, func(ctx context.Context, input *PublishServerInput) (*Response[apiv0.ServerResponse], error) {
+	}, func(ctx context.Context, input *PublishServerInput) (*PublishServerOutput, error) {
 		// Extract bearer token
 		const bearerPrefix = "Bearer "
 		authHeader := input.Authorization
@@ -56,12 +73,63 @@ func RegisterPublishEndpoint(api huma.API, pathPrefix string, registry service.R
 		// Publish the server with extensions
 		publishedServer, err := registry.CreateServer(ctx, &input.Body)
 		if err != nil {
+			if errors.Is(err, validators.ErrRegistryRateLimited) {
+				return nil, huma.Error503ServiceUnavailable("Failed to publish server", err)
+			}
 			return nil, huma.Error400BadRequest("Failed to publish server", err)
 		}
 
 		// Return the published server response with metadata
-		return &Response[apiv0.ServerResponse]{
-			Body: *publishedServer,
+		status := http.StatusOK
+		if publishedServer.Meta.Official != nil && publishedServer.Meta.Official.Status == model.StatusPending {
+			status = http.StatusAccepted
+		}
+		return &PublishServerOutput{
+			Status: status,
+			Body:   *publishedServer,
+		}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-publish-status" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/publish/{id}",
+		Summary:     "Get publish status",
+		Description: "Get the outcome of a publish that the registry validates asynchronously: pending, active or rejected with a reason",
+		Tags:        []string{"publish"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *PublishStatusInput) (*Response[apiv0.PublishStatus], error) {
+		// Extract bearer token
+		const bearerPrefix = "Bearer "
+		authHeader := input.Authorization
+		if len(authHeader) < len(bearerPrefix) || !strings.EqualFold(authHeader[:len(bearerPrefix)], bearerPrefix) {
+			return nil, huma.Error401Unauthorized("Invalid Authorization header format. Expected 'Bearer <token>'")
+		}
+		token := authHeader[len(bearerPrefix):]
+
+		// Validate Registry JWT token
+		claims, err := jwtManager.ValidateToken(ctx, token)
+		if err != nil {
+			return nil, huma.Error401Unauthorized("Invalid or expired Registry JWT token", err)
+		}
+
+		status, err := registry.GetPublishStatus(ctx, input.ID)
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				return nil, huma.Error404NotFound("Publish not found")
+			}
+			return nil, huma.Error500InternalServerError("Failed to get publish status", err)
+		}
+
+		// Only publishers of the server may see the outcome of its publishes
+		if !jwtManager.HasPermission(status.ServerName, auth.PermissionActionPublish, claims.Permissions) {
+			return nil, huma.Error404NotFound("Publish not found")
+		}
+
+		return &Response[apiv0.PublishStatus]{
+			Body: *status,
 		}, nil
 	})
 }
@@ -23,6 +23,9 @@ type ListServersInput struct {
 	UpdatedSince string `query:"updated_since" doc:"Filter servers updated since timestamp (RFC3339 datetime)" required:"false" example:"2025-08-07T13:15:04.280Z"`
 	Search       string `query:"search" doc:"Search servers by name (substring match)" required:"false" example:"filesystem"`
 	Version      string `query:"version" doc:"Filter by version ('latest' for latest version, or an exact version like '1.2.3')" required:"false" example:"latest"`
+	Tool         string `query:"tool" doc:"Filter servers exposing a tool whose name contains this substring" required:"false" example:"weather"`
+	Prompt       string `query:"prompt" doc:"Filter servers exposing a prompt whose name contains this substring" required:"false" example:"summarize"`
+	Resource     string `query:"resource" doc:"Filter servers exposing a resource whose name or URI contains this substring" required:"false" example:"file://"`
 }
 
 // ServerDetailInput represents the input for getting server details
@@ -70,6 +73,17 @@ func RegisterServersEndpoints(api huma.API, pathPrefix string, registry service.
 			filter.SubstringName = &input.Search
 		}
 
+		// Handle capability parameters
+		if input.Tool != "" {
+			filter.ToolName = &input.Tool
+		}
+		if input.Prompt != "" {
+			filter.PromptName = &input.Prompt
+		}
+		if input.Resource != "" {
+			filter.ResourceName = &input.Resource
+		}
+
 		// Handle version parameter
 		if input.Version != "" {
 			if input.Version == "latest" {
@@ -146,6 +160,98 @@ func RegisterServersEndpoints(api huma.API, pathPrefix string, registry service.
 		}, nil
 	})
 
+	// Get remote status of a server version endpoint (supports "latest" as special version)
+	huma.Register(api, huma.Operation{
+		OperationID: "get-server-version-status" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/servers/{serverName}/versions/{version}/status",
+		Summary:     "Get remote status of an MCP server version",
+		Description: "Get the liveness of the remotes of a specific version of an MCP server, as probed by the registry with an MCP initialize handshake, including uptime and the latest probes. Use the special version 'latest' for the latest version.",
+		Tags:        []string{"servers"},
+	}, func(ctx context.Context, input *ServerVersionDetailInput) (*Response[apiv0.RemoteStatusResponse], error) {
+		// URL-decode the server name
+		serverName, err := url.PathUnescape(input.ServerName)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid server name encoding", err)
+		}
+
+		// URL-decode the version
+		version, err := url.PathUnescape(input.Version)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid version encoding", err)
+		}
+
+		// Handle "latest" as a special version
+		if version == "latest" {
+			serverResponse, err := registry.GetServerByName(ctx, serverName)
+			if err != nil {
+				if err.Error() == errRecordNotFound || errors.Is(err, database.ErrNotFound) {
+					return nil, huma.Error404NotFound("Server not found")
+				}
+				return nil, huma.Error500InternalServerError("Failed to get server details", err)
+			}
+			version = serverResponse.Server.Version
+		}
+
+		status, err := registry.GetRemoteStatus(ctx, serverName, version)
+		if err != nil {
+			if err.Error() == errRecordNotFound || errors.Is(err, database.ErrNotFound) {
+				return nil, huma.Error404NotFound("Server not found")
+			}
+			return nil, huma.Error500InternalServerError("Failed to get server status", err)
+		}
+
+		return &Response[apiv0.RemoteStatusResponse]{
+			Body: *status,
+		}, nil
+	})
+
+	// Get capabilities of a server version endpoint (supports "latest" as special version)
+	huma.Register(api, huma.Operation{
+		OperationID: "get-server-version-capabilities" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/servers/{serverName}/versions/{version}/capabilities",
+		Summary:     "Get capabilities of an MCP server version",
+		Description: "Get the tools, prompts and resources a specific version of an MCP server offers, as supplied by its publisher or harvested by probing its remotes. Use the special version 'latest' for the latest version.",
+		Tags:        []string{"servers"},
+	}, func(ctx context.Context, input *ServerVersionDetailInput) (*Response[apiv0.ServerCapabilitiesResponse], error) {
+		// URL-decode the server name
+		serverName, err := url.PathUnescape(input.ServerName)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid server name encoding", err)
+		}
+
+		// URL-decode the version
+		version, err := url.PathUnescape(input.Version)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid version encoding", err)
+		}
+
+		// Handle "latest" as a special version
+		if version == "latest" {
+			serverResponse, err := registry.GetServerByName(ctx, serverName)
+			if err != nil {
+				if err.Error() == errRecordNotFound || errors.Is(err, database.ErrNotFound) {
+					return nil, huma.Error404NotFound("Server not found")
+				}
+				return nil, huma.Error500InternalServerError("Failed to get server details", err)
+			}
+			version = serverResponse.Server.Version
+		}
+
+		capabilities, err := registry.GetServerCapabilities(ctx, serverName, version)
+		if err != nil {
+			if err.Error() == errRecordNotFound || errors.Is(err, database.ErrNotFound) {
+				return nil, huma.Error404NotFound("Server not found")
+			}
+			return nil, huma.Error500InternalServerError("Failed to get server capabilities", err)
+		}
+
+		return &Response[apiv0.ServerCapabilitiesResponse]{
+			Body: *capabilities,
+		}, nil
+	})
+
 	// Get server versions endpoint
 	huma.Register(api, huma.Operation{
 		OperationID: "get-server-versions" + strings.ReplaceAll(pathPrefix, "/", "-"),
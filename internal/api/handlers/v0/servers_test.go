@@ -300,6 +300,131 @@ func TestGetServerVersionEndpoint(t *testing.T) {
 	}
 }
 
+func TestGetServerVersionStatusEndpoint(t *testing.T) {
+	ctx := context.Background()
+	registryService := service.NewRegistryService(database.NewTestDB(t), config.NewConfig())
+
+	serverName := "com.example/status-server"
+	_, err := registryService.CreateServer(ctx, &apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        serverName,
+		Description: "Status test server",
+		Version:     "1.0.0",
+		Remotes: []model.Transport{
+			{Type: model.TransportTypeStreamableHTTP, URL: "https://status.example.com/mcp"},
+		},
+	})
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterServersEndpoints(api, "/v0", registryService)
+
+	tests := []struct {
+		name           string
+		serverName     string
+		version        string
+		expectedStatus int
+	}{
+		{name: "get status of existing version", serverName: serverName, version: "1.0.0", expectedStatus: http.StatusOK},
+		{name: "get status of latest version", serverName: serverName, version: "latest", expectedStatus: http.StatusOK},
+		{name: "get status of non-existent version", serverName: serverName, version: "2.0.0", expectedStatus: http.StatusNotFound},
+		{name: "get status of non-existent server", serverName: "com.example/non-existent", version: "latest", expectedStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/v0/servers/"+url.PathEscape(tt.serverName)+"/versions/"+url.PathEscape(tt.version)+"/status", nil)
+			w := httptest.NewRecorder()
+
+			mux.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var resp apiv0.RemoteStatusResponse
+				require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+				assert.Equal(t, serverName, resp.ServerName)
+				assert.Equal(t, "1.0.0", resp.Version)
+				assert.Empty(t, resp.Remotes, "remotes that were never probed have no status")
+			}
+		})
+	}
+}
+
+func TestGetServerVersionCapabilitiesEndpoint(t *testing.T) {
+	ctx := context.Background()
+	registryService := service.NewRegistryService(database.NewTestDB(t), config.NewConfig())
+
+	serverName := "com.example/capable-server"
+	_, err := registryService.CreateServer(ctx, &apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        serverName,
+		Description: "Capabilities test server",
+		Version:     "1.0.0",
+		Meta: &apiv0.ServerMeta{
+			Capabilities: &apiv0.CapabilityManifest{
+				Tools: []apiv0.ToolCapability{{Name: "get_weather", Description: "Get the current weather"}},
+			},
+		},
+	})
+	require.NoError(t, err)
+	_, err = registryService.CreateServer(ctx, &apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "com.example/plain-server",
+		Description: "Server without declared capabilities",
+		Version:     "1.0.0",
+	})
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterServersEndpoints(api, "/v0", registryService)
+
+	tests := []struct {
+		name           string
+		serverName     string
+		version        string
+		expectedStatus int
+	}{
+		{name: "get capabilities of existing version", serverName: serverName, version: "1.0.0", expectedStatus: http.StatusOK},
+		{name: "get capabilities of latest version", serverName: serverName, version: "latest", expectedStatus: http.StatusOK},
+		{name: "get capabilities of non-existent version", serverName: serverName, version: "2.0.0", expectedStatus: http.StatusNotFound},
+		{name: "get capabilities of non-existent server", serverName: "com.example/non-existent", version: "latest", expectedStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/v0/servers/"+url.PathEscape(tt.serverName)+"/versions/"+url.PathEscape(tt.version)+"/capabilities", nil)
+			w := httptest.NewRecorder()
+
+			mux.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var resp apiv0.ServerCapabilitiesResponse
+				require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+				assert.Equal(t, serverName, resp.ServerName)
+				assert.Equal(t, "1.0.0", resp.Version)
+				assert.Equal(t, apiv0.CapabilitySourcePublisher, resp.Source)
+				assert.Equal(t, []apiv0.ToolCapability{{Name: "get_weather", Description: "Get the current weather"}}, resp.Capabilities.Tools)
+			}
+		})
+	}
+
+	t.Run("list servers by tool", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v0/servers?tool=weather", nil)
+		w := httptest.NewRecorder()
+
+		mux.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var resp apiv0.ServerListResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		require.Len(t, resp.Servers, 1)
+		assert.Equal(t, serverName, resp.Servers[0].Server.Name)
+	})
+}
+
 func TestGetAllVersionsEndpoint(t *testing.T) {
 	ctx := context.Background()
 	registryService := service.NewRegistryService(database.NewTestDB(t), config.NewConfig())
@@ -43,7 +43,7 @@ func TestOpenAPIEndpointCompliance(t *testing.T) {
 	}
 
 	// Register V0 routes exactly like production does
-	router.RegisterV0Routes(api, cfg, nil, nil) // nil service and metrics for schema testing
+	router.RegisterV0Routes(api, cfg, nil, nil, nil) // nil service, metrics and nonce store for schema testing
 
 	// Get the OpenAPI schema
 	req := httptest.NewRequest(http.MethodGet, "/openapi.yaml", nil)
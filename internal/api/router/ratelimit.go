@@ -0,0 +1,214 @@
+package router
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/ratelimit"
+	"github.com/modelcontextprotocol/registry/internal/telemetry"
+)
+
+// maxPublishBodyPeek bounds how much of a publish request body is read to find the server name
+const maxPublishBodyPeek = 1 << 20
+
+// rateLimitCheck is a single key checked against a budget for a request
+type rateLimitCheck struct {
+	key    string
+	budget ratelimit.Budget
+}
+
+// RateLimitMiddleware throttles the publish and auth endpoints.
+// Publish requests are limited per client IP, token subject and the namespace of the
+// published server; auth token exchanges are limited per client IP.
+func RateLimitMiddleware(api huma.API, cfg *config.Config, limiter ratelimit.Limiter, metrics *telemetry.Metrics) func(huma.Context, func(huma.Context)) {
+	var jwtManager *auth.JWTManager
+	if cfg.JWTPrivateKey != "" {
+		jwtManager = auth.NewJWTManager(cfg)
+	}
+
+	return func(ctx huma.Context, next func(huma.Context)) {
+		routePath := getRoutePath(ctx)
+		checks := rateLimitChecks(ctx, cfg, jwtManager, routePath)
+		if len(checks) == 0 {
+			next(ctx)
+			return
+		}
+
+		var (
+			tightest *ratelimit.Result
+			denied   *ratelimit.Result
+		)
+		for _, check := range checks {
+			// A non-positive budget disables that check
+			if check.budget.Limit <= 0 || check.budget.Window <= 0 {
+				continue
+			}
+			result, err := limiter.Allow(ctx.Context(), check.key, check.budget)
+			if err != nil {
+				// Fail open: an unavailable limiter backend should not take down publishing
+				log.Printf("Rate limiter error for %s: %v", routePath, err)
+				continue
+			}
+			if tightest == nil || result.Remaining < tightest.Remaining {
+				tightest = &result
+			}
+			if !result.Allowed && (denied == nil || result.ResetAt.After(denied.ResetAt)) {
+				denied = &result
+			}
+		}
+
+		if tightest != nil {
+			setRateLimitHeaders(ctx, *tightest)
+		}
+
+		if denied != nil {
+			setRateLimitHeaders(ctx, *denied)
+			retryAfter := int(time.Until(denied.ResetAt).Seconds()) + 1
+			ctx.SetHeader("Retry-After", strconv.Itoa(retryAfter))
+
+			if metrics != nil {
+				metrics.RateLimited.Add(ctx.Context(), 1, metric.WithAttributes(
+					attribute.String("method", ctx.Method()),
+					attribute.String("path", routePath),
+				))
+			}
+
+			_ = huma.WriteErr(api, ctx, http.StatusTooManyRequests,
+				"Rate limit exceeded. Retry after "+strconv.Itoa(retryAfter)+" seconds")
+			return
+		}
+
+		next(ctx)
+	}
+}
+
+// rateLimitChecks returns the keys and budgets that apply to the request
+func rateLimitChecks(ctx huma.Context, cfg *config.Config, jwtManager *auth.JWTManager, routePath string) []rateLimitCheck {
+	ip := clientIP(ctx, cfg.RateLimitTrustForwardedFor, cfg.RateLimitTrustedProxyHops)
+
+	switch {
+	case strings.HasSuffix(routePath, "/publish"):
+		checks := []rateLimitCheck{{
+			key:    "publish:ip:" + ip,
+			budget: ratelimit.Budget{Limit: cfg.RateLimitPublishPerIP, Window: cfg.RateLimitPublishWindow},
+		}}
+
+		claims := tokenClaims(ctx, jwtManager)
+		if claims == nil {
+			return checks
+		}
+
+		checks = append(checks, rateLimitCheck{
+			key:    "publish:sub:" + string(claims.AuthMethod) + ":" + claims.AuthMethodSubject,
+			budget: ratelimit.Budget{Limit: cfg.RateLimitPublishPerSubject, Window: cfg.RateLimitPublishWindow},
+		})
+		// Only publishes the token is allowed to make count against the namespace, so that
+		// tokens cannot exhaust the budget of namespaces they do not own
+		if name := publishServerName(ctx); name != "" && jwtManager.HasPermission(name, auth.PermissionActionPublish, claims.Permissions) {
+			namespace, _, _ := strings.Cut(name, "/")
+			checks = append(checks, rateLimitCheck{
+				key:    "publish:ns:" + namespace,
+				budget: ratelimit.Budget{Limit: cfg.RateLimitPublishPerNamespace, Window: cfg.RateLimitPublishWindow},
+			})
+		}
+		return checks
+
+	case strings.Contains(routePath, "/auth/"):
+		return []rateLimitCheck{{
+			key:    "auth:ip:" + ip,
+			budget: ratelimit.Budget{Limit: cfg.RateLimitAuthPerIP, Window: cfg.RateLimitAuthWindow},
+		}}
+	}
+
+	return nil
+}
+
+// publishServerName returns the name of the server in a publish request body, if any.
+// The body is left intact for the handler.
+func publishServerName(ctx huma.Context) string {
+	r, _ := humago.Unwrap(ctx)
+	if r.Body == nil {
+		return ""
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r.Body, maxPublishBodyPeek))
+	r.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(data), r.Body), r.Body}
+	if err != nil {
+		return ""
+	}
+
+	var body struct {
+		Name string `json:"name"`
+	}
+	if err := json.Unmarshal(data, &body); err != nil {
+		return ""
+	}
+	return body.Name
+}
+
+// tokenClaims returns the claims of a valid Registry JWT on the request, if any.
+// Invalid tokens are left for the handler to reject.
+func tokenClaims(ctx huma.Context, jwtManager *auth.JWTManager) *auth.JWTClaims {
+	if jwtManager == nil {
+		return nil
+	}
+
+	const bearerPrefix = "Bearer "
+	authHeader := ctx.Header("Authorization")
+	if len(authHeader) < len(bearerPrefix) || !strings.EqualFold(authHeader[:len(bearerPrefix)], bearerPrefix) {
+		return nil
+	}
+
+	claims, err := jwtManager.ValidateToken(ctx.Context(), authHeader[len(bearerPrefix):])
+	if err != nil {
+		return nil
+	}
+	return claims
+}
+
+// clientIP extracts the client IP. When forwarded headers are trusted, it is the X-Forwarded-For
+// entry added by the outermost of the given number of trusted proxies, counted from the right:
+// entries to its left are supplied by the client and can be spoofed.
+func clientIP(ctx huma.Context, trustForwardedFor bool, trustedProxyHops int) string {
+	if trustForwardedFor {
+		if forwarded := ctx.Header("X-Forwarded-For"); forwarded != "" {
+			entries := strings.Split(forwarded, ",")
+			index := max(len(entries)-max(trustedProxyHops, 1), 0)
+			return strings.TrimSpace(entries[index])
+		}
+	}
+
+	host, _, err := net.SplitHostPort(ctx.RemoteAddr())
+	if err != nil {
+		return ctx.RemoteAddr()
+	}
+	return host
+}
+
+// setRateLimitHeaders sets the RateLimit-* response headers
+func setRateLimitHeaders(ctx huma.Context, result ratelimit.Result) {
+	reset := int(time.Until(result.ResetAt).Seconds())
+	if reset < 0 {
+		reset = 0
+	}
+	ctx.SetHeader("RateLimit-Limit", strconv.Itoa(result.Limit))
+	ctx.SetHeader("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+	ctx.SetHeader("RateLimit-Reset", strconv.Itoa(reset))
+}
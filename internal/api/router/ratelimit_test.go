@@ -0,0 +1,154 @@
+package router_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/api/router"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/ratelimit"
+)
+
+func TestRateLimitMiddleware(t *testing.T) {
+	cfg := &config.Config{
+		RateLimitAuthPerIP:  2,
+		RateLimitAuthWindow: time.Minute,
+	}
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	api.UseMiddleware(router.RateLimitMiddleware(api, cfg, ratelimit.NewMemoryLimiter(), nil))
+
+	handler := func(_ context.Context, _ *struct{}) (*struct{}, error) { return nil, nil }
+	huma.Register(api, huma.Operation{Method: http.MethodPost, Path: "/v0/auth/none"}, handler)
+	huma.Register(api, huma.Operation{Method: http.MethodGet, Path: "/v0/servers"}, handler)
+
+	do := func(method, path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		req.RemoteAddr = "192.0.2.1:1234"
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+
+	for i := 0; i < 2; i++ {
+		w := do(http.MethodPost, "/v0/auth/none")
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		assert.Equal(t, "2", w.Header().Get("RateLimit-Limit"))
+	}
+
+	w := do(http.MethodPost, "/v0/auth/none")
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "0", w.Header().Get("RateLimit-Remaining"))
+	assert.NotEmpty(t, w.Header().Get("Retry-After"))
+
+	// Unlimited routes are not affected
+	w = do(http.MethodGet, "/v0/servers")
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Empty(t, w.Header().Get("RateLimit-Limit"))
+}
+
+func TestRateLimitMiddleware_ForwardedFor(t *testing.T) {
+	cfg := &config.Config{
+		RateLimitAuthPerIP:         1,
+		RateLimitAuthWindow:        time.Minute,
+		RateLimitTrustForwardedFor: true,
+		RateLimitTrustedProxyHops:  2,
+	}
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	api.UseMiddleware(router.RateLimitMiddleware(api, cfg, ratelimit.NewMemoryLimiter(), nil))
+	huma.Register(api, huma.Operation{Method: http.MethodPost, Path: "/v0/auth/none"}, func(_ context.Context, _ *struct{}) (*struct{}, error) {
+		return nil, nil
+	})
+
+	do := func(forwardedFor string) int {
+		req := httptest.NewRequest(http.MethodPost, "/v0/auth/none", nil)
+		req.RemoteAddr = "10.0.0.2:1234"
+		req.Header.Set("X-Forwarded-For", forwardedFor)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	// The client address is the one appended by the outer proxy, in front of the load balancer's
+	assert.Equal(t, http.StatusNoContent, do("198.51.100.7, 10.0.0.1"))
+	assert.Equal(t, http.StatusNoContent, do("203.0.113.9, 10.0.0.1"))
+
+	// Entries supplied by the client are ignored, so spoofing them does not reset the budget
+	assert.Equal(t, http.StatusTooManyRequests, do("192.0.2.55, 198.51.100.7, 10.0.0.1"))
+	assert.Equal(t, http.StatusTooManyRequests, do("192.0.2.56, 198.51.100.7, 10.0.0.1"))
+}
+
+func TestRateLimitMiddleware_PublishNamespace(t *testing.T) {
+	seed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(seed)
+	require.NoError(t, err)
+	cfg := &config.Config{
+		JWTPrivateKey:                hex.EncodeToString(seed),
+		RateLimitPublishPerNamespace: 1,
+		RateLimitPublishWindow:       time.Minute,
+	}
+
+	// A member of two organizations
+	token, err := auth.NewJWTManager(cfg).GenerateTokenResponse(context.Background(), auth.JWTClaims{
+		AuthMethod:        auth.MethodGitHubAT,
+		AuthMethodSubject: "octocat",
+		Permissions: []auth.Permission{
+			{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.org-a/*"},
+			{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.org-b/*"},
+		},
+	})
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	api.UseMiddleware(router.RateLimitMiddleware(api, cfg, ratelimit.NewMemoryLimiter(), nil))
+
+	type publishInput struct {
+		Body struct {
+			Name string `json:"name"`
+		}
+	}
+	var published []string
+	huma.Register(api, huma.Operation{Method: http.MethodPost, Path: "/v0/publish"}, func(_ context.Context, input *publishInput) (*struct{}, error) {
+		published = append(published, input.Body.Name)
+		return nil, nil
+	})
+
+	publish := func(name string) int {
+		req := httptest.NewRequest(http.MethodPost, "/v0/publish", strings.NewReader(`{"name":"`+name+`"}`))
+		req.Header.Set("Authorization", "Bearer "+token.RegistryToken)
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	assert.Equal(t, http.StatusNoContent, publish("io.github.org-a/server"))
+	assert.Equal(t, http.StatusTooManyRequests, publish("io.github.org-a/other-server"))
+
+	// Publishing to one namespace does not spend the budget of the others the token can publish to
+	assert.Equal(t, http.StatusNoContent, publish("io.github.org-b/server"))
+
+	// Publishes the token is not allowed to make are left for the handler to reject
+	assert.Equal(t, http.StatusNoContent, publish("io.github.org-c/server"))
+	assert.Equal(t, http.StatusNoContent, publish("io.github.org-c/server"))
+
+	// The body reaches the handler intact
+	assert.Equal(t, []string{"io.github.org-a/server", "io.github.org-b/server", "io.github.org-c/server", "io.github.org-c/server"}, published)
+}
@@ -13,7 +13,9 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
 
+	"github.com/modelcontextprotocol/registry/internal/auth"
 	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/ratelimit"
 	"github.com/modelcontextprotocol/registry/internal/service"
 	"github.com/modelcontextprotocol/registry/internal/telemetry"
 )
@@ -128,8 +130,9 @@ func handle404(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// NewHumaAPI creates a new Huma API with all routes registered
-func NewHumaAPI(cfg *config.Config, registry service.RegistryService, mux *http.ServeMux, metrics *telemetry.Metrics) huma.API {
+// NewHumaAPI creates a new Huma API with all routes registered.
+// A nil limiter disables rate limiting. A nil nonceStore tracks used auth nonces in memory.
+func NewHumaAPI(cfg *config.Config, registry service.RegistryService, mux *http.ServeMux, metrics *telemetry.Metrics, limiter ratelimit.Limiter, nonceStore auth.NonceStore) huma.API {
 	// Create Huma API configuration
 	humaConfig := huma.DefaultConfig("Official MCP Registry", "1.0.0")
 	humaConfig.Info.Description = "A community driven registry service for Model Context Protocol (MCP) servers.\n\n[GitHub repository](https://github.com/modelcontextprotocol/registry) | [Documentation](https://github.com/modelcontextprotocol/registry/tree/main/docs)"
@@ -172,9 +175,19 @@ func NewHumaAPI(cfg *config.Config, registry service.RegistryService, mux *http.
 		WithSkipPaths("/health", "/metrics", "/ping", "/docs"),
 	))
 
+	// Add rate limiting for publish and auth endpoints
+	if limiter != nil {
+		api.UseMiddleware(RateLimitMiddleware(api, cfg, limiter, metrics))
+	}
+
+	// Share one nonce store between API versions, so a nonce cannot be replayed against the other prefix
+	if nonceStore == nil {
+		nonceStore = auth.NewMemoryNonceStore()
+	}
+
 	// Register routes for all API versions
-	RegisterV0Routes(api, cfg, registry, metrics)
-	RegisterV0_1Routes(api, cfg, registry, metrics)
+	RegisterV0Routes(api, cfg, registry, metrics, nonceStore)
+	RegisterV0_1Routes(api, cfg, registry, metrics, nonceStore)
 
 	// Add /metrics for Prometheus metrics using promhttp
 	mux.Handle("/metrics", metrics.PrometheusHandler())
@@ -6,29 +6,30 @@ import (
 
 	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
 	v0auth "github.com/modelcontextprotocol/registry/internal/api/handlers/v0/auth"
+	"github.com/modelcontextprotocol/registry/internal/auth"
 	"github.com/modelcontextprotocol/registry/internal/config"
 	"github.com/modelcontextprotocol/registry/internal/service"
 	"github.com/modelcontextprotocol/registry/internal/telemetry"
 )
 
 func RegisterV0Routes(
-	api huma.API, cfg *config.Config, registry service.RegistryService, metrics *telemetry.Metrics,
+	api huma.API, cfg *config.Config, registry service.RegistryService, metrics *telemetry.Metrics, nonceStore auth.NonceStore,
 ) {
 	v0.RegisterHealthEndpoint(api, "/v0", cfg, metrics)
 	v0.RegisterPingEndpoint(api, "/v0")
 	v0.RegisterServersEndpoints(api, "/v0", registry)
 	v0.RegisterEditEndpoints(api, "/v0", registry, cfg)
-	v0auth.RegisterAuthEndpoints(api, "/v0", cfg)
+	v0auth.RegisterAuthEndpoints(api, "/v0", cfg, nonceStore)
 	v0.RegisterPublishEndpoint(api, "/v0", registry, cfg)
 }
 
 func RegisterV0_1Routes(
-	api huma.API, cfg *config.Config, registry service.RegistryService, metrics *telemetry.Metrics,
+	api huma.API, cfg *config.Config, registry service.RegistryService, metrics *telemetry.Metrics, nonceStore auth.NonceStore,
 ) {
 	v0.RegisterHealthEndpoint(api, "/v0.1", cfg, metrics)
 	v0.RegisterPingEndpoint(api, "/v0.1")
 	v0.RegisterServersEndpoints(api, "/v0.1", registry)
 	v0.RegisterEditEndpoints(api, "/v0.1", registry, cfg)
-	v0auth.RegisterAuthEndpoints(api, "/v0.1", cfg)
+	v0auth.RegisterAuthEndpoints(api, "/v0.1", cfg, nonceStore)
 	v0.RegisterPublishEndpoint(api, "/v0.1", registry, cfg)
 }
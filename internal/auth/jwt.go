@@ -69,8 +69,14 @@ func NewJWTManager(cfg *config.Config) *JWTManager {
 	}
 }
 
-// GenerateToken generates a new Registry JWT token
-func (j *JWTManager) GenerateTokenResponse(_ context.Context, claims JWTClaims) (*TokenResponse, error) {
+// GenerateTokenResponse generates a new Registry JWT token
+func (j *JWTManager) GenerateTokenResponse(ctx context.Context, claims JWTClaims) (*TokenResponse, error) {
+	return j.GenerateScopedTokenResponse(ctx, claims, nil)
+}
+
+// GenerateScopedTokenResponse generates a new Registry JWT token. If a scope is requested, the
+// token's permissions are narrowed to the intersection of the claims' permissions and that scope.
+func (j *JWTManager) GenerateScopedTokenResponse(_ context.Context, claims JWTClaims, scope []Permission) (*TokenResponse, error) {
 	// Check whether they have global permissions (used by admins)
 	hasGlobalPermissions := false
 	for _, perm := range claims.Permissions {
@@ -89,6 +95,15 @@ func (j *JWTManager) GenerateTokenResponse(_ context.Context, claims JWTClaims)
 		}
 	}
 
+	// Narrow the token to the requested scope, after the denylist check so that it
+	// applies to the caller's full permissions
+	if len(scope) > 0 {
+		claims.Permissions = NarrowPermissions(claims.Permissions, scope)
+		if len(claims.Permissions) == 0 {
+			return nil, ErrScopeNotPermitted
+		}
+	}
+
 	if claims.IssuedAt == nil {
 		claims.IssuedAt = jwt.NewNumericDate(time.Now())
 	}
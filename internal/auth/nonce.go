@@ -0,0 +1,136 @@
+package auth
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+)
+
+// NonceTTL is how long an issued challenge nonce can be used for
+const NonceTTL = 2 * time.Minute
+
+var (
+	ErrInvalidNonce = errors.New("invalid nonce")
+	ErrExpiredNonce = errors.New("nonce has expired")
+	ErrUsedNonce    = errors.New("nonce has already been used")
+)
+
+// NonceStore records which nonces have been used
+type NonceStore interface {
+	// MarkNonceUsed records the nonce as used until expiresAt. It returns false if it was already used.
+	MarkNonceUsed(ctx context.Context, nonce string, expiresAt time.Time) (bool, error)
+}
+
+// NonceManager issues and verifies single-use challenge nonces for signature-based authentication.
+// Nonces are self-contained (random value, expiry and MAC), so any replica can verify them;
+// only used nonces are recorded in the store.
+type NonceManager struct {
+	key   []byte
+	store NonceStore
+	now   func() time.Time
+}
+
+// NewNonceManager creates a nonce manager whose MAC key is derived from the JWT private key
+func NewNonceManager(cfg *config.Config, store NonceStore) *NonceManager {
+	key := sha256.Sum256([]byte("mcp-registry-auth-nonce:" + cfg.JWTPrivateKey))
+	return &NonceManager{
+		key:   key[:],
+		store: store,
+		now:   time.Now,
+	}
+}
+
+// Issue creates a new nonce and returns it with its expiry time
+func (m *NonceManager) Issue() (string, time.Time, error) {
+	random := make([]byte, 16)
+	if _, err := rand.Read(random); err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	expiresAt := m.now().Add(NonceTTL).Truncate(time.Second)
+	payload := hex.EncodeToString(random) + "." + strconv.FormatInt(expiresAt.Unix(), 10)
+
+	return payload + "." + m.mac(payload), expiresAt, nil
+}
+
+// Consume verifies that the nonce was issued by this registry and has not expired, and
+// marks it as used so it cannot be replayed
+func (m *NonceManager) Consume(ctx context.Context, nonce string) error {
+	lastDot := strings.LastIndex(nonce, ".")
+	if lastDot < 0 {
+		return ErrInvalidNonce
+	}
+	payload, mac := nonce[:lastDot], nonce[lastDot+1:]
+	if !hmac.Equal([]byte(mac), []byte(m.mac(payload))) {
+		return ErrInvalidNonce
+	}
+
+	_, expiry, found := strings.Cut(payload, ".")
+	if !found {
+		return ErrInvalidNonce
+	}
+	expiresUnix, err := strconv.ParseInt(expiry, 10, 64)
+	if err != nil {
+		return ErrInvalidNonce
+	}
+	expiresAt := time.Unix(expiresUnix, 0)
+	if !m.now().Before(expiresAt) {
+		return ErrExpiredNonce
+	}
+
+	fresh, err := m.store.MarkNonceUsed(ctx, nonce, expiresAt)
+	if err != nil {
+		return fmt.Errorf("failed to record nonce: %w", err)
+	}
+	if !fresh {
+		return ErrUsedNonce
+	}
+
+	return nil
+}
+
+func (m *NonceManager) mac(payload string) string {
+	h := hmac.New(sha256.New, m.key)
+	h.Write([]byte(payload))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// MemoryNonceStore is an in-process NonceStore. Used nonces are not shared between replicas.
+type MemoryNonceStore struct {
+	mu   sync.Mutex
+	used map[string]time.Time
+}
+
+// NewMemoryNonceStore creates a new in-memory nonce store
+func NewMemoryNonceStore() *MemoryNonceStore {
+	return &MemoryNonceStore{used: make(map[string]time.Time)}
+}
+
+// MarkNonceUsed records the nonce as used until expiresAt. It returns false if it was already used.
+func (s *MemoryNonceStore) MarkNonceUsed(_ context.Context, nonce string, expiresAt time.Time) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for n, exp := range s.used {
+		if now.After(exp) {
+			delete(s.used, n)
+		}
+	}
+
+	if _, ok := s.used[nonce]; ok {
+		return false, nil
+	}
+	s.used[nonce] = expiresAt
+	return true, nil
+}
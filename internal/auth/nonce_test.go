@@ -0,0 +1,80 @@
+package auth_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNonceManager(t *testing.T) {
+	ctx := context.Background()
+	cfg := &config.Config{
+		JWTPrivateKey: "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+	}
+	manager := auth.NewNonceManager(cfg, auth.NewMemoryNonceStore())
+
+	t.Run("nonce can be used once", func(t *testing.T) {
+		nonce, expiresAt, err := manager.Issue()
+		require.NoError(t, err)
+		assert.WithinDuration(t, time.Now().Add(auth.NonceTTL), expiresAt, 2*time.Second)
+
+		require.NoError(t, manager.Consume(ctx, nonce))
+		assert.ErrorIs(t, manager.Consume(ctx, nonce), auth.ErrUsedNonce)
+	})
+
+	t.Run("nonces are unique", func(t *testing.T) {
+		first, _, err := manager.Issue()
+		require.NoError(t, err)
+		second, _, err := manager.Issue()
+		require.NoError(t, err)
+		assert.NotEqual(t, first, second)
+	})
+
+	t.Run("nonce issued by another manager with the same key is accepted", func(t *testing.T) {
+		nonce, _, err := auth.NewNonceManager(cfg, auth.NewMemoryNonceStore()).Issue()
+		require.NoError(t, err)
+		assert.NoError(t, manager.Consume(ctx, nonce))
+	})
+
+	t.Run("nonce from a different registry key is rejected", func(t *testing.T) {
+		other := auth.NewNonceManager(&config.Config{JWTPrivateKey: strings.Repeat("ab", 32)}, auth.NewMemoryNonceStore())
+		nonce, _, err := other.Issue()
+		require.NoError(t, err)
+		assert.ErrorIs(t, manager.Consume(ctx, nonce), auth.ErrInvalidNonce)
+	})
+
+	t.Run("tampered expiry is rejected", func(t *testing.T) {
+		nonce, _, err := manager.Issue()
+		require.NoError(t, err)
+		parts := strings.Split(nonce, ".")
+		require.Len(t, parts, 3)
+		parts[1] = strconv.FormatInt(time.Now().Add(24*time.Hour).Unix(), 10)
+		assert.ErrorIs(t, manager.Consume(ctx, strings.Join(parts, ".")), auth.ErrInvalidNonce)
+	})
+
+	t.Run("malformed nonce is rejected", func(t *testing.T) {
+		assert.ErrorIs(t, manager.Consume(ctx, "not-a-nonce"), auth.ErrInvalidNonce)
+		assert.ErrorIs(t, manager.Consume(ctx, ""), auth.ErrInvalidNonce)
+	})
+
+	t.Run("expired nonce is rejected", func(t *testing.T) {
+		// Build a correctly authenticated nonce whose expiry is in the past
+		key := sha256.Sum256([]byte("mcp-registry-auth-nonce:" + cfg.JWTPrivateKey))
+		payload := "00112233445566778899aabbccddeeff." + strconv.FormatInt(time.Now().Add(-time.Minute).Unix(), 10)
+		mac := hmac.New(sha256.New, key[:])
+		mac.Write([]byte(payload))
+		nonce := payload + "." + hex.EncodeToString(mac.Sum(nil))
+
+		assert.ErrorIs(t, manager.Consume(ctx, nonce), auth.ErrExpiredNonce)
+	})
+}
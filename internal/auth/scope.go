@@ -0,0 +1,68 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrScopeNotPermitted is returned when none of the requested scope is covered by the caller's permissions
+var ErrScopeNotPermitted = errors.New("requested scope is not covered by your permissions")
+
+// ValidateScope checks that a requested scope only contains known actions and
+// resource patterns with at most a trailing wildcard
+func ValidateScope(scope []Permission) error {
+	for _, perm := range scope {
+		switch perm.Action {
+		case PermissionActionPublish, PermissionActionEdit:
+		default:
+			return fmt.Errorf("invalid scope action %q: must be publish or edit", perm.Action)
+		}
+		if perm.ResourcePattern == "" {
+			return fmt.Errorf("scope resource is required")
+		}
+		if strings.Contains(strings.TrimSuffix(perm.ResourcePattern, "*"), "*") {
+			return fmt.Errorf("invalid scope resource %q: wildcard is only allowed at the end", perm.ResourcePattern)
+		}
+	}
+	return nil
+}
+
+// NarrowPermissions returns the intersection of the entitled permissions and the requested scope.
+// For each pair with the same action, the narrower pattern is kept if one covers the other.
+func NarrowPermissions(entitled, requested []Permission) []Permission {
+	var narrowed []Permission
+	seen := make(map[Permission]bool)
+	for _, req := range requested {
+		for _, ent := range entitled {
+			if req.Action != ent.Action {
+				continue
+			}
+
+			var perm Permission
+			switch {
+			case patternCovers(ent.ResourcePattern, req.ResourcePattern):
+				perm = req
+			case patternCovers(req.ResourcePattern, ent.ResourcePattern):
+				perm = ent
+			default:
+				continue
+			}
+
+			if !seen[perm] {
+				seen[perm] = true
+				narrowed = append(narrowed, perm)
+			}
+		}
+	}
+	return narrowed
+}
+
+// patternCovers reports whether every resource matched by inner is also matched by outer
+func patternCovers(outer, inner string) bool {
+	if strings.HasSuffix(inner, "*") {
+		return strings.HasSuffix(outer, "*") &&
+			strings.HasPrefix(strings.TrimSuffix(inner, "*"), strings.TrimSuffix(outer, "*"))
+	}
+	return isResourceMatch(inner, outer)
+}
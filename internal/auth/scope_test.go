@@ -0,0 +1,121 @@
+package auth_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+)
+
+func publish(pattern string) auth.Permission {
+	return auth.Permission{Action: auth.PermissionActionPublish, ResourcePattern: pattern}
+}
+
+func edit(pattern string) auth.Permission {
+	return auth.Permission{Action: auth.PermissionActionEdit, ResourcePattern: pattern}
+}
+
+func TestNarrowPermissions(t *testing.T) {
+	entitled := []auth.Permission{
+		publish("io.github.octocat/*"),
+		publish("io.github.octo-org/*"),
+	}
+
+	tests := []struct {
+		name      string
+		requested []auth.Permission
+		expected  []auth.Permission
+	}{
+		{
+			name:      "single server within namespace",
+			requested: []auth.Permission{publish("io.github.octo-org/weather")},
+			expected:  []auth.Permission{publish("io.github.octo-org/weather")},
+		},
+		{
+			name:      "narrower wildcard within namespace",
+			requested: []auth.Permission{publish("io.github.octo-org/weather-*")},
+			expected:  []auth.Permission{publish("io.github.octo-org/weather-*")},
+		},
+		{
+			name:      "broader request is capped at entitlement",
+			requested: []auth.Permission{publish("io.github.*")},
+			expected:  entitled,
+		},
+		{
+			name:      "other namespace is dropped",
+			requested: []auth.Permission{publish("io.github.someone-else/server"), publish("io.github.octocat/server")},
+			expected:  []auth.Permission{publish("io.github.octocat/server")},
+		},
+		{
+			name:      "action must match",
+			requested: []auth.Permission{edit("io.github.octo-org/weather")},
+			expected:  nil,
+		},
+		{
+			name:      "prefix without wildcard does not match",
+			requested: []auth.Permission{publish("io.github.octo-org")},
+			expected:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, auth.NarrowPermissions(entitled, tt.requested))
+		})
+	}
+
+	t.Run("admin wildcard is narrowed to request", func(t *testing.T) {
+		admin := []auth.Permission{publish("*"), edit("*")}
+		narrowed := auth.NarrowPermissions(admin, []auth.Permission{edit("com.example/server")})
+		assert.Equal(t, []auth.Permission{edit("com.example/server")}, narrowed)
+	})
+}
+
+func TestValidateScope(t *testing.T) {
+	assert.NoError(t, auth.ValidateScope([]auth.Permission{publish("com.example/*"), edit("com.example/server")}))
+	assert.Error(t, auth.ValidateScope([]auth.Permission{{Action: "delete", ResourcePattern: "com.example/*"}}))
+	assert.Error(t, auth.ValidateScope([]auth.Permission{publish("")}))
+	assert.Error(t, auth.ValidateScope([]auth.Permission{publish("com.*/server")}))
+}
+
+func TestJWTManager_RequestedScope(t *testing.T) {
+	cfg := &config.Config{
+		JWTPrivateKey: "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+	}
+	jwtManager := auth.NewJWTManager(cfg)
+	claims := auth.JWTClaims{
+		AuthMethod:        auth.MethodGitHubAT,
+		AuthMethodSubject: "octocat",
+		Permissions:       []auth.Permission{publish("io.github.octocat/*"), publish("io.github.octo-org/*")},
+	}
+
+	t.Run("token is narrowed to requested scope", func(t *testing.T) {
+		ctx := context.Background()
+		response, err := jwtManager.GenerateScopedTokenResponse(ctx, claims, []auth.Permission{publish("io.github.octo-org/weather")})
+		require.NoError(t, err)
+
+		parsed, err := jwtManager.ValidateToken(ctx, response.RegistryToken)
+		require.NoError(t, err)
+		assert.Equal(t, []auth.Permission{publish("io.github.octo-org/weather")}, parsed.Permissions)
+		assert.True(t, jwtManager.HasPermission("io.github.octo-org/weather", auth.PermissionActionPublish, parsed.Permissions))
+		assert.False(t, jwtManager.HasPermission("io.github.octo-org/other", auth.PermissionActionPublish, parsed.Permissions))
+	})
+
+	t.Run("scope outside entitlement is rejected", func(t *testing.T) {
+		_, err := jwtManager.GenerateScopedTokenResponse(context.Background(), claims, []auth.Permission{publish("io.github.someone-else/*")})
+		assert.ErrorIs(t, err, auth.ErrScopeNotPermitted)
+	})
+
+	t.Run("no scope keeps all permissions", func(t *testing.T) {
+		response, err := jwtManager.GenerateTokenResponse(context.Background(), claims)
+		require.NoError(t, err)
+
+		parsed, err := jwtManager.ValidateToken(context.Background(), response.RegistryToken)
+		require.NoError(t, err)
+		assert.Equal(t, claims.Permissions, parsed.Permissions)
+	})
+}
@@ -8,6 +8,8 @@ const (
 	MethodGitHubAT Method = "github-at"
 	// GitHub Actions OIDC authentication
 	MethodGitHubOIDC Method = "github-oidc"
+	// GitLab CI OIDC authentication
+	MethodGitLabOIDC Method = "gitlab-oidc"
 	// Generic OIDC authentication
 	MethodOIDC Method = "oidc"
 	// DNS-based public/private key authentication
@@ -1,7 +1,10 @@
 package config
 
 import (
+	"time"
+
 	env "github.com/caarlos0/env/v11"
+	"github.com/sigstore/sigstore-go/pkg/root"
 )
 
 // Config holds the application configuration
@@ -16,6 +19,15 @@ type Config struct {
 	JWTPrivateKey            string `env:"JWT_PRIVATE_KEY" envDefault:""`
 	EnableAnonymousAuth      bool   `env:"ENABLE_ANONYMOUS_AUTH" envDefault:"false"`
 	EnableRegistryValidation bool   `env:"ENABLE_REGISTRY_VALIDATION" envDefault:"true"`
+	GitLabOIDCIssuer         string `env:"GITLAB_OIDC_ISSUER" envDefault:"https://gitlab.com"`
+
+	// Rejects DNS/HTTP logins that sign a timestamp instead of a challenge nonce
+	DisableLegacySignatureAuth bool `env:"DISABLE_LEGACY_SIGNATURE_AUTH" envDefault:"false"`
+
+	// DNS authentication: require DNSSEC-authenticated TXT records, resolved through trusted
+	// validating resolvers (tls://host[:port], https://host/path or loopback-only tcp://127.0.0.1[:port])
+	DNSAuthDNSSEC    bool     `env:"DNS_AUTH_DNSSEC" envDefault:"false"`
+	DNSAuthResolvers []string `env:"DNS_AUTH_RESOLVERS" envSeparator:"," envDefault:"https://cloudflare-dns.com/dns-query,https://dns.google/dns-query"`
 
 	// OIDC Configuration
 	OIDCEnabled      bool   `env:"OIDC_ENABLED" envDefault:"false"`
@@ -24,6 +36,79 @@ type Config struct {
 	OIDCExtraClaims  string `env:"OIDC_EXTRA_CLAIMS" envDefault:""`
 	OIDCEditPerms    string `env:"OIDC_EDIT_PERMISSIONS" envDefault:""`
 	OIDCPublishPerms string `env:"OIDC_PUBLISH_PERMISSIONS" envDefault:""`
+	// Path to a YAML file with additional issuers and claim-to-permission rules, see OIDCFileConfig
+	OIDCConfigFile string `env:"OIDC_CONFIG_FILE" envDefault:""`
+	// OIDCIssuers and OIDCRules are loaded from OIDCConfigFile
+	OIDCIssuers []OIDCIssuerConfig
+	OIDCRules   []OIDCPermissionRule
+
+	// Path to a YAML file allowing additional package registry hosts, see PackageRegistriesFileConfig
+	PackageRegistriesFile string `env:"PACKAGE_REGISTRIES_FILE" envDefault:""`
+	// PackageRegistries is loaded from PackageRegistriesFile
+	PackageRegistries []PackageRegistryConfig
+	// Module proxy used to resolve Go modules published to proxy.golang.org, e.g. an internal mirror
+	GoModuleProxy string `env:"GO_MODULE_PROXY" envDefault:"https://proxy.golang.org"`
+	// HTTP client for package validation: timeout per request including retries, retries of
+	// transient failures with exponential backoff, and a proxy (defaults to HTTP(S)_PROXY)
+	PackageValidationTimeout      time.Duration `env:"PACKAGE_VALIDATION_TIMEOUT" envDefault:"30s"`
+	PackageValidationMaxRetries   int           `env:"PACKAGE_VALIDATION_MAX_RETRIES" envDefault:"2"`
+	PackageValidationRetryBackoff time.Duration `env:"PACKAGE_VALIDATION_RETRY_BACKOFF" envDefault:"500ms"`
+	PackageValidationProxy        string        `env:"PACKAGE_VALIDATION_PROXY" envDefault:""`
+	// How long successful and failed package validations are cached, keyed by registry type,
+	// identifier and version; zero disables caching. Transient registry failures are never cached.
+	PackageValidationCacheTTL         time.Duration `env:"PACKAGE_VALIDATION_CACHE_TTL" envDefault:"10m"`
+	PackageValidationNegativeCacheTTL time.Duration `env:"PACKAGE_VALIDATION_NEGATIVE_CACHE_TTL" envDefault:"1m"`
+	// Registry types whose packages are rejected at publish, e.g. mcpb,oci
+	DisabledPackageRegistryTypes []string `env:"DISABLED_PACKAGE_REGISTRY_TYPES" envSeparator:","`
+	// Accepts publishes as pending and validates them against package registries in the background,
+	// so slow registries do not hold up publish requests. Outcomes are polled from GET /v0/publish/{id}.
+	AsyncPublishValidation bool `env:"ASYNC_PUBLISH_VALIDATION" envDefault:"false"`
+	// Background validation of pending publishes, when async publish validation is enabled:
+	// concurrent workers per replica (0 disables them), attempts before a publish is rejected, and
+	// the wait before retrying after a transient failure, doubled for each retry
+	PublishValidationWorkers      int           `env:"PUBLISH_VALIDATION_WORKERS" envDefault:"4"`
+	PublishValidationMaxAttempts  int           `env:"PUBLISH_VALIDATION_MAX_ATTEMPTS" envDefault:"5"`
+	PublishValidationRetryBackoff time.Duration `env:"PUBLISH_VALIDATION_RETRY_BACKOFF" envDefault:"30s"`
+	// How long active and rejected publish requests are kept for polling before they are deleted
+	// (0 keeps them)
+	PublishRequestRetention time.Duration `env:"PUBLISH_REQUEST_RETENTION" envDefault:"168h"`
+	// Re-validates the packages of active latest server versions at this interval (0 disables it),
+	// recording the outcome as health in the official metadata. Enable it on a single replica.
+	PackageRevalidationInterval time.Duration `env:"PACKAGE_REVALIDATION_INTERVAL" envDefault:"0"`
+	// Flags servers for admin review after this many failed re-validations in a row (0 never flags)
+	PackageRevalidationFlagAfter int `env:"PACKAGE_REVALIDATION_FLAG_AFTER" envDefault:"3"`
+	// Probes the remotes of active latest server versions at this interval with an MCP initialize
+	// handshake (0 disables it), recording their liveness in the official metadata. Enable it on a
+	// single replica.
+	RemoteProbeInterval time.Duration `env:"REMOTE_PROBE_INTERVAL" envDefault:"0"`
+	// Bounds each probe of a remote
+	RemoteProbeTimeout time.Duration `env:"REMOTE_PROBE_TIMEOUT" envDefault:"10s"`
+	// Lets probes connect to loopback, private and link-local addresses, e.g. for local development
+	RemoteProbeAllowPrivateNetworks bool `env:"REMOTE_PROBE_ALLOW_PRIVATE_NETWORKS" envDefault:"false"`
+	// How long probes are kept, which is also the period uptime is reported over
+	RemoteProbeRetention time.Duration `env:"REMOTE_PROBE_RETENTION" envDefault:"168h"`
+	// Rejects new publishes of OCI packages that are not pinned by digest (image@sha256:...)
+	RequireOCIDigest bool `env:"REQUIRE_OCI_DIGEST" envDefault:"false"`
+	// Verifies Sigstore signatures and provenance attestations of packages against the server's
+	// repository, and marks servers whose packages all verify with the official verified badge
+	VerifyPackageProvenance bool `env:"VERIFY_PACKAGE_PROVENANCE" envDefault:"false"`
+	// Path to a Sigstore trusted_root.json naming the Fulcio, Rekor and CT log keys to trust.
+	// If empty, the public-good Sigstore trusted root is fetched through TUF.
+	SigstoreTrustedRootFile string `env:"SIGSTORE_TRUSTED_ROOT_FILE" envDefault:""`
+	// SigstoreTrustedRoot is loaded from SigstoreTrustedRootFile
+	SigstoreTrustedRoot *root.TrustedRoot
+
+	// Rate limiting configuration
+	RateLimitEnabled             bool          `env:"RATE_LIMIT_ENABLED" envDefault:"false"`
+	RateLimitBackend             string        `env:"RATE_LIMIT_BACKEND" envDefault:"memory"`
+	RateLimitTrustForwardedFor   bool          `env:"RATE_LIMIT_TRUST_FORWARDED_FOR" envDefault:"false"`
+	RateLimitTrustedProxyHops    int           `env:"RATE_LIMIT_TRUSTED_PROXY_HOPS" envDefault:"1"`
+	RateLimitPublishWindow       time.Duration `env:"RATE_LIMIT_PUBLISH_WINDOW" envDefault:"1h"`
+	RateLimitPublishPerIP        int           `env:"RATE_LIMIT_PUBLISH_PER_IP" envDefault:"120"`
+	RateLimitPublishPerSubject   int           `env:"RATE_LIMIT_PUBLISH_PER_SUBJECT" envDefault:"60"`
+	RateLimitPublishPerNamespace int           `env:"RATE_LIMIT_PUBLISH_PER_NAMESPACE" envDefault:"60"`
+	RateLimitAuthWindow          time.Duration `env:"RATE_LIMIT_AUTH_WINDOW" envDefault:"1m"`
+	RateLimitAuthPerIP           int           `env:"RATE_LIMIT_AUTH_PER_IP" envDefault:"30"`
 }
 
 // NewConfig creates a new configuration with default values
@@ -35,5 +120,31 @@ func NewConfig() *Config {
 	if err != nil {
 		panic(err)
 	}
+
+	if cfg.OIDCConfigFile != "" {
+		fileConfig, err := LoadOIDCFileConfig(cfg.OIDCConfigFile)
+		if err != nil {
+			panic(err)
+		}
+		cfg.OIDCIssuers = fileConfig.Issuers
+		cfg.OIDCRules = fileConfig.Rules
+	}
+
+	if cfg.PackageRegistriesFile != "" {
+		fileConfig, err := LoadPackageRegistriesFileConfig(cfg.PackageRegistriesFile)
+		if err != nil {
+			panic(err)
+		}
+		cfg.PackageRegistries = fileConfig.Registries
+	}
+
+	if cfg.SigstoreTrustedRootFile != "" {
+		trustedRoot, err := LoadSigstoreTrustedRoot(cfg.SigstoreTrustedRootFile)
+		if err != nil {
+			panic(err)
+		}
+		cfg.SigstoreTrustedRoot = trustedRoot
+	}
+
 	return &cfg
 }
@@ -0,0 +1,103 @@
+package config
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// OIDCFileConfig is the structure of the YAML file referenced by OIDC_CONFIG_FILE
+type OIDCFileConfig struct {
+	Issuers []OIDCIssuerConfig   `yaml:"issuers"`
+	Rules   []OIDCPermissionRule `yaml:"rules"`
+}
+
+// OIDCIssuerConfig configures one trusted OIDC identity provider
+type OIDCIssuerConfig struct {
+	// Issuer is the issuer URL, used for discovery and to route tokens by their iss claim
+	Issuer string `yaml:"issuer"`
+	// ClientID is the audience tokens must be issued for
+	ClientID string `yaml:"client_id"`
+	// ExtraClaims lists claims that must be present with the given values, like OIDC_EXTRA_CLAIMS
+	ExtraClaims []map[string]any `yaml:"extra_claims"`
+	// PublishPermissions and EditPermissions are granted to every user of this issuer
+	PublishPermissions []string `yaml:"publish_permissions"`
+	EditPermissions    []string `yaml:"edit_permissions"`
+}
+
+// OIDCPermissionRule grants permissions to OIDC users whose claims match.
+// All populated match fields must match for the rule to apply.
+type OIDCPermissionRule struct {
+	// Issuer limits the rule to tokens from one issuer. It is required when several issuers are
+	// configured, so that a rule written for one organization's users cannot match another's.
+	Issuer string `yaml:"issuer"`
+	// Match selects which tokens the rule applies to
+	Match OIDCRuleMatch `yaml:"match"`
+	// Publish and Edit are permission patterns to grant. They may reference claims with
+	// templates like "com.acme.{claims.team}/*"; list-valued claims expand to one pattern per value.
+	Publish []string `yaml:"publish"`
+	Edit    []string `yaml:"edit"`
+}
+
+// OIDCRuleMatch describes the claims an OIDC token must have for a rule to apply
+type OIDCRuleMatch struct {
+	// Groups matches if the groups claim contains any of these values
+	Groups []string `yaml:"groups"`
+	// GroupsClaim is the name of the claim holding group membership (default "groups")
+	GroupsClaim string `yaml:"groups_claim"`
+	// EmailDomains matches if the email claim belongs to any of these domains and email_verified is true
+	EmailDomains []string `yaml:"email_domains"`
+	// Subject is a glob pattern (path.Match syntax) the sub claim must match
+	Subject string `yaml:"sub"`
+	// Claims are claims that must be present with exactly these string values
+	Claims map[string]string `yaml:"claims"`
+	// Any must be set, without other criteria, for a rule to apply to every token
+	Any bool `yaml:"any"`
+}
+
+// HasCriteria reports whether any claim criteria are set
+func (m OIDCRuleMatch) HasCriteria() bool {
+	return len(m.Groups) > 0 || len(m.EmailDomains) > 0 || m.Subject != "" || len(m.Claims) > 0
+}
+
+// LoadOIDCFileConfig reads and parses an OIDC configuration file
+func LoadOIDCFileConfig(path string) (*OIDCFileConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read OIDC config file: %w", err)
+	}
+
+	var fileConfig OIDCFileConfig
+	if err := yaml.Unmarshal(data, &fileConfig); err != nil {
+		return nil, fmt.Errorf("failed to parse OIDC config file: %w", err)
+	}
+
+	seen := make(map[string]bool)
+	for i, issuer := range fileConfig.Issuers {
+		if issuer.Issuer == "" || issuer.ClientID == "" {
+			return nil, fmt.Errorf("OIDC issuer %d requires issuer and client_id", i)
+		}
+		if seen[issuer.Issuer] {
+			return nil, fmt.Errorf("OIDC issuer %s is configured more than once", issuer.Issuer)
+		}
+		seen[issuer.Issuer] = true
+	}
+
+	for i, rule := range fileConfig.Rules {
+		if len(rule.Publish) == 0 && len(rule.Edit) == 0 {
+			return nil, fmt.Errorf("OIDC rule %d grants no permissions", i)
+		}
+		if rule.Issuer == "" && len(fileConfig.Issuers) > 1 {
+			return nil, fmt.Errorf("OIDC rule %d requires issuer when several issuers are configured", i)
+		}
+		if !rule.Match.HasCriteria() && !rule.Match.Any {
+			return nil, fmt.Errorf("OIDC rule %d has no match criteria; set match: {any: true} to apply it to every token", i)
+		}
+		if rule.Match.HasCriteria() && rule.Match.Any {
+			return nil, fmt.Errorf("OIDC rule %d combines any with other match criteria", i)
+		}
+	}
+
+	return &fileConfig, nil
+}
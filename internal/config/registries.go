@@ -0,0 +1,104 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// PackageRegistriesFileConfig is the structure of the YAML file referenced by PACKAGE_REGISTRIES_FILE
+type PackageRegistriesFileConfig struct {
+	Registries []PackageRegistryConfig `yaml:"registries"`
+}
+
+// PackageRegistryConfig allows one additional package registry host for a registry type
+type PackageRegistryConfig struct {
+	// Type is the registry type the host serves: npm, pypi, nuget, cargo, go, rubygems, maven or oci
+	Type string `yaml:"type"`
+	// BaseURL must match the package's registryBaseUrl exactly, without a trailing slash.
+	// For oci it is the registry's scheme and host, matched against the host in image references.
+	BaseURL string `yaml:"base_url"`
+	// Username and PasswordEnv configure basic auth. PasswordEnv and TokenEnv name environment
+	// variables holding the secrets, so they are not written to the file.
+	Username    string `yaml:"username"`
+	PasswordEnv string `yaml:"password_env"`
+	// TokenEnv configures bearer token auth
+	TokenEnv string `yaml:"token_env"`
+	// AuthHosts are the hosts, besides the registry's own, that an oci registry's token realm
+	// may be on. Credentials are only sent to https token realms on these hosts.
+	AuthHosts []string `yaml:"auth_hosts"`
+
+	// Password and Token are read from the environment when the file is loaded
+	Password string `yaml:"-"`
+	Token    string `yaml:"-"`
+}
+
+// LoadPackageRegistriesFileConfig reads and parses a package registries file, resolving credentials from the environment
+func LoadPackageRegistriesFileConfig(path string) (*PackageRegistriesFileConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read package registries file: %w", err)
+	}
+
+	var fileConfig PackageRegistriesFileConfig
+	if err := yaml.Unmarshal(data, &fileConfig); err != nil {
+		return nil, fmt.Errorf("failed to parse package registries file: %w", err)
+	}
+
+	seen := make(map[string]bool)
+	for i := range fileConfig.Registries {
+		registry := &fileConfig.Registries[i]
+
+		switch registry.Type {
+		case "npm", "pypi", "nuget", "cargo", "go", "rubygems", "maven", "oci":
+		default:
+			return nil, fmt.Errorf("package registry %d has unsupported type %q: must be npm, pypi, nuget, cargo, go, rubygems, maven or oci", i, registry.Type)
+		}
+
+		registry.BaseURL = strings.TrimSuffix(registry.BaseURL, "/")
+		u, err := url.Parse(registry.BaseURL)
+		if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			return nil, fmt.Errorf("package registry %d has invalid base_url %q", i, registry.BaseURL)
+		}
+		if registry.Type == "oci" && u.Path != "" {
+			return nil, fmt.Errorf("package registry %d: OCI base_url must not have a path, got %q", i, registry.BaseURL)
+		}
+
+		if len(registry.AuthHosts) > 0 && registry.Type != "oci" {
+			return nil, fmt.Errorf("package registry %s: auth_hosts is only supported for oci registries", registry.BaseURL)
+		}
+		for _, host := range registry.AuthHosts {
+			if host == "" || strings.ContainsAny(host, "/:@") {
+				return nil, fmt.Errorf("package registry %s has invalid auth host %q: must be a host name", registry.BaseURL, host)
+			}
+		}
+
+		key := registry.Type + " " + registry.BaseURL
+		if seen[key] {
+			return nil, fmt.Errorf("package registry %s is configured more than once", key)
+		}
+		seen[key] = true
+
+		if registry.TokenEnv != "" && (registry.Username != "" || registry.PasswordEnv != "") {
+			return nil, fmt.Errorf("package registry %s: token_env cannot be combined with username and password_env", registry.BaseURL)
+		}
+		if (registry.Username == "") != (registry.PasswordEnv == "") {
+			return nil, fmt.Errorf("package registry %s: username and password_env must be set together", registry.BaseURL)
+		}
+		if registry.PasswordEnv != "" {
+			if registry.Password = os.Getenv(registry.PasswordEnv); registry.Password == "" {
+				return nil, fmt.Errorf("package registry %s: environment variable %s is not set", registry.BaseURL, registry.PasswordEnv)
+			}
+		}
+		if registry.TokenEnv != "" {
+			if registry.Token = os.Getenv(registry.TokenEnv); registry.Token == "" {
+				return nil, fmt.Errorf("package registry %s: environment variable %s is not set", registry.BaseURL, registry.TokenEnv)
+			}
+		}
+	}
+
+	return &fileConfig, nil
+}
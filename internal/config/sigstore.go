@@ -0,0 +1,17 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/sigstore/sigstore-go/pkg/root"
+)
+
+// LoadSigstoreTrustedRoot reads the Sigstore trusted root referenced by SIGSTORE_TRUSTED_ROOT_FILE,
+// in the trusted_root.json format distributed through Sigstore's TUF repositories
+func LoadSigstoreTrustedRoot(path string) (*root.TrustedRoot, error) {
+	trustedRoot, err := root.NewTrustedRootFromPath(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load Sigstore trusted root: %w", err)
+	}
+	return trustedRoot, nil
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/jackc/pgx/v5"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
 )
 
 // Common database errors
@@ -27,6 +28,36 @@ type ServerFilter struct {
 	SubstringName *string    // for substring search on name
 	Version       *string    // for exact version matching
 	IsLatest      *bool      // for filtering latest versions only
+	ToolName      *string    // for substring search on the names of tools servers expose
+	PromptName    *string    // for substring search on the names of prompts servers expose
+	ResourceName  *string    // for substring search on the names and URIs of resources servers expose
+}
+
+// PublishRequest is a publish accepted for asynchronous validation
+type PublishRequest struct {
+	ID     string
+	Server apiv0.ServerJSON
+	// Status is pending until the request is validated, then active or rejected
+	Status model.Status
+	// Reason is why the request was rejected, or why its last attempt failed while pending
+	Reason    string
+	Attempts  int
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// RemoteProbe is the outcome of probing a remote of a server version
+type RemoteProbe struct {
+	URL       string
+	CheckedAt time.Time
+	// Live is set when the remote completed the handshake, or required authorization to start it
+	Live            bool
+	AuthRequired    bool
+	Latency         time.Duration
+	ProtocolVersion string
+	Capabilities    map[string]any
+	// Error is why the probe failed, empty if it succeeded
+	Error string
 }
 
 // Database defines the interface for database operations
@@ -56,6 +87,36 @@ type Database interface {
 	// AcquirePublishLock acquires an exclusive advisory lock for publishing a server
 	// This prevents race conditions when multiple versions are published concurrently
 	AcquirePublishLock(ctx context.Context, tx pgx.Tx, serverName string) error
+	// CreatePublishRequest stores a pending publish request for asynchronous validation
+	CreatePublishRequest(ctx context.Context, tx pgx.Tx, serverJSON *apiv0.ServerJSON) (*PublishRequest, error)
+	// GetPublishRequest retrieve a publish request by its ID
+	GetPublishRequest(ctx context.Context, tx pgx.Tx, id string) (*PublishRequest, error)
+	// ClaimPublishRequest leases the next pending publish request that is due for an attempt,
+	// so that no other worker attempts it before the lease expires
+	ClaimPublishRequest(ctx context.Context, tx pgx.Tx, lease time.Duration) (*PublishRequest, error)
+	// RetryPublishRequest schedules another attempt of a pending publish request
+	RetryPublishRequest(ctx context.Context, tx pgx.Tx, id string, retryAt time.Time, reason string) error
+	// CompletePublishRequest marks a pending publish request as active or rejected
+	CompletePublishRequest(ctx context.Context, tx pgx.Tx, id string, status model.Status, reason string) error
+	// DeletePublishRequestsBefore deletes active and rejected publish requests completed before the
+	// given time, and returns how many were deleted
+	DeletePublishRequestsBefore(ctx context.Context, tx pgx.Tx, before time.Time) (int64, error)
+	// RecordPackageHealth records the outcome of re-validating the packages of a server version,
+	// and flags it for review once flagAfter re-validations in a row failed (0 never flags)
+	RecordPackageHealth(ctx context.Context, tx pgx.Tx, serverName, version string, checkErr error, flagAfter int) (*apiv0.PackageHealth, error)
+	// RecordRemoteProbes records probes of the remotes of a server version, drops its probes checked
+	// before since, and stores the resulting status of the probed remotes with the server version
+	RecordRemoteProbes(ctx context.Context, tx pgx.Tx, serverName, version string, probes []RemoteProbe, since time.Time) ([]apiv0.RemoteStatus, error)
+	// ListRemoteStatus retrieve the status of the remotes of a server version from probes checked
+	// since the given time, with up to historyLimit of the latest probes of each remote
+	ListRemoteStatus(ctx context.Context, tx pgx.Tx, serverName, version string, since time.Time, historyLimit int) ([]apiv0.RemoteStatus, error)
+	// SetServerCapabilities replaces the capabilities of a server version with a manifest from the
+	// given source. Capabilities supplied by the publisher take precedence over harvested ones, which
+	// are not stored for versions with a publisher manifest. A nil manifest removes those of the source.
+	SetServerCapabilities(ctx context.Context, tx pgx.Tx, serverName, version string, manifest *apiv0.CapabilityManifest, source string) error
+	// GetServerCapabilities retrieve the capabilities of a server version and their source, which
+	// is empty if none are stored
+	GetServerCapabilities(ctx context.Context, tx pgx.Tx, serverName, version string) (*apiv0.CapabilityManifest, string, error)
 	// InTransaction executes a function within a database transaction
 	InTransaction(ctx context.Context, fn func(ctx context.Context, tx pgx.Tx) error) error
 	// Close closes the database connection
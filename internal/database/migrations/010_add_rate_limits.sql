@@ -0,0 +1,11 @@
+-- Add storage for fixed-window rate limit counters
+-- One row per limiter key; the counter resets when a request arrives in a new window
+
+CREATE TABLE IF NOT EXISTS rate_limits (
+    key TEXT PRIMARY KEY,
+    window_start TIMESTAMP WITH TIME ZONE NOT NULL,
+    count INTEGER NOT NULL DEFAULT 0
+);
+
+-- Allow stale windows to be cleaned up efficiently
+CREATE INDEX IF NOT EXISTS idx_rate_limits_window_start ON rate_limits (window_start);
@@ -0,0 +1,9 @@
+-- Track used challenge nonces for DNS/HTTP signature authentication
+-- Rows can be deleted once expired, since expired nonces are rejected anyway
+
+CREATE TABLE IF NOT EXISTS used_auth_nonces (
+    nonce TEXT PRIMARY KEY,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_used_auth_nonces_expires_at ON used_auth_nonces (expires_at);
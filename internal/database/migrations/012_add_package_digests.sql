@@ -0,0 +1,4 @@
+-- Record the manifest digests that OCI package references resolved to at publish time,
+-- so that clients can detect tags repointed after validation
+
+ALTER TABLE servers ADD COLUMN IF NOT EXISTS package_digests JSONB NOT NULL DEFAULT '{}';
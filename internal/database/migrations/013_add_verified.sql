@@ -0,0 +1,4 @@
+-- Record whether the packages of a server version were verified, through Sigstore signatures
+-- or provenance attestations, to have been built from the server's source repository
+
+ALTER TABLE servers ADD COLUMN IF NOT EXISTS verified BOOLEAN NOT NULL DEFAULT false;
@@ -0,0 +1,26 @@
+-- Publish requests accepted for asynchronous validation. A request stays pending until a worker
+-- validates its packages and publishes the server version (active), or rejects it with a reason.
+-- Workers lease a request by pushing next_attempt_at into the future while validating it.
+
+CREATE TABLE IF NOT EXISTS publish_requests (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    server_name VARCHAR(255) NOT NULL,
+    version VARCHAR(255) NOT NULL,
+    value JSONB NOT NULL,
+    status VARCHAR(50) NOT NULL DEFAULT 'pending',
+    reason TEXT NOT NULL DEFAULT '',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT check_publish_request_status CHECK (status IN ('pending', 'active', 'rejected'))
+);
+
+-- Only one pending request per server version
+CREATE UNIQUE INDEX IF NOT EXISTS idx_publish_requests_pending_version
+ON publish_requests (server_name, version)
+WHERE status = 'pending';
+
+CREATE INDEX IF NOT EXISTS idx_publish_requests_next_attempt
+ON publish_requests (next_attempt_at)
+WHERE status = 'pending';
@@ -0,0 +1,9 @@
+-- Record the outcome of periodic re-validation of the packages of published server versions.
+-- Servers whose packages fail re-validation repeatedly are flagged for admin review.
+
+ALTER TABLE servers ADD COLUMN IF NOT EXISTS last_checked_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE servers ADD COLUMN IF NOT EXISTS last_check_error TEXT NOT NULL DEFAULT '';
+ALTER TABLE servers ADD COLUMN IF NOT EXISTS check_failures INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE servers ADD COLUMN IF NOT EXISTS flagged_for_review BOOLEAN NOT NULL DEFAULT false;
+
+CREATE INDEX IF NOT EXISTS idx_servers_flagged_for_review ON servers (flagged_for_review) WHERE flagged_for_review = true;
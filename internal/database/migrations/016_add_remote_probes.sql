@@ -0,0 +1,23 @@
+-- Record liveness probes of the remotes of published server versions. Each probe performs an MCP
+-- initialize handshake. The probe history backs the uptime reported per remote, and the status of
+-- each remote as of its latest probe is kept with the server version for the official metadata.
+
+CREATE TABLE IF NOT EXISTS remote_probes (
+    id BIGSERIAL PRIMARY KEY,
+    server_name VARCHAR(255) NOT NULL,
+    version VARCHAR(255) NOT NULL,
+    url TEXT NOT NULL,
+    checked_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    live BOOLEAN NOT NULL,
+    auth_required BOOLEAN NOT NULL DEFAULT false,
+    latency_ms INTEGER NOT NULL DEFAULT 0,
+    protocol_version VARCHAR(50) NOT NULL DEFAULT '',
+    capabilities JSONB,
+    error TEXT NOT NULL DEFAULT '',
+    FOREIGN KEY (server_name, version) REFERENCES servers (server_name, version) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_remote_probes_server_version
+ON remote_probes (server_name, version, url, checked_at DESC);
+
+ALTER TABLE servers ADD COLUMN IF NOT EXISTS remote_status JSONB NOT NULL DEFAULT '[]';
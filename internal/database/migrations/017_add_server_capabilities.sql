@@ -0,0 +1,23 @@
+-- Capabilities of server versions: the tools, prompts and resources they offer, as supplied by the
+-- publisher in server.json or harvested by probing a remote. Stored one per row so that servers
+-- can be searched by the capabilities they expose.
+
+CREATE TABLE IF NOT EXISTS server_capabilities (
+    id BIGSERIAL PRIMARY KEY,
+    server_name VARCHAR(255) NOT NULL,
+    version VARCHAR(255) NOT NULL,
+    kind VARCHAR(20) NOT NULL,
+    name TEXT NOT NULL,
+    uri TEXT NOT NULL DEFAULT '',
+    description TEXT NOT NULL DEFAULT '',
+    source VARCHAR(20) NOT NULL,
+    FOREIGN KEY (server_name, version) REFERENCES servers (server_name, version) ON DELETE CASCADE,
+    CONSTRAINT check_server_capability_kind CHECK (kind IN ('tool', 'prompt', 'resource')),
+    CONSTRAINT check_server_capability_source CHECK (source IN ('publisher', 'probe'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_server_capabilities_server_version
+ON server_capabilities (server_name, version);
+
+CREATE INDEX IF NOT EXISTS idx_server_capabilities_kind_name
+ON server_capabilities (kind, lower(name));
@@ -0,0 +1,5 @@
+-- Completed publish requests are deleted once they are older than the retention period
+
+CREATE INDEX IF NOT EXISTS idx_publish_requests_completed
+ON publish_requests (updated_at)
+WHERE status IN ('active', 'rejected');
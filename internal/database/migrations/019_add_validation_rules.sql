@@ -0,0 +1,5 @@
+-- Record the package validation rules each server version was published under, so that
+-- re-validation does not hold versions published before stricter rules to those rules.
+-- Existing versions were published under the legacy rules (0).
+
+ALTER TABLE servers ADD COLUMN IF NOT EXISTS validation_rules INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,10 @@
+-- Trigram indexes for the substring searches of the tool, prompt and resource filters, which the
+-- btree index on lower(name) cannot serve.
+
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX IF NOT EXISTS idx_server_capabilities_name_trgm
+ON server_capabilities USING gin (name gin_trgm_ops);
+
+CREATE INDEX IF NOT EXISTS idx_server_capabilities_uri_trgm
+ON server_capabilities USING gin (uri gin_trgm_ops);
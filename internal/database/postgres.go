@@ -131,6 +131,21 @@ func (db *PostgreSQL) ListServers(
 			args = append(args, *filter.IsLatest)
 			argIndex++
 		}
+		if filter.ToolName != nil {
+			whereConditions = append(whereConditions, capabilityCondition("tool", "c.name ILIKE $%d", argIndex))
+			args = append(args, containsPattern(*filter.ToolName))
+			argIndex++
+		}
+		if filter.PromptName != nil {
+			whereConditions = append(whereConditions, capabilityCondition("prompt", "c.name ILIKE $%d", argIndex))
+			args = append(args, containsPattern(*filter.PromptName))
+			argIndex++
+		}
+		if filter.ResourceName != nil {
+			whereConditions = append(whereConditions, capabilityCondition("resource", "(c.name ILIKE $%[1]d OR c.uri ILIKE $%[1]d)", argIndex))
+			args = append(args, containsPattern(*filter.ResourceName))
+			argIndex++
+		}
 	}
 
 	// Add cursor pagination using compound serverName:version cursor
@@ -161,7 +176,7 @@ func (db *PostgreSQL) ListServers(
 
 	// Query servers table with hybrid column/JSON data
 	query := fmt.Sprintf(`
-        SELECT server_name, version, status, published_at, updated_at, is_latest, value
+        SELECT server_name, version, status, published_at, updated_at, is_latest, package_digests, verified, last_checked_at, last_check_error, flagged_for_review, remote_status, validation_rules, value
         FROM servers
         %s
         ORDER BY server_name, version
@@ -180,9 +195,16 @@ func (db *PostgreSQL) ListServers(
 		var serverName, version, status string
 		var publishedAt, updatedAt time.Time
 		var isLatest bool
+		var packageDigests map[string]string
+		var verified bool
+		var lastCheckedAt *time.Time
+		var lastCheckError string
+		var flagged bool
+		var remoteStatus []apiv0.RemoteStatus
+		var validationRules int
 		var valueJSON []byte
 
-		err := rows.Scan(&serverName, &version, &status, &publishedAt, &updatedAt, &isLatest, &valueJSON)
+		err := rows.Scan(&serverName, &version, &status, &publishedAt, &updatedAt, &isLatest, &packageDigests, &verified, &lastCheckedAt, &lastCheckError, &flagged, &remoteStatus, &validationRules, &valueJSON)
 		if err != nil {
 			return nil, "", fmt.Errorf("failed to scan server row: %w", err)
 		}
@@ -198,10 +220,15 @@ func (db *PostgreSQL) ListServers(
 			Server: serverJSON,
 			Meta: apiv0.ResponseMeta{
 				Official: &apiv0.RegistryExtensions{
-					Status:      model.Status(status),
-					PublishedAt: publishedAt,
-					UpdatedAt:   updatedAt,
-					IsLatest:    isLatest,
+					Status:          model.Status(status),
+					PublishedAt:     publishedAt,
+					UpdatedAt:       updatedAt,
+					IsLatest:        isLatest,
+					PackageDigests:  packageDigests,
+					Verified:        verified,
+					Health:          packageHealth(lastCheckedAt, lastCheckError, flagged),
+					RemoteStatus:    remoteStatus,
+					ValidationRules: validationRules,
 				},
 			},
 		}
@@ -230,7 +257,7 @@ func (db *PostgreSQL) GetServerByName(ctx context.Context, tx pgx.Tx, serverName
 	}
 
 	query := `
-		SELECT server_name, version, status, published_at, updated_at, is_latest, value
+		SELECT server_name, version, status, published_at, updated_at, is_latest, package_digests, verified, last_checked_at, last_check_error, flagged_for_review, remote_status, validation_rules, value
 		FROM servers
 		WHERE server_name = $1 AND is_latest = true
 		ORDER BY published_at DESC
@@ -240,9 +267,16 @@ func (db *PostgreSQL) GetServerByName(ctx context.Context, tx pgx.Tx, serverName
 	var name, version, status string
 	var publishedAt, updatedAt time.Time
 	var isLatest bool
+	var packageDigests map[string]string
+	var verified bool
+	var lastCheckedAt *time.Time
+	var lastCheckError string
+	var flagged bool
+	var remoteStatus []apiv0.RemoteStatus
+	var validationRules int
 	var valueJSON []byte
 
-	err := db.getExecutor(tx).QueryRow(ctx, query, serverName).Scan(&name, &version, &status, &publishedAt, &updatedAt, &isLatest, &valueJSON)
+	err := db.getExecutor(tx).QueryRow(ctx, query, serverName).Scan(&name, &version, &status, &publishedAt, &updatedAt, &isLatest, &packageDigests, &verified, &lastCheckedAt, &lastCheckError, &flagged, &remoteStatus, &validationRules, &valueJSON)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, ErrNotFound
@@ -261,10 +295,15 @@ func (db *PostgreSQL) GetServerByName(ctx context.Context, tx pgx.Tx, serverName
 		Server: serverJSON,
 		Meta: apiv0.ResponseMeta{
 			Official: &apiv0.RegistryExtensions{
-				Status:      model.Status(status),
-				PublishedAt: publishedAt,
-				UpdatedAt:   updatedAt,
-				IsLatest:    isLatest,
+				Status:          model.Status(status),
+				PublishedAt:     publishedAt,
+				UpdatedAt:       updatedAt,
+				IsLatest:        isLatest,
+				PackageDigests:  packageDigests,
+				Verified:        verified,
+				Health:          packageHealth(lastCheckedAt, lastCheckError, flagged),
+				RemoteStatus:    remoteStatus,
+				ValidationRules: validationRules,
 			},
 		},
 	}
@@ -279,7 +318,7 @@ func (db *PostgreSQL) GetServerByNameAndVersion(ctx context.Context, tx pgx.Tx,
 	}
 
 	query := `
-		SELECT server_name, version, status, published_at, updated_at, is_latest, value
+		SELECT server_name, version, status, published_at, updated_at, is_latest, package_digests, verified, last_checked_at, last_check_error, flagged_for_review, remote_status, validation_rules, value
 		FROM servers
 		WHERE server_name = $1 AND version = $2
 		LIMIT 1
@@ -288,9 +327,16 @@ func (db *PostgreSQL) GetServerByNameAndVersion(ctx context.Context, tx pgx.Tx,
 	var name, vers, status string
 	var publishedAt, updatedAt time.Time
 	var isLatest bool
+	var packageDigests map[string]string
+	var verified bool
+	var lastCheckedAt *time.Time
+	var lastCheckError string
+	var flagged bool
+	var remoteStatus []apiv0.RemoteStatus
+	var validationRules int
 	var valueJSON []byte
 
-	err := db.getExecutor(tx).QueryRow(ctx, query, serverName, version).Scan(&name, &vers, &status, &publishedAt, &updatedAt, &isLatest, &valueJSON)
+	err := db.getExecutor(tx).QueryRow(ctx, query, serverName, version).Scan(&name, &vers, &status, &publishedAt, &updatedAt, &isLatest, &packageDigests, &verified, &lastCheckedAt, &lastCheckError, &flagged, &remoteStatus, &validationRules, &valueJSON)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, ErrNotFound
@@ -309,10 +355,15 @@ func (db *PostgreSQL) GetServerByNameAndVersion(ctx context.Context, tx pgx.Tx,
 		Server: serverJSON,
 		Meta: apiv0.ResponseMeta{
 			Official: &apiv0.RegistryExtensions{
-				Status:      model.Status(status),
-				PublishedAt: publishedAt,
-				UpdatedAt:   updatedAt,
-				IsLatest:    isLatest,
+				Status:          model.Status(status),
+				PublishedAt:     publishedAt,
+				UpdatedAt:       updatedAt,
+				IsLatest:        isLatest,
+				PackageDigests:  packageDigests,
+				Verified:        verified,
+				Health:          packageHealth(lastCheckedAt, lastCheckError, flagged),
+				RemoteStatus:    remoteStatus,
+				ValidationRules: validationRules,
 			},
 		},
 	}
@@ -327,7 +378,7 @@ func (db *PostgreSQL) GetAllVersionsByServerName(ctx context.Context, tx pgx.Tx,
 	}
 
 	query := `
-		SELECT server_name, version, status, published_at, updated_at, is_latest, value
+		SELECT server_name, version, status, published_at, updated_at, is_latest, package_digests, verified, last_checked_at, last_check_error, flagged_for_review, remote_status, validation_rules, value
 		FROM servers
 		WHERE server_name = $1
 		ORDER BY published_at DESC
@@ -344,9 +395,16 @@ func (db *PostgreSQL) GetAllVersionsByServerName(ctx context.Context, tx pgx.Tx,
 		var name, version, status string
 		var publishedAt, updatedAt time.Time
 		var isLatest bool
+		var packageDigests map[string]string
+		var verified bool
+		var lastCheckedAt *time.Time
+		var lastCheckError string
+		var flagged bool
+		var remoteStatus []apiv0.RemoteStatus
+		var validationRules int
 		var valueJSON []byte
 
-		err := rows.Scan(&name, &version, &status, &publishedAt, &updatedAt, &isLatest, &valueJSON)
+		err := rows.Scan(&name, &version, &status, &publishedAt, &updatedAt, &isLatest, &packageDigests, &verified, &lastCheckedAt, &lastCheckError, &flagged, &remoteStatus, &validationRules, &valueJSON)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan server row: %w", err)
 		}
@@ -362,10 +420,15 @@ func (db *PostgreSQL) GetAllVersionsByServerName(ctx context.Context, tx pgx.Tx,
 			Server: serverJSON,
 			Meta: apiv0.ResponseMeta{
 				Official: &apiv0.RegistryExtensions{
-					Status:      model.Status(status),
-					PublishedAt: publishedAt,
-					UpdatedAt:   updatedAt,
-					IsLatest:    isLatest,
+					Status:          model.Status(status),
+					PublishedAt:     publishedAt,
+					UpdatedAt:       updatedAt,
+					IsLatest:        isLatest,
+					PackageDigests:  packageDigests,
+					Verified:        verified,
+					Health:          packageHealth(lastCheckedAt, lastCheckError, flagged),
+					RemoteStatus:    remoteStatus,
+					ValidationRules: validationRules,
 				},
 			},
 		}
@@ -407,10 +470,15 @@ func (db *PostgreSQL) CreateServer(ctx context.Context, tx pgx.Tx, serverJSON *a
 
 	// Insert the new server version using composite primary key
 	insertQuery := `
-		INSERT INTO servers (server_name, version, status, published_at, updated_at, is_latest, value)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		INSERT INTO servers (server_name, version, status, published_at, updated_at, is_latest, package_digests, verified, validation_rules, value)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 	`
 
+	packageDigests := officialMeta.PackageDigests
+	if packageDigests == nil {
+		packageDigests = map[string]string{}
+	}
+
 	_, err = db.getExecutor(tx).Exec(ctx, insertQuery,
 		serverJSON.Name,
 		serverJSON.Version,
@@ -418,6 +486,9 @@ func (db *PostgreSQL) CreateServer(ctx context.Context, tx pgx.Tx, serverJSON *a
 		officialMeta.PublishedAt,
 		officialMeta.UpdatedAt,
 		officialMeta.IsLatest,
+		packageDigests,
+		officialMeta.Verified,
+		officialMeta.ValidationRules,
 		valueJSON,
 	)
 
@@ -458,19 +529,39 @@ func (db *PostgreSQL) UpdateServer(ctx context.Context, tx pgx.Tx, serverName, v
 		return nil, fmt.Errorf("failed to marshal updated server: %w", err)
 	}
 
-	// Update only the JSON data (keep existing metadata columns)
+	// Update only the JSON data (keep existing metadata columns). The verified badge only
+	// holds while the repository and packages it was verified against are unchanged, and
+	// digests are only kept for OCI packages whose identifiers are unchanged.
 	query := `
 		UPDATE servers
-		SET value = $1, updated_at = NOW()
+		SET value = $1, updated_at = NOW(),
+			verified = verified
+				AND value->'repository' IS NOT DISTINCT FROM $1::jsonb->'repository'
+				AND value->'packages' IS NOT DISTINCT FROM $1::jsonb->'packages',
+			package_digests = COALESCE((
+				SELECT jsonb_object_agg(digest.key, digest.value)
+				FROM jsonb_each(package_digests) AS digest
+				WHERE EXISTS (
+					SELECT 1 FROM jsonb_array_elements($1::jsonb->'packages') AS pkg
+					WHERE pkg->>'registryType' = 'oci' AND pkg->>'identifier' = digest.key
+				)
+			), '{}')
 		WHERE server_name = $2 AND version = $3
-		RETURNING server_name, version, status, published_at, updated_at, is_latest
+		RETURNING server_name, version, status, published_at, updated_at, is_latest, package_digests, verified, last_checked_at, last_check_error, flagged_for_review, remote_status, validation_rules
 	`
 
 	var name, vers, status string
 	var publishedAt, updatedAt time.Time
 	var isLatest bool
-
-	err = db.getExecutor(tx).QueryRow(ctx, query, valueJSON, serverName, version).Scan(&name, &vers, &status, &publishedAt, &updatedAt, &isLatest)
+	var packageDigests map[string]string
+	var verified bool
+	var lastCheckedAt *time.Time
+	var lastCheckError string
+	var flagged bool
+	var remoteStatus []apiv0.RemoteStatus
+	var validationRules int
+
+	err = db.getExecutor(tx).QueryRow(ctx, query, valueJSON, serverName, version).Scan(&name, &vers, &status, &publishedAt, &updatedAt, &isLatest, &packageDigests, &verified, &lastCheckedAt, &lastCheckError, &flagged, &remoteStatus, &validationRules)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, ErrNotFound
@@ -483,10 +574,15 @@ func (db *PostgreSQL) UpdateServer(ctx context.Context, tx pgx.Tx, serverName, v
 		Server: *serverJSON,
 		Meta: apiv0.ResponseMeta{
 			Official: &apiv0.RegistryExtensions{
-				Status:      model.Status(status),
-				PublishedAt: publishedAt,
-				UpdatedAt:   updatedAt,
-				IsLatest:    isLatest,
+				Status:          model.Status(status),
+				PublishedAt:     publishedAt,
+				UpdatedAt:       updatedAt,
+				IsLatest:        isLatest,
+				PackageDigests:  packageDigests,
+				Verified:        verified,
+				Health:          packageHealth(lastCheckedAt, lastCheckError, flagged),
+				RemoteStatus:    remoteStatus,
+				ValidationRules: validationRules,
 			},
 		},
 	}
@@ -505,15 +601,22 @@ func (db *PostgreSQL) SetServerStatus(ctx context.Context, tx pgx.Tx, serverName
 		UPDATE servers
 		SET status = $1, updated_at = NOW()
 		WHERE server_name = $2 AND version = $3
-		RETURNING server_name, version, status, value, published_at, updated_at, is_latest
+		RETURNING server_name, version, status, value, published_at, updated_at, is_latest, package_digests, verified, last_checked_at, last_check_error, flagged_for_review, remote_status, validation_rules
 	`
 
 	var name, vers, currentStatus string
 	var publishedAt, updatedAt time.Time
 	var isLatest bool
+	var packageDigests map[string]string
+	var verified bool
+	var lastCheckedAt *time.Time
+	var lastCheckError string
+	var flagged bool
+	var remoteStatus []apiv0.RemoteStatus
+	var validationRules int
 	var valueJSON []byte
 
-	err := db.getExecutor(tx).QueryRow(ctx, query, status, serverName, version).Scan(&name, &vers, &currentStatus, &valueJSON, &publishedAt, &updatedAt, &isLatest)
+	err := db.getExecutor(tx).QueryRow(ctx, query, status, serverName, version).Scan(&name, &vers, &currentStatus, &valueJSON, &publishedAt, &updatedAt, &isLatest, &packageDigests, &verified, &lastCheckedAt, &lastCheckError, &flagged, &remoteStatus, &validationRules)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, ErrNotFound
@@ -532,10 +635,15 @@ func (db *PostgreSQL) SetServerStatus(ctx context.Context, tx pgx.Tx, serverName
 		Server: serverJSON,
 		Meta: apiv0.ResponseMeta{
 			Official: &apiv0.RegistryExtensions{
-				Status:      model.Status(currentStatus),
-				PublishedAt: publishedAt,
-				UpdatedAt:   updatedAt,
-				IsLatest:    isLatest,
+				Status:          model.Status(currentStatus),
+				PublishedAt:     publishedAt,
+				UpdatedAt:       updatedAt,
+				IsLatest:        isLatest,
+				PackageDigests:  packageDigests,
+				Verified:        verified,
+				Health:          packageHealth(lastCheckedAt, lastCheckError, flagged),
+				RemoteStatus:    remoteStatus,
+				ValidationRules: validationRules,
 			},
 		},
 	}
@@ -712,6 +820,581 @@ func (db *PostgreSQL) UnmarkAsLatest(ctx context.Context, tx pgx.Tx, serverName
 	return nil
 }
 
+// packageHealth builds the health of a server version from its columns, nil if it was never re-validated
+func packageHealth(lastCheckedAt *time.Time, lastCheckError string, flagged bool) *apiv0.PackageHealth {
+	if lastCheckedAt == nil {
+		return nil
+	}
+	return &apiv0.PackageHealth{
+		LastCheckedAt:    *lastCheckedAt,
+		LastError:        lastCheckError,
+		FlaggedForReview: flagged,
+	}
+}
+
+// RecordPackageHealth records the outcome of re-validating the packages of a server version.
+// Failures are counted until a re-validation passes, which also clears the review flag.
+// The update time is kept, since the server itself did not change.
+func (db *PostgreSQL) RecordPackageHealth(ctx context.Context, tx pgx.Tx, serverName, version string, checkErr error, flagAfter int) (*apiv0.PackageHealth, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	lastError := ""
+	if checkErr != nil {
+		lastError = checkErr.Error()
+	}
+
+	query := `
+		UPDATE servers
+		SET last_checked_at = NOW(),
+			last_check_error = $3,
+			check_failures = CASE WHEN $3 = '' THEN 0 ELSE check_failures + 1 END,
+			flagged_for_review = CASE
+				WHEN $3 = '' THEN false
+				ELSE flagged_for_review OR ($4 > 0 AND check_failures + 1 >= $4)
+			END
+		WHERE server_name = $1 AND version = $2
+		RETURNING last_checked_at, last_check_error, flagged_for_review
+	`
+
+	var health apiv0.PackageHealth
+	err := db.getExecutor(tx).QueryRow(ctx, query, serverName, version, lastError, flagAfter).Scan(&health.LastCheckedAt, &health.LastError, &health.FlaggedForReview)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to record package health: %w", err)
+	}
+
+	return &health, nil
+}
+
+// RecordRemoteProbes records probes of the remotes of a server version, drops its probes checked
+// before since, and stores the resulting status of the probed remotes with the server version.
+// Like health, remote status does not change the update time of the server.
+func (db *PostgreSQL) RecordRemoteProbes(ctx context.Context, tx pgx.Tx, serverName, version string, probes []RemoteProbe, since time.Time) ([]apiv0.RemoteStatus, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	executor := db.getExecutor(tx)
+	urls := make([]string, 0, len(probes))
+	for _, probe := range probes {
+		_, err := executor.Exec(ctx, `
+			INSERT INTO remote_probes (server_name, version, url, checked_at, live, auth_required, latency_ms, protocol_version, capabilities, error)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		`, serverName, version, probe.URL, probe.CheckedAt, probe.Live, probe.AuthRequired, probe.Latency.Milliseconds(), probe.ProtocolVersion, probe.Capabilities, probe.Error)
+		if err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+				return nil, ErrNotFound
+			}
+			return nil, fmt.Errorf("failed to record remote probe: %w", err)
+		}
+		urls = append(urls, probe.URL)
+	}
+
+	_, err := executor.Exec(ctx, `
+		DELETE FROM remote_probes
+		WHERE server_name = $1 AND version = $2 AND checked_at < $3
+	`, serverName, version, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete old remote probes: %w", err)
+	}
+
+	statuses, err := queryRemoteStatus(ctx, executor, serverName, version, urls, since)
+	if err != nil {
+		return nil, err
+	}
+	statusJSON, err := json.Marshal(statuses)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal remote status: %w", err)
+	}
+
+	result, err := executor.Exec(ctx, `
+		UPDATE servers
+		SET remote_status = $3
+		WHERE server_name = $1 AND version = $2
+	`, serverName, version, statusJSON)
+	if err != nil {
+		return nil, fmt.Errorf("failed to store remote status: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return nil, ErrNotFound
+	}
+
+	return statuses, nil
+}
+
+// ListRemoteStatus retrieve the status of the remotes of a server version from probes checked
+// since the given time, with up to historyLimit of the latest probes of each remote
+func (db *PostgreSQL) ListRemoteStatus(ctx context.Context, tx pgx.Tx, serverName, version string, since time.Time, historyLimit int) ([]apiv0.RemoteStatus, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	executor := db.getExecutor(tx)
+	statuses, err := queryRemoteStatus(ctx, executor, serverName, version, nil, since)
+	if err != nil || historyLimit <= 0 {
+		return statuses, err
+	}
+
+	query := `
+		SELECT url, checked_at, live, latency_ms, error
+		FROM (
+			SELECT url, checked_at, live, latency_ms, error,
+				ROW_NUMBER() OVER (PARTITION BY url ORDER BY checked_at DESC, id DESC) AS n
+			FROM remote_probes
+			WHERE server_name = $1 AND version = $2 AND checked_at >= $3
+		) probes
+		WHERE n <= $4
+		ORDER BY url, checked_at DESC
+	`
+
+	rows, err := executor.Query(ctx, query, serverName, version, since, historyLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query remote probes: %w", err)
+	}
+	defer rows.Close()
+
+	history := make(map[string][]apiv0.RemoteProbe)
+	for rows.Next() {
+		var url string
+		var probe apiv0.RemoteProbe
+		if err := rows.Scan(&url, &probe.CheckedAt, &probe.Live, &probe.LatencyMs, &probe.Error); err != nil {
+			return nil, fmt.Errorf("failed to scan remote probe row: %w", err)
+		}
+		history[url] = append(history[url], probe)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating remote probe rows: %w", err)
+	}
+
+	for i := range statuses {
+		statuses[i].History = history[statuses[i].URL]
+	}
+	return statuses, nil
+}
+
+// queryRemoteStatus builds the status of the remotes of a server version from their latest probe
+// checked since the given time, and their uptime over all such probes. If urls is nil, all remotes
+// with such probes are included.
+func queryRemoteStatus(ctx context.Context, executor Executor, serverName, version string, urls []string, since time.Time) ([]apiv0.RemoteStatus, error) {
+	args := []any{serverName, version, since}
+	urlCondition := ""
+	if urls != nil {
+		args = append(args, urls)
+		urlCondition = "AND url = ANY($4)"
+	}
+
+	query := fmt.Sprintf(`
+		SELECT url, live, auth_required, checked_at, error, protocol_version, capabilities, uptime
+		FROM (
+			SELECT url, live, auth_required, checked_at, error, protocol_version, capabilities,
+				AVG(CASE WHEN live THEN 1 ELSE 0 END) OVER (PARTITION BY url)::float8 AS uptime,
+				ROW_NUMBER() OVER (PARTITION BY url ORDER BY checked_at DESC, id DESC) AS n
+			FROM remote_probes
+			WHERE server_name = $1 AND version = $2 AND checked_at >= $3 %s
+		) probes
+		WHERE n = 1
+		ORDER BY url
+	`, urlCondition)
+
+	rows, err := executor.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query remote status: %w", err)
+	}
+	defer rows.Close()
+
+	statuses := []apiv0.RemoteStatus{}
+	for rows.Next() {
+		var status apiv0.RemoteStatus
+		if err := rows.Scan(&status.URL, &status.Live, &status.AuthRequired, &status.LastCheckedAt, &status.LastError, &status.ProtocolVersion, &status.Capabilities, &status.Uptime); err != nil {
+			return nil, fmt.Errorf("failed to scan remote status row: %w", err)
+		}
+		statuses = append(statuses, status)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating remote status rows: %w", err)
+	}
+
+	return statuses, nil
+}
+
+// likeEscaper escapes the LIKE wildcards and the default escape character
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// containsPattern returns an ILIKE pattern matching values that contain s literally
+func containsPattern(s string) string {
+	return "%" + likeEscaper.Replace(s) + "%"
+}
+
+// capabilityCondition builds a condition matching servers with a capability of the given kind
+// that satisfies match, a condition on the capability c formatted with the argument index
+func capabilityCondition(kind, match string, argIndex int) string {
+	return fmt.Sprintf(`EXISTS (
+		SELECT 1 FROM server_capabilities c
+		WHERE c.server_name = servers.server_name AND c.version = servers.version AND c.kind = '%s' AND %s
+	)`, kind, fmt.Sprintf(match, argIndex))
+}
+
+// SetServerCapabilities replaces the capabilities of a server version with a manifest from the
+// given source. Capabilities supplied by the publisher take precedence over harvested ones, which
+// are not stored for versions with a publisher manifest. A nil manifest removes those of the source.
+func (db *PostgreSQL) SetServerCapabilities(ctx context.Context, tx pgx.Tx, serverName, version string, manifest *apiv0.CapabilityManifest, source string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	executor := db.getExecutor(tx)
+	if source == apiv0.CapabilitySourceProbe {
+		var hasPublisherManifest bool
+		err := executor.QueryRow(ctx, `
+			SELECT EXISTS (SELECT 1 FROM server_capabilities WHERE server_name = $1 AND version = $2 AND source = $3)
+		`, serverName, version, apiv0.CapabilitySourcePublisher).Scan(&hasPublisherManifest)
+		if err != nil {
+			return fmt.Errorf("failed to check server capabilities: %w", err)
+		}
+		if hasPublisherManifest {
+			return nil
+		}
+	}
+
+	// A manifest replaces all capabilities, since it takes precedence or no publisher manifest exists
+	deleteQuery := `DELETE FROM server_capabilities WHERE server_name = $1 AND version = $2`
+	deleteArgs := []any{serverName, version}
+	if manifest == nil {
+		deleteQuery += ` AND source = $3`
+		deleteArgs = append(deleteArgs, source)
+	}
+	if _, err := executor.Exec(ctx, deleteQuery, deleteArgs...); err != nil {
+		return fmt.Errorf("failed to delete server capabilities: %w", err)
+	}
+	if manifest == nil {
+		return nil
+	}
+
+	var kinds, names, uris, descriptions []string
+	add := func(kind, name, uri, description string) {
+		kinds = append(kinds, kind)
+		names = append(names, name)
+		uris = append(uris, uri)
+		descriptions = append(descriptions, description)
+	}
+	for _, tool := range manifest.Tools {
+		add("tool", tool.Name, "", tool.Description)
+	}
+	for _, prompt := range manifest.Prompts {
+		add("prompt", prompt.Name, "", prompt.Description)
+	}
+	for _, resource := range manifest.Resources {
+		add("resource", resource.Name, resource.URI, resource.Description)
+	}
+	if len(kinds) == 0 {
+		return nil
+	}
+
+	_, err := executor.Exec(ctx, `
+		INSERT INTO server_capabilities (server_name, version, kind, name, uri, description, source)
+		SELECT $1::text, $2::text, kind, name, uri, description, $7::text
+		FROM unnest($3::text[], $4::text[], $5::text[], $6::text[]) WITH ORDINALITY AS c(kind, name, uri, description, position)
+		ORDER BY position
+	`, serverName, version, kinds, names, uris, descriptions, source)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return ErrNotFound
+		}
+		return fmt.Errorf("failed to store server capabilities: %w", err)
+	}
+
+	return nil
+}
+
+// GetServerCapabilities retrieves the capabilities of a server version and their source, which
+// is empty if none are stored
+func (db *PostgreSQL) GetServerCapabilities(ctx context.Context, tx pgx.Tx, serverName, version string) (*apiv0.CapabilityManifest, string, error) {
+	if ctx.Err() != nil {
+		return nil, "", ctx.Err()
+	}
+
+	query := `
+		SELECT kind, name, uri, description, source
+		FROM server_capabilities
+		WHERE server_name = $1 AND version = $2
+		ORDER BY id
+	`
+
+	rows, err := db.getExecutor(tx).Query(ctx, query, serverName, version)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to query server capabilities: %w", err)
+	}
+	defer rows.Close()
+
+	manifest := &apiv0.CapabilityManifest{}
+	source := ""
+	for rows.Next() {
+		var kind, name, uri, description string
+		if err := rows.Scan(&kind, &name, &uri, &description, &source); err != nil {
+			return nil, "", fmt.Errorf("failed to scan server capability row: %w", err)
+		}
+		switch kind {
+		case "tool":
+			manifest.Tools = append(manifest.Tools, apiv0.ToolCapability{Name: name, Description: description})
+		case "prompt":
+			manifest.Prompts = append(manifest.Prompts, apiv0.PromptCapability{Name: name, Description: description})
+		case "resource":
+			manifest.Resources = append(manifest.Resources, apiv0.ResourceCapability{URI: uri, Name: name, Description: description})
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", fmt.Errorf("error iterating server capability rows: %w", err)
+	}
+
+	return manifest, source, nil
+}
+
+// publishRequestColumns are the columns scanned by scanPublishRequest
+const publishRequestColumns = `id::text, value, status, reason, attempts, created_at, updated_at`
+
+// scanPublishRequest scans a row of publishRequestColumns
+func scanPublishRequest(row pgx.Row) (*PublishRequest, error) {
+	var request PublishRequest
+	var status string
+	var valueJSON []byte
+
+	if err := row.Scan(&request.ID, &valueJSON, &status, &request.Reason, &request.Attempts, &request.CreatedAt, &request.UpdatedAt); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(valueJSON, &request.Server); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal server JSON: %w", err)
+	}
+	request.Status = model.Status(status)
+
+	return &request, nil
+}
+
+// isInvalidUUID reports whether err is PostgreSQL rejecting a malformed UUID
+func isInvalidUUID(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "22P02"
+}
+
+// CreatePublishRequest stores a pending publish request for asynchronous validation.
+// It returns ErrAlreadyExists if the server version already has a pending request.
+func (db *PostgreSQL) CreatePublishRequest(ctx context.Context, tx pgx.Tx, serverJSON *apiv0.ServerJSON) (*PublishRequest, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	if serverJSON == nil || serverJSON.Name == "" || serverJSON.Version == "" {
+		return nil, fmt.Errorf("server name and version are required")
+	}
+
+	valueJSON, err := json.Marshal(serverJSON)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal server JSON: %w", err)
+	}
+
+	query := `
+		INSERT INTO publish_requests (server_name, version, value)
+		VALUES ($1, $2, $3)
+		RETURNING ` + publishRequestColumns
+
+	request, err := scanPublishRequest(db.getExecutor(tx).QueryRow(ctx, query, serverJSON.Name, serverJSON.Version, valueJSON))
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return nil, ErrAlreadyExists
+		}
+		return nil, fmt.Errorf("failed to insert publish request: %w", err)
+	}
+
+	return request, nil
+}
+
+// GetPublishRequest retrieves a publish request by its ID
+func (db *PostgreSQL) GetPublishRequest(ctx context.Context, tx pgx.Tx, id string) (*PublishRequest, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `SELECT ` + publishRequestColumns + ` FROM publish_requests WHERE id = $1::uuid`
+
+	request, err := scanPublishRequest(db.getExecutor(tx).QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) || isInvalidUUID(err) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get publish request: %w", err)
+	}
+
+	return request, nil
+}
+
+// ClaimPublishRequest leases the pending publish request that has been due for an attempt the
+// longest, counting the attempt. It returns ErrNotFound if no request is due.
+func (db *PostgreSQL) ClaimPublishRequest(ctx context.Context, tx pgx.Tx, lease time.Duration) (*PublishRequest, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	// SKIP LOCKED lets concurrent workers claim different requests
+	query := `
+		UPDATE publish_requests
+		SET attempts = attempts + 1, next_attempt_at = NOW() + make_interval(secs => $1), updated_at = NOW()
+		WHERE id = (
+			SELECT id FROM publish_requests
+			WHERE status = 'pending' AND next_attempt_at <= NOW()
+			ORDER BY next_attempt_at
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + publishRequestColumns
+
+	request, err := scanPublishRequest(db.getExecutor(tx).QueryRow(ctx, query, lease.Seconds()))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to claim publish request: %w", err)
+	}
+
+	return request, nil
+}
+
+// RetryPublishRequest schedules another attempt of a pending publish request, recording why the last one failed
+func (db *PostgreSQL) RetryPublishRequest(ctx context.Context, tx pgx.Tx, id string, retryAt time.Time, reason string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	query := `
+		UPDATE publish_requests
+		SET next_attempt_at = $2, reason = $3, updated_at = NOW()
+		WHERE id = $1::uuid AND status = 'pending'
+	`
+
+	tag, err := db.getExecutor(tx).Exec(ctx, query, id, retryAt, reason)
+	if err != nil {
+		return fmt.Errorf("failed to reschedule publish request: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// CompletePublishRequest marks a pending publish request as active or rejected
+func (db *PostgreSQL) CompletePublishRequest(ctx context.Context, tx pgx.Tx, id string, status model.Status, reason string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	if status != model.StatusActive && status != model.StatusRejected {
+		return fmt.Errorf("%w: publish requests complete as active or rejected, not %s", ErrInvalidInput, status)
+	}
+
+	query := `
+		UPDATE publish_requests
+		SET status = $2, reason = $3, updated_at = NOW()
+		WHERE id = $1::uuid AND status = 'pending'
+	`
+
+	tag, err := db.getExecutor(tx).Exec(ctx, query, id, string(status), reason)
+	if err != nil {
+		return fmt.Errorf("failed to complete publish request: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// DeletePublishRequestsBefore deletes active and rejected publish requests completed before the
+// given time. Pending requests are kept however old they are.
+func (db *PostgreSQL) DeletePublishRequestsBefore(ctx context.Context, tx pgx.Tx, before time.Time) (int64, error) {
+	if ctx.Err() != nil {
+		return 0, ctx.Err()
+	}
+
+	query := `
+		DELETE FROM publish_requests
+		WHERE status IN ('active', 'rejected') AND updated_at < $1
+	`
+
+	tag, err := db.getExecutor(tx).Exec(ctx, query, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete completed publish requests: %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+// IncrementRateLimit increments the rate limit counter for key in the window starting at windowStart
+// and returns the new count. Counters from earlier windows are reset.
+func (db *PostgreSQL) IncrementRateLimit(ctx context.Context, key string, windowStart time.Time) (int, error) {
+	if ctx.Err() != nil {
+		return 0, ctx.Err()
+	}
+
+	query := `
+		INSERT INTO rate_limits (key, window_start, count)
+		VALUES ($1, $2, 1)
+		ON CONFLICT (key) DO UPDATE SET
+			count = CASE WHEN rate_limits.window_start = EXCLUDED.window_start THEN rate_limits.count + 1 ELSE 1 END,
+			window_start = EXCLUDED.window_start
+		RETURNING count
+	`
+
+	var count int
+	if err := db.pool.QueryRow(ctx, query, key, windowStart).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to increment rate limit: %w", err)
+	}
+
+	return count, nil
+}
+
+// DeleteRateLimitsBefore deletes the rate limit counters of windows starting before the given time
+func (db *PostgreSQL) DeleteRateLimitsBefore(ctx context.Context, before time.Time) (int64, error) {
+	if ctx.Err() != nil {
+		return 0, ctx.Err()
+	}
+
+	result, err := db.pool.Exec(ctx, `DELETE FROM rate_limits WHERE window_start < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete stale rate limits: %w", err)
+	}
+
+	return result.RowsAffected(), nil
+}
+
+// MarkNonceUsed records an authentication nonce as used until expiresAt.
+// It returns false if the nonce had already been used. Expired nonces are cleaned up as a side effect.
+func (db *PostgreSQL) MarkNonceUsed(ctx context.Context, nonce string, expiresAt time.Time) (bool, error) {
+	if ctx.Err() != nil {
+		return false, ctx.Err()
+	}
+
+	if _, err := db.pool.Exec(ctx, `DELETE FROM used_auth_nonces WHERE expires_at < NOW()`); err != nil {
+		return false, fmt.Errorf("failed to clean up expired nonces: %w", err)
+	}
+
+	tag, err := db.pool.Exec(ctx, `
+		INSERT INTO used_auth_nonces (nonce, expires_at)
+		VALUES ($1, $2)
+		ON CONFLICT (nonce) DO NOTHING
+	`, nonce, expiresAt)
+	if err != nil {
+		return false, fmt.Errorf("failed to record nonce: %w", err)
+	}
+
+	return tag.RowsAffected() == 1, nil
+}
+
 // Close closes the database connection
 func (db *PostgreSQL) Close() error {
 	db.pool.Close()
@@ -471,6 +471,31 @@ func TestPostgreSQL_UpdateServer(t *testing.T) {
 			}
 		})
 	}
+
+	t.Run("digests of changed OCI packages are dropped", func(t *testing.T) {
+		ociServer := func(identifiers ...string) *apiv0.ServerJSON {
+			server := &apiv0.ServerJSON{Name: "com.example/oci-server", Description: "An OCI server", Version: "1.0.0"}
+			for _, identifier := range identifiers {
+				server.Packages = append(server.Packages, model.Package{RegistryType: model.RegistryTypeOCI, Identifier: identifier})
+			}
+			return server
+		}
+		_, err := db.CreateServer(ctx, nil, ociServer("ghcr.io/acme/weather:1.0.0", "ghcr.io/acme/files:1.0.0"), &apiv0.RegistryExtensions{
+			Status:      model.StatusActive,
+			PublishedAt: time.Now(),
+			UpdatedAt:   time.Now(),
+			IsLatest:    true,
+			PackageDigests: map[string]string{
+				"ghcr.io/acme/weather:1.0.0": "sha256:weather",
+				"ghcr.io/acme/files:1.0.0":   "sha256:files",
+			},
+		})
+		require.NoError(t, err)
+
+		result, err := db.UpdateServer(ctx, nil, "com.example/oci-server", "1.0.0", ociServer("ghcr.io/acme/weather:1.0.0", "ghcr.io/attacker/files:1.0.0"))
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"ghcr.io/acme/weather:1.0.0": "sha256:weather"}, result.Meta.Official.PackageDigests)
+	})
 }
 
 func TestPostgreSQL_SetServerStatus(t *testing.T) {
@@ -967,3 +992,290 @@ func boolPtr(b bool) *bool {
 func timePtr(t time.Time) *time.Time {
 	return &t
 }
+
+func TestPostgreSQL_PublishRequests(t *testing.T) {
+	db := database.NewTestDB(t)
+	ctx := context.Background()
+
+	serverJSON := &apiv0.ServerJSON{
+		Name:        "com.example/pending-server",
+		Description: "A server awaiting validation",
+		Version:     "1.0.0",
+	}
+
+	request, err := db.CreatePublishRequest(ctx, nil, serverJSON)
+	require.NoError(t, err)
+	assert.NotEmpty(t, request.ID)
+	assert.Equal(t, model.StatusPending, request.Status)
+	assert.Equal(t, *serverJSON, request.Server)
+
+	t.Run("one pending request per version", func(t *testing.T) {
+		_, err := db.CreatePublishRequest(ctx, nil, serverJSON)
+		assert.ErrorIs(t, err, database.ErrAlreadyExists)
+	})
+
+	t.Run("unknown and malformed IDs are not found", func(t *testing.T) {
+		_, err := db.GetPublishRequest(ctx, nil, "00000000-0000-0000-0000-000000000000")
+		assert.ErrorIs(t, err, database.ErrNotFound)
+		_, err = db.GetPublishRequest(ctx, nil, "not-a-uuid")
+		assert.ErrorIs(t, err, database.ErrNotFound)
+	})
+
+	t.Run("claimed requests are leased", func(t *testing.T) {
+		claimed, err := db.ClaimPublishRequest(ctx, nil, time.Hour)
+		require.NoError(t, err)
+		assert.Equal(t, request.ID, claimed.ID)
+		assert.Equal(t, 1, claimed.Attempts)
+
+		_, err = db.ClaimPublishRequest(ctx, nil, time.Hour)
+		assert.ErrorIs(t, err, database.ErrNotFound)
+	})
+
+	t.Run("retried requests are claimed when due", func(t *testing.T) {
+		require.NoError(t, db.RetryPublishRequest(ctx, nil, request.ID, time.Now().Add(-time.Second), "rate limited by registry"))
+
+		claimed, err := db.ClaimPublishRequest(ctx, nil, time.Hour)
+		require.NoError(t, err)
+		assert.Equal(t, 2, claimed.Attempts)
+		assert.Equal(t, "rate limited by registry", claimed.Reason)
+	})
+
+	t.Run("completed requests are final", func(t *testing.T) {
+		require.NoError(t, db.CompletePublishRequest(ctx, nil, request.ID, model.StatusRejected, "package not found"))
+
+		rejected, err := db.GetPublishRequest(ctx, nil, request.ID)
+		require.NoError(t, err)
+		assert.Equal(t, model.StatusRejected, rejected.Status)
+		assert.Equal(t, "package not found", rejected.Reason)
+
+		assert.ErrorIs(t, db.CompletePublishRequest(ctx, nil, request.ID, model.StatusActive, ""), database.ErrNotFound)
+		assert.ErrorIs(t, db.RetryPublishRequest(ctx, nil, request.ID, time.Now(), ""), database.ErrNotFound)
+
+		// The version can be published again once no request is pending
+		_, err = db.CreatePublishRequest(ctx, nil, serverJSON)
+		assert.NoError(t, err)
+	})
+
+	t.Run("completed requests are deleted after retention", func(t *testing.T) {
+		deleted, err := db.DeletePublishRequestsBefore(ctx, nil, time.Now().Add(-time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), deleted)
+
+		deleted, err = db.DeletePublishRequestsBefore(ctx, nil, time.Now().Add(time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), deleted)
+
+		_, err = db.GetPublishRequest(ctx, nil, request.ID)
+		assert.ErrorIs(t, err, database.ErrNotFound)
+
+		// The pending request for the version is kept
+		claimed, err := db.ClaimPublishRequest(ctx, nil, time.Hour)
+		require.NoError(t, err)
+		assert.Equal(t, serverJSON.Name, claimed.Server.Name)
+	})
+}
+
+func TestPostgreSQL_RecordPackageHealth(t *testing.T) {
+	db := database.NewTestDB(t)
+	ctx := context.Background()
+
+	_, err := db.CreateServer(ctx, nil, &apiv0.ServerJSON{
+		Name:        "com.example/health-server",
+		Description: "A server whose packages are re-validated",
+		Version:     "1.0.0",
+	}, &apiv0.RegistryExtensions{
+		Status:      model.StatusActive,
+		PublishedAt: time.Now(),
+		UpdatedAt:   time.Now(),
+		IsLatest:    true,
+	})
+	require.NoError(t, err)
+
+	server, err := db.GetServerByNameAndVersion(ctx, nil, "com.example/health-server", "1.0.0")
+	require.NoError(t, err)
+	assert.Nil(t, server.Meta.Official.Health, "servers that were never re-validated have no health")
+
+	checkErr := fmt.Errorf("NPM package 'health-server' not found")
+	for _, expectFlagged := range []bool{false, true, true} {
+		health, err := db.RecordPackageHealth(ctx, nil, "com.example/health-server", "1.0.0", checkErr, 2)
+		require.NoError(t, err)
+		assert.Equal(t, checkErr.Error(), health.LastError)
+		assert.Equal(t, expectFlagged, health.FlaggedForReview)
+	}
+
+	health, err := db.RecordPackageHealth(ctx, nil, "com.example/health-server", "1.0.0", nil, 2)
+	require.NoError(t, err)
+	assert.Empty(t, health.LastError)
+	assert.False(t, health.FlaggedForReview, "passing re-validation clears the flag")
+
+	server, err = db.GetServerByNameAndVersion(ctx, nil, "com.example/health-server", "1.0.0")
+	require.NoError(t, err)
+	require.NotNil(t, server.Meta.Official.Health)
+	assert.WithinDuration(t, time.Now(), server.Meta.Official.Health.LastCheckedAt, time.Minute)
+
+	_, err = db.RecordPackageHealth(ctx, nil, "com.example/missing-server", "1.0.0", nil, 2)
+	assert.ErrorIs(t, err, database.ErrNotFound)
+}
+
+func TestPostgreSQL_RemoteProbes(t *testing.T) {
+	db := database.NewTestDB(t)
+	ctx := context.Background()
+
+	_, err := db.CreateServer(ctx, nil, &apiv0.ServerJSON{
+		Name:        "com.example/probed-server",
+		Description: "A server whose remotes are probed",
+		Version:     "1.0.0",
+	}, &apiv0.RegistryExtensions{
+		Status:      model.StatusActive,
+		PublishedAt: time.Now(),
+		UpdatedAt:   time.Now(),
+		IsLatest:    true,
+	})
+	require.NoError(t, err)
+
+	now := time.Now()
+	since := now.Add(-time.Hour)
+	record := func(checkedAt time.Time, live bool) []apiv0.RemoteStatus {
+		probe := database.RemoteProbe{URL: "https://mcp.example.com/mcp", CheckedAt: checkedAt, Live: live, Latency: 42 * time.Millisecond}
+		if live {
+			probe.ProtocolVersion = "2025-06-18"
+			probe.Capabilities = map[string]any{"tools": map[string]any{}}
+		} else {
+			probe.Error = "unexpected status: 502"
+		}
+		statuses, err := db.RecordRemoteProbes(ctx, nil, "com.example/probed-server", "1.0.0", []database.RemoteProbe{probe}, since)
+		require.NoError(t, err)
+		return statuses
+	}
+
+	// Probes before the retention period are dropped and do not count towards uptime
+	record(now.Add(-2*time.Hour), false)
+	record(now.Add(-3*time.Minute), true)
+	record(now.Add(-2*time.Minute), true)
+	record(now.Add(-time.Minute), false)
+	statuses := record(now, true)
+
+	require.Len(t, statuses, 1)
+	assert.True(t, statuses[0].Live)
+	assert.Equal(t, "2025-06-18", statuses[0].ProtocolVersion)
+	assert.Contains(t, statuses[0].Capabilities, "tools")
+	assert.InDelta(t, 0.75, statuses[0].Uptime, 0.001)
+
+	server, err := db.GetServerByNameAndVersion(ctx, nil, "com.example/probed-server", "1.0.0")
+	require.NoError(t, err)
+	require.Len(t, server.Meta.Official.RemoteStatus, 1)
+	assert.Equal(t, "https://mcp.example.com/mcp", server.Meta.Official.RemoteStatus[0].URL)
+	assert.InDelta(t, 0.75, server.Meta.Official.RemoteStatus[0].Uptime, 0.001)
+	assert.Empty(t, server.Meta.Official.RemoteStatus[0].History, "history is not part of the official metadata")
+
+	statuses, err = db.ListRemoteStatus(ctx, nil, "com.example/probed-server", "1.0.0", since, 2)
+	require.NoError(t, err)
+	require.Len(t, statuses, 1)
+	require.Len(t, statuses[0].History, 2)
+	assert.True(t, statuses[0].History[0].Live, "history lists the latest probes first")
+	assert.False(t, statuses[0].History[1].Live)
+	assert.Equal(t, "unexpected status: 502", statuses[0].History[1].Error)
+	assert.Equal(t, int64(42), statuses[0].History[1].LatencyMs)
+
+	_, err = db.RecordRemoteProbes(ctx, nil, "com.example/missing-server", "1.0.0", []database.RemoteProbe{{URL: "https://mcp.example.com/mcp", CheckedAt: now}}, since)
+	assert.ErrorIs(t, err, database.ErrNotFound)
+}
+
+func TestPostgreSQL_ServerCapabilities(t *testing.T) {
+	db := database.NewTestDB(t)
+	ctx := context.Background()
+
+	for _, name := range []string{"com.example/weather-server", "com.example/files-server"} {
+		_, err := db.CreateServer(ctx, nil, &apiv0.ServerJSON{
+			Name:        name,
+			Description: "A server offering capabilities",
+			Version:     "1.0.0",
+		}, &apiv0.RegistryExtensions{
+			Status:      model.StatusActive,
+			PublishedAt: time.Now(),
+			UpdatedAt:   time.Now(),
+			IsLatest:    true,
+		})
+		require.NoError(t, err)
+	}
+
+	weather := &apiv0.CapabilityManifest{
+		Tools:   []apiv0.ToolCapability{{Name: "get_weather", Description: "Get the current weather"}, {Name: "get_forecast"}},
+		Prompts: []apiv0.PromptCapability{{Name: "summarize_forecast"}},
+	}
+	files := &apiv0.CapabilityManifest{
+		Tools:     []apiv0.ToolCapability{{Name: "read_file"}},
+		Resources: []apiv0.ResourceCapability{{URI: "file:///home", Name: "Home directory"}},
+	}
+
+	t.Run("manifests are stored per server version", func(t *testing.T) {
+		require.NoError(t, db.SetServerCapabilities(ctx, nil, "com.example/weather-server", "1.0.0", weather, apiv0.CapabilitySourcePublisher))
+		require.NoError(t, db.SetServerCapabilities(ctx, nil, "com.example/files-server", "1.0.0", files, apiv0.CapabilitySourceProbe))
+
+		manifest, source, err := db.GetServerCapabilities(ctx, nil, "com.example/weather-server", "1.0.0")
+		require.NoError(t, err)
+		assert.Equal(t, weather, manifest)
+		assert.Equal(t, apiv0.CapabilitySourcePublisher, source)
+	})
+
+	t.Run("servers are filtered by their capabilities", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			filter   *database.ServerFilter
+			expected []string
+		}{
+			{name: "tool name substring", filter: &database.ServerFilter{ToolName: stringPtr("WEATHER")}, expected: []string{"com.example/weather-server"}},
+			{name: "tool shared by both servers", filter: &database.ServerFilter{ToolName: stringPtr("e")}, expected: []string{"com.example/files-server", "com.example/weather-server"}},
+			{name: "prompt name", filter: &database.ServerFilter{PromptName: stringPtr("summarize")}, expected: []string{"com.example/weather-server"}},
+			{name: "resource URI", filter: &database.ServerFilter{ResourceName: stringPtr("file://")}, expected: []string{"com.example/files-server"}},
+			{name: "resource name", filter: &database.ServerFilter{ResourceName: stringPtr("home")}, expected: []string{"com.example/files-server"}},
+			{name: "no match", filter: &database.ServerFilter{ToolName: stringPtr("delete_everything")}, expected: nil},
+			{name: "underscore matches literally", filter: &database.ServerFilter{ToolName: stringPtr("d_f")}, expected: []string{"com.example/files-server"}},
+			{name: "percent sign matches literally", filter: &database.ServerFilter{ToolName: stringPtr("get%weather")}, expected: nil},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				servers, _, err := db.ListServers(ctx, nil, tt.filter, "", 10)
+				require.NoError(t, err)
+				var names []string
+				for _, server := range servers {
+					names = append(names, server.Server.Name)
+				}
+				assert.Equal(t, tt.expected, names)
+			})
+		}
+	})
+
+	t.Run("publisher manifests take precedence over harvested ones", func(t *testing.T) {
+		require.NoError(t, db.SetServerCapabilities(ctx, nil, "com.example/weather-server", "1.0.0", files, apiv0.CapabilitySourceProbe))
+		manifest, source, err := db.GetServerCapabilities(ctx, nil, "com.example/weather-server", "1.0.0")
+		require.NoError(t, err)
+		assert.Equal(t, weather, manifest)
+		assert.Equal(t, apiv0.CapabilitySourcePublisher, source)
+
+		require.NoError(t, db.SetServerCapabilities(ctx, nil, "com.example/files-server", "1.0.0", weather, apiv0.CapabilitySourcePublisher))
+		manifest, source, err = db.GetServerCapabilities(ctx, nil, "com.example/files-server", "1.0.0")
+		require.NoError(t, err)
+		assert.Equal(t, weather, manifest)
+		assert.Equal(t, apiv0.CapabilitySourcePublisher, source)
+	})
+
+	t.Run("dropping a manifest removes only that of its source", func(t *testing.T) {
+		require.NoError(t, db.SetServerCapabilities(ctx, nil, "com.example/weather-server", "1.0.0", nil, apiv0.CapabilitySourcePublisher))
+		manifest, source, err := db.GetServerCapabilities(ctx, nil, "com.example/weather-server", "1.0.0")
+		require.NoError(t, err)
+		assert.Equal(t, &apiv0.CapabilityManifest{}, manifest)
+		assert.Empty(t, source)
+
+		require.NoError(t, db.SetServerCapabilities(ctx, nil, "com.example/weather-server", "1.0.0", files, apiv0.CapabilitySourceProbe))
+		require.NoError(t, db.SetServerCapabilities(ctx, nil, "com.example/weather-server", "1.0.0", nil, apiv0.CapabilitySourcePublisher))
+		_, source, err = db.GetServerCapabilities(ctx, nil, "com.example/weather-server", "1.0.0")
+		require.NoError(t, err)
+		assert.Equal(t, apiv0.CapabilitySourceProbe, source)
+	})
+
+	t.Run("manifests of missing servers are not stored", func(t *testing.T) {
+		err := db.SetServerCapabilities(ctx, nil, "com.example/missing-server", "1.0.0", weather, apiv0.CapabilitySourcePublisher)
+		assert.ErrorIs(t, err, database.ErrNotFound)
+	})
+}
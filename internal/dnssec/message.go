@@ -0,0 +1,123 @@
+package dnssec
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+const (
+	// ednsUDPSize is the advertised EDNS payload size
+	ednsUDPSize = 4096
+	// maxCNAMEHops bounds the CNAME chain followed within an answer
+	maxCNAMEHops = 32
+)
+
+var errMalformed = errors.New("malformed DNS message")
+
+// buildQuery encodes a recursive query for the name and type, with the AD bit and
+// EDNS DO bit set so the upstream reports whether the answer was DNSSEC-validated
+func buildQuery(id uint16, name string, qtype dnsmessage.Type) ([]byte, error) {
+	qname, err := dnsmessage.NewName(fqdn(name))
+	if err != nil {
+		return nil, fmt.Errorf("invalid name %s: %w", name, err)
+	}
+
+	var opt dnsmessage.ResourceHeader
+	if err := opt.SetEDNS0(ednsUDPSize, dnsmessage.RCodeSuccess, true); err != nil {
+		return nil, err
+	}
+
+	msg := dnsmessage.Message{
+		Header:      dnsmessage.Header{ID: id, RecursionDesired: true, AuthenticData: true},
+		Questions:   []dnsmessage.Question{{Name: qname, Type: qtype, Class: dnsmessage.ClassINET}},
+		Additionals: []dnsmessage.Resource{{Header: opt, Body: &dnsmessage.OPTResource{}}},
+	}
+	query, err := msg.Pack()
+	if err != nil {
+		return nil, fmt.Errorf("invalid name %s: %w", name, err)
+	}
+	return query, nil
+}
+
+// response holds the parts of a DNS response the resolver needs
+type response struct {
+	header   dnsmessage.Header
+	question dnsmessage.Question
+	answers  []dnsmessage.Resource
+}
+
+// parseResponse decodes the header, question and answer sections of a DNS response
+func parseResponse(msg []byte) (*response, error) {
+	var p dnsmessage.Parser
+	header, err := p.Start(msg)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errMalformed, err)
+	}
+	if !header.Response {
+		return nil, fmt.Errorf("%w: not a response", errMalformed)
+	}
+	if header.Truncated {
+		return nil, fmt.Errorf("%w: truncated response", errMalformed)
+	}
+
+	questions, err := p.AllQuestions()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errMalformed, err)
+	}
+	if len(questions) != 1 {
+		return nil, fmt.Errorf("%w: expected 1 question, got %d", errMalformed, len(questions))
+	}
+
+	answers, err := p.AllAnswers()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errMalformed, err)
+	}
+
+	return &response{header: header, question: questions[0], answers: answers}, nil
+}
+
+// matches reports whether the response is for the query with the given ID, name and type
+func (r *response) matches(id uint16, name string, qtype dnsmessage.Type) bool {
+	return r.header.ID == id && r.question.Type == qtype && r.question.Class == dnsmessage.ClassINET &&
+		strings.EqualFold(r.question.Name.String(), fqdn(name))
+}
+
+// txtRecords returns the TXT records for name, following CNAMEs in the answer section.
+// The character strings of each record are concatenated, like net.Resolver.LookupTXT.
+func (r *response) txtRecords(name string) []string {
+	target := fqdn(name)
+
+	// Follow the CNAME chain to the name that owns the TXT records
+	for range maxCNAMEHops {
+		next := ""
+		for _, rec := range r.answers {
+			cname, ok := rec.Body.(*dnsmessage.CNAMEResource)
+			if ok && rec.Header.Class == dnsmessage.ClassINET && strings.EqualFold(rec.Header.Name.String(), target) {
+				next = cname.CNAME.String()
+				break
+			}
+		}
+		if next == "" {
+			break
+		}
+		target = next
+	}
+
+	var txts []string
+	for _, rec := range r.answers {
+		txt, ok := rec.Body.(*dnsmessage.TXTResource)
+		if !ok || rec.Header.Class != dnsmessage.ClassINET || !strings.EqualFold(rec.Header.Name.String(), target) {
+			continue
+		}
+		txts = append(txts, strings.Join(txt.TXT, ""))
+	}
+	return txts
+}
+
+// fqdn returns name as a fully qualified domain name with a trailing dot
+func fqdn(name string) string {
+	return strings.TrimSuffix(name, ".") + "."
+}
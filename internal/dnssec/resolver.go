@@ -0,0 +1,271 @@
+// Package dnssec provides a DNS resolver that only accepts DNSSEC-authenticated answers.
+//
+// Queries are sent to trusted validating resolvers over DNS-over-TLS or DNS-over-HTTPS, or
+// over plain TCP to a resolver on the loopback interface, and answers are only accepted if the
+// resolver set the AD (authenticated data) bit. The secure transport ensures the AD bit cannot
+// be forged on the path to the resolver.
+package dnssec
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// DefaultTimeout bounds each query to a single upstream
+const DefaultTimeout = 5 * time.Second
+
+// maxMessageSize is the largest DNS message accepted from an upstream
+const maxMessageSize = 65535
+
+var (
+	// ErrNotAuthenticated is returned when the upstream did not validate the answer with DNSSEC
+	ErrNotAuthenticated = errors.New("DNS response is not DNSSEC-authenticated")
+	// ErrNotFound is returned for an authenticated NXDOMAIN response
+	ErrNotFound = errors.New("no such host")
+)
+
+// upstream is a trusted validating resolver
+type upstream struct {
+	// scheme is "tcp" (loopback only), "tls" (DNS-over-TLS) or "https" (DNS-over-HTTPS)
+	scheme string
+	// address is host:port for tcp and tls, or the query URL for https
+	address string
+	host    string
+}
+
+// Resolver looks up TXT records through trusted DNSSEC-validating upstream resolvers
+type Resolver struct {
+	upstreams []upstream
+	tlsConfig *tls.Config
+	timeout   time.Duration
+}
+
+// NewResolver creates a resolver for the given upstreams, which are tried in order.
+// Upstreams are written as tls://host[:port], https://host/path or, for a validating
+// resolver on the same host, tcp://127.0.0.1[:port].
+func NewResolver(upstreams []string) (*Resolver, error) {
+	if len(upstreams) == 0 {
+		return nil, errors.New("at least one DNSSEC upstream resolver is required")
+	}
+
+	r := &Resolver{timeout: DefaultTimeout}
+	for _, raw := range upstreams {
+		up, err := parseUpstream(strings.TrimSpace(raw))
+		if err != nil {
+			return nil, err
+		}
+		r.upstreams = append(r.upstreams, up)
+	}
+	return r, nil
+}
+
+func parseUpstream(raw string) (upstream, error) {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return upstream{}, fmt.Errorf("invalid DNSSEC upstream %q", raw)
+	}
+
+	switch u.Scheme {
+	case "tcp", "tls":
+		// Plain TCP can be tampered with on the network, so it is only trusted on the loopback interface
+		if u.Scheme == "tcp" && !isLoopback(u.Hostname()) {
+			return upstream{}, fmt.Errorf("invalid DNSSEC upstream %q: tcp is only allowed for loopback addresses, use tls or https", raw)
+		}
+		port := u.Port()
+		if port == "" {
+			port = "53"
+			if u.Scheme == "tls" {
+				port = "853"
+			}
+		}
+		return upstream{scheme: u.Scheme, address: net.JoinHostPort(u.Hostname(), port), host: u.Hostname()}, nil
+	case "https":
+		return upstream{scheme: u.Scheme, address: u.String(), host: u.Hostname()}, nil
+	default:
+		return upstream{}, fmt.Errorf("invalid DNSSEC upstream %q: scheme must be tcp, tls or https", raw)
+	}
+}
+
+// isLoopback reports whether host is localhost or a loopback IP address
+func isLoopback(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// SetTLSConfig sets the TLS configuration for DNS-over-TLS and DNS-over-HTTPS upstreams (used for testing)
+func (r *Resolver) SetTLSConfig(cfg *tls.Config) {
+	r.tlsConfig = cfg
+}
+
+// LookupTXT returns the TXT records for name. It fails unless the upstream
+// reports that the answer was validated with DNSSEC.
+func (r *Resolver) LookupTXT(ctx context.Context, name string) ([]string, error) {
+	var lastErr error
+	for _, up := range r.upstreams {
+		txts, err := r.lookupTXT(ctx, up, name)
+		if err == nil {
+			return txts, nil
+		}
+		// Authentication failures are definitive answers; only retry transport and server failures
+		if errors.Is(err, ErrNotAuthenticated) || errors.Is(err, ErrNotFound) {
+			return nil, err
+		}
+		lastErr = err
+	}
+	return nil, lastErr
+}
+
+func (r *Resolver) lookupTXT(ctx context.Context, up upstream, name string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	var idBytes [2]byte
+	if _, err := rand.Read(idBytes[:]); err != nil {
+		return nil, fmt.Errorf("failed to generate query ID: %w", err)
+	}
+	id := binary.BigEndian.Uint16(idBytes[:])
+	// DoH recommends ID 0 so responses are cacheable; the TLS channel already authenticates them
+	if up.scheme == "https" {
+		id = 0
+	}
+
+	query, err := buildQuery(id, name, dnsmessage.TypeTXT)
+	if err != nil {
+		return nil, err
+	}
+
+	var msg []byte
+	if up.scheme == "https" {
+		msg, err = r.exchangeHTTPS(ctx, up, query)
+	} else {
+		msg, err = r.exchangeStream(ctx, up, query)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("DNS query to %s failed: %w", up.address, err)
+	}
+
+	resp, err := parseResponse(msg)
+	if err != nil {
+		return nil, fmt.Errorf("invalid response from %s: %w", up.address, err)
+	}
+	if !resp.matches(id, name, dnsmessage.TypeTXT) {
+		return nil, fmt.Errorf("response from %s does not match query", up.address)
+	}
+
+	switch resp.header.RCode {
+	case dnsmessage.RCodeSuccess:
+		if !resp.header.AuthenticData {
+			return nil, fmt.Errorf("%w: TXT records for %s (is the zone signed?)", ErrNotAuthenticated, name)
+		}
+		return resp.txtRecords(name), nil
+	case dnsmessage.RCodeNameError:
+		if !resp.header.AuthenticData {
+			return nil, fmt.Errorf("%w: NXDOMAIN for %s", ErrNotAuthenticated, name)
+		}
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, name)
+	case dnsmessage.RCodeServerFailure:
+		// Validating resolvers answer SERVFAIL when DNSSEC validation fails
+		return nil, fmt.Errorf("%s returned SERVFAIL for %s (DNSSEC validation may have failed)", up.address, name)
+	default:
+		return nil, fmt.Errorf("%s returned %s for %s", up.address, resp.header.RCode, name)
+	}
+}
+
+// exchangeStream sends a length-prefixed query over TCP or TLS (RFC 7766, RFC 7858)
+func (r *Resolver) exchangeStream(ctx context.Context, up upstream, query []byte) ([]byte, error) {
+	var conn net.Conn
+	var err error
+	if up.scheme == "tls" {
+		dialer := &tls.Dialer{Config: r.clientTLSConfig(up.host)}
+		conn, err = dialer.DialContext(ctx, "tcp", up.address)
+	} else {
+		conn, err = (&net.Dialer{}).DialContext(ctx, "tcp", up.address)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			return nil, err
+		}
+	}
+
+	framed := binary.BigEndian.AppendUint16(make([]byte, 0, 2+len(query)), uint16(len(query))) //nolint:gosec // Queries are far below 64KiB
+	if _, err := conn.Write(append(framed, query...)); err != nil {
+		return nil, err
+	}
+
+	var length [2]byte
+	if _, err := io.ReadFull(conn, length[:]); err != nil {
+		return nil, err
+	}
+	msg := make([]byte, binary.BigEndian.Uint16(length[:]))
+	if _, err := io.ReadFull(conn, msg); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+// exchangeHTTPS sends the query as a DNS-over-HTTPS POST request (RFC 8484)
+func (r *Resolver) exchangeHTTPS(ctx context.Context, up upstream, query []byte) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, up.address, bytes.NewReader(query))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/dns-message")
+	req.Header.Set("Accept", "application/dns-message")
+
+	client := &http.Client{
+		Transport: &http.Transport{TLSClientConfig: r.clientTLSConfig(up.host)},
+		CheckRedirect: func(_ *http.Request, _ []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+
+	msg, err := io.ReadAll(io.LimitReader(resp.Body, maxMessageSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(msg) > maxMessageSize {
+		return nil, errors.New("response too large")
+	}
+	return msg, nil
+}
+
+func (r *Resolver) clientTLSConfig(host string) *tls.Config {
+	cfg := &tls.Config{MinVersion: tls.VersionTLS12}
+	if r.tlsConfig != nil {
+		cfg = r.tlsConfig.Clone()
+	}
+	if cfg.ServerName == "" {
+		cfg.ServerName = host
+	}
+	return cfg
+}
@@ -0,0 +1,286 @@
+package dnssec_test
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/dnssec"
+)
+
+// stubAnswer is the configured response for a name
+type stubAnswer struct {
+	txt           []string
+	cname         string
+	authenticated bool
+	rcode         uint16
+}
+
+// stubDNS answers TXT queries from a fixed zone, like a validating recursive resolver
+type stubDNS struct {
+	zone    map[string]stubAnswer
+	queries atomic.Int32
+}
+
+// respond builds a response to the query. It copies the question section, and
+// answers with the CNAME (if any) and the TXT records of the target.
+func (s *stubDNS) respond(t *testing.T, query []byte) []byte {
+	t.Helper()
+	s.queries.Add(1)
+
+	// Find the end of the question: uncompressed name, then type and class
+	offset := 12
+	var labels []string
+	for query[offset] != 0 {
+		length := int(query[offset])
+		labels = append(labels, string(query[offset+1:offset+1+length]))
+		offset += 1 + length
+	}
+	questionEnd := offset + 5
+	name := strings.ToLower(strings.Join(labels, "."))
+
+	queryFlags := binary.BigEndian.Uint16(query[2:])
+	if queryFlags&(1<<5) == 0 {
+		t.Errorf("query for %s does not request authenticated data", name)
+	}
+
+	answer, ok := s.zone[name]
+	if !ok {
+		answer = stubAnswer{rcode: 3, authenticated: true}
+	}
+
+	var records []byte
+	count := 0
+	owner := []byte{0xC0, 12}
+	if answer.cname != "" {
+		target := encodeName(answer.cname)
+		records = append(records, owner...)
+		records = binary.BigEndian.AppendUint16(records, 5)
+		records = binary.BigEndian.AppendUint16(records, 1)
+		records = binary.BigEndian.AppendUint32(records, 300)
+		records = binary.BigEndian.AppendUint16(records, uint16(len(target)))
+		records = append(records, target...)
+		count++
+		owner = target
+		answer.txt = s.zone[answer.cname].txt
+	}
+	for _, txt := range answer.txt {
+		// Split into 255-byte character strings, as long TXT records are published
+		var data []byte
+		for len(txt) > 0 {
+			chunk := min(len(txt), 255)
+			data = append(data, byte(chunk))
+			data = append(data, txt[:chunk]...)
+			txt = txt[chunk:]
+		}
+		records = append(records, owner...)
+		records = binary.BigEndian.AppendUint16(records, 16)
+		records = binary.BigEndian.AppendUint16(records, 1)
+		records = binary.BigEndian.AppendUint32(records, 300)
+		records = binary.BigEndian.AppendUint16(records, uint16(len(data)))
+		records = append(records, data...)
+		count++
+	}
+
+	flags := uint16(1<<15|1<<8|1<<7) | answer.rcode
+	if answer.authenticated {
+		flags |= 1 << 5
+	}
+
+	resp := make([]byte, 12)
+	copy(resp, query[:2])
+	binary.BigEndian.PutUint16(resp[2:], flags)
+	binary.BigEndian.PutUint16(resp[4:], 1)
+	binary.BigEndian.PutUint16(resp[6:], uint16(count))
+	resp = append(resp, query[12:questionEnd]...)
+	return append(resp, records...)
+}
+
+func encodeName(name string) []byte {
+	var encoded []byte
+	for _, label := range strings.Split(name, ".") {
+		encoded = append(encoded, byte(len(label)))
+		encoded = append(encoded, label...)
+	}
+	return append(encoded, 0)
+}
+
+// serveStream answers length-prefixed queries on the listener (TCP or TLS)
+func (s *stubDNS) serveStream(t *testing.T, listener net.Listener) {
+	t.Helper()
+	t.Cleanup(func() { _ = listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				var length [2]byte
+				if _, err := io.ReadFull(conn, length[:]); err != nil {
+					return
+				}
+				query := make([]byte, binary.BigEndian.Uint16(length[:]))
+				if _, err := io.ReadFull(conn, query); err != nil {
+					return
+				}
+				resp := s.respond(t, query)
+				_, _ = conn.Write(append(binary.BigEndian.AppendUint16(nil, uint16(len(resp))), resp...))
+			}()
+		}
+	}()
+}
+
+func newStubDNS() *stubDNS {
+	longKey := "v=MCPv1; k=rsa; p=" + strings.Repeat("A", 400)
+	return &stubDNS{zone: map[string]stubAnswer{
+		"signed.example":   {txt: []string{"v=MCPv1; k=ed25519; p=abc", "unrelated"}, authenticated: true},
+		"long.example":     {txt: []string{longKey}, authenticated: true},
+		"unsigned.example": {txt: []string{"v=MCPv1; k=ed25519; p=abc"}},
+		"alias.example":    {cname: "signed.example", authenticated: true},
+		"bogus.example":    {rcode: 2},
+	}}
+}
+
+func TestResolver_TCP(t *testing.T) {
+	ctx := context.Background()
+	stub := newStubDNS()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	stub.serveStream(t, listener)
+
+	resolver, err := dnssec.NewResolver([]string{"tcp://" + listener.Addr().String()})
+	require.NoError(t, err)
+
+	t.Run("authenticated answer", func(t *testing.T) {
+		txts, err := resolver.LookupTXT(ctx, "signed.example")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"v=MCPv1; k=ed25519; p=abc", "unrelated"}, txts)
+	})
+
+	t.Run("long records are joined", func(t *testing.T) {
+		txts, err := resolver.LookupTXT(ctx, "long.example")
+		require.NoError(t, err)
+		require.Len(t, txts, 1)
+		assert.Len(t, txts[0], len("v=MCPv1; k=rsa; p=")+400)
+	})
+
+	t.Run("CNAME is followed", func(t *testing.T) {
+		txts, err := resolver.LookupTXT(ctx, "alias.example")
+		require.NoError(t, err)
+		assert.Contains(t, txts, "v=MCPv1; k=ed25519; p=abc")
+	})
+
+	t.Run("unsigned answer is rejected", func(t *testing.T) {
+		_, err := resolver.LookupTXT(ctx, "unsigned.example")
+		assert.ErrorIs(t, err, dnssec.ErrNotAuthenticated)
+	})
+
+	t.Run("authenticated NXDOMAIN", func(t *testing.T) {
+		_, err := resolver.LookupTXT(ctx, "missing.example")
+		assert.ErrorIs(t, err, dnssec.ErrNotFound)
+	})
+
+	t.Run("validation failure", func(t *testing.T) {
+		_, err := resolver.LookupTXT(ctx, "bogus.example")
+		assert.ErrorContains(t, err, "SERVFAIL")
+	})
+}
+
+func TestResolver_FallsBackToNextUpstream(t *testing.T) {
+	stub := newStubDNS()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	stub.serveStream(t, listener)
+
+	// Reserve a port with nothing listening on it
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	closedAddr := closed.Addr().String()
+	require.NoError(t, closed.Close())
+
+	resolver, err := dnssec.NewResolver([]string{"tcp://" + closedAddr, "tcp://" + listener.Addr().String()})
+	require.NoError(t, err)
+
+	txts, err := resolver.LookupTXT(context.Background(), "signed.example")
+	require.NoError(t, err)
+	assert.Len(t, txts, 2)
+}
+
+func TestResolver_TLSAndHTTPS(t *testing.T) {
+	ctx := context.Background()
+	stub := newStubDNS()
+
+	doh := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/dns-message" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		query, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/dns-message")
+		_, _ = w.Write(stub.respond(t, query))
+	}))
+	t.Cleanup(doh.Close)
+
+	// Reuse the test server certificate for the DNS-over-TLS listener
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{Certificates: doh.TLS.Certificates, MinVersion: tls.VersionTLS12})
+	require.NoError(t, err)
+	stub.serveStream(t, listener)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(doh.Certificate())
+	tlsConfig := &tls.Config{RootCAs: roots, MinVersion: tls.VersionTLS12}
+
+	for _, upstream := range []string{"tls://" + listener.Addr().String(), doh.URL + "/dns-query"} {
+		resolver, err := dnssec.NewResolver([]string{upstream})
+		require.NoError(t, err)
+
+		t.Run(strings.SplitN(upstream, ":", 2)[0]+" without trusted certificate", func(t *testing.T) {
+			_, err := resolver.LookupTXT(ctx, "signed.example")
+			var certErr *tls.CertificateVerificationError
+			assert.True(t, errors.As(err, &certErr), "expected certificate error, got %v", err)
+		})
+
+		resolver.SetTLSConfig(tlsConfig)
+
+		t.Run(strings.SplitN(upstream, ":", 2)[0], func(t *testing.T) {
+			txts, err := resolver.LookupTXT(ctx, "signed.example")
+			require.NoError(t, err)
+			assert.Contains(t, txts, "v=MCPv1; k=ed25519; p=abc")
+
+			_, err = resolver.LookupTXT(ctx, "unsigned.example")
+			assert.ErrorIs(t, err, dnssec.ErrNotAuthenticated)
+		})
+	}
+}
+
+func TestNewResolver_InvalidUpstreams(t *testing.T) {
+	for _, upstreams := range [][]string{nil, {"udp://1.1.1.1"}, {"1.1.1.1"}, {"tls://"}, {"tcp://1.1.1.1"}, {"tcp://resolver.example.com:53"}} {
+		_, err := dnssec.NewResolver(upstreams)
+		assert.Error(t, err, "upstreams %v", upstreams)
+	}
+}
+
+func TestNewResolver_LoopbackTCP(t *testing.T) {
+	for _, upstream := range []string{"tcp://127.0.0.1", "tcp://[::1]:5353", "tcp://localhost:53"} {
+		_, err := dnssec.NewResolver([]string{upstream})
+		assert.NoError(t, err, "upstream %s", upstream)
+	}
+}
@@ -0,0 +1,674 @@
+// Package prober checks whether remote MCP servers are live, by performing the MCP initialize
+// handshake over the streamable HTTP and SSE transports, and harvests the tools, prompts and
+// resources they offer.
+package prober
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+const (
+	// ProtocolVersion is the MCP protocol version requested when initializing
+	ProtocolVersion = "2025-06-18"
+	// userAgent identifies the registry to remote servers
+	userAgent = "MCP-Registry-Prober/1.0"
+	// maxMessageSize bounds the size of messages read from remote servers
+	maxMessageSize = 1 << 20
+	// maxListPages bounds the pages read when listing tools, prompts or resources
+	maxListPages = 10
+)
+
+// ErrAuthRequired is returned for remotes that refuse to initialize without authorization.
+// Such remotes are reachable, but whether they speak MCP cannot be confirmed.
+var ErrAuthRequired = errors.New("remote requires authorization")
+
+// ErrDisallowedAddress is returned for remotes that resolve to loopback, private, link-local or
+// unspecified addresses, which probes must not reach into
+var ErrDisallowedAddress = errors.New("remote resolves to a disallowed address")
+
+var (
+	errUnexpectedStatus      = errors.New("unexpected status")
+	errUnexpectedContentType = errors.New("unexpected content type")
+	errUnsupportedTransport  = errors.New("unsupported transport type for remotes")
+)
+
+// ServerInfo identifies the implementation of a remote server
+type ServerInfo struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// Result is the outcome of a successful initialize handshake
+type Result struct {
+	// ProtocolVersion is the MCP protocol version the remote server agreed to
+	ProtocolVersion string
+	// Capabilities are the capabilities the remote server announced, such as tools or prompts
+	Capabilities map[string]any
+	ServerInfo   ServerInfo
+	// Manifest lists the tools, prompts and resources of the capabilities the remote server
+	// announced. It is nil if listing them failed.
+	Manifest *apiv0.CapabilityManifest
+}
+
+// Prober probes remote MCP servers
+type Prober struct {
+	client  *http.Client
+	timeout time.Duration
+}
+
+// New creates a prober whose probes time out after timeout. Unless allowPrivateNetworks is set,
+// such as for local development, probes only connect to public addresses, so that published
+// remotes cannot point the registry at its own network.
+func New(timeout time.Duration, allowPrivateNetworks bool) *Prober {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if !allowPrivateNetworks {
+		// Connect directly, so that the addresses checked are those of the remotes
+		transport.Proxy = nil
+		dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second, Control: checkAddress}
+		transport.DialContext = dialer.DialContext
+	}
+	return &Prober{
+		client: &http.Client{
+			Transport: transport,
+			// Redirects are not followed, so that probes only reach the published URLs
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		timeout: timeout,
+	}
+}
+
+// nonPublicPrefixes are special-purpose ranges that global unicast addresses can fall in but that
+// are not publicly routable, from the IANA IPv4 and IPv6 special-purpose address registries
+var nonPublicPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),       // "this network"
+	netip.MustParsePrefix("100.64.0.0/10"),   // carrier-grade NAT, including cloud metadata services
+	netip.MustParsePrefix("192.0.0.0/24"),    // IETF protocol assignments
+	netip.MustParsePrefix("192.0.2.0/24"),    // documentation
+	netip.MustParsePrefix("192.88.99.0/24"),  // 6to4 relay anycast
+	netip.MustParsePrefix("198.18.0.0/15"),   // benchmarking
+	netip.MustParsePrefix("198.51.100.0/24"), // documentation
+	netip.MustParsePrefix("203.0.113.0/24"),  // documentation
+	netip.MustParsePrefix("240.0.0.0/4"),     // reserved, including broadcast
+	netip.MustParsePrefix("64:ff9b:1::/48"),  // local-use NAT64
+	netip.MustParsePrefix("100::/64"),        // discard-only
+	netip.MustParsePrefix("2001::/23"),       // IETF protocol assignments, including Teredo
+	netip.MustParsePrefix("2001:db8::/32"),   // documentation
+	netip.MustParsePrefix("fec0::/10"),       // deprecated site-local
+}
+
+var (
+	nat64Prefix = netip.MustParsePrefix("64:ff9b::/96")
+	sixToFour   = netip.MustParsePrefix("2002::/16")
+)
+
+// checkAddress refuses connections to addresses that are not public, after they were resolved
+func checkAddress(_, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrDisallowedAddress, address)
+	}
+	if !isPublic(addrPort.Addr()) {
+		return fmt.Errorf("%w: %s", ErrDisallowedAddress, addrPort.Addr())
+	}
+	return nil
+}
+
+// isPublic reports whether addr is a publicly routable unicast address. IPv6 addresses that
+// embed an IPv4 address, through NAT64 or 6to4, are only public if the IPv4 address is.
+func isPublic(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if addr.Is6() {
+		bytes := addr.As16()
+		switch {
+		case nat64Prefix.Contains(addr):
+			return isPublic(netip.AddrFrom4([4]byte(bytes[12:16])))
+		case sixToFour.Contains(addr):
+			return isPublic(netip.AddrFrom4([4]byte(bytes[2:6])))
+		}
+	}
+
+	if !addr.IsGlobalUnicast() || addr.IsPrivate() {
+		return false
+	}
+	for _, prefix := range nonPublicPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+// ErrorCategory reduces a probe error to a coarse category. Probe errors can carry details of the
+// responses and network of remotes, which are not recorded.
+func ErrorCategory(err error) string {
+	var dnsErr *net.DNSError
+	var netErr net.Error
+	var certErr *tls.CertificateVerificationError
+	switch {
+	case err == nil:
+		return ""
+	case errors.Is(err, ErrAuthRequired):
+		return "authorization required"
+	case errors.Is(err, ErrDisallowedAddress):
+		return "disallowed address"
+	case errors.Is(err, errUnsupportedTransport):
+		return "unsupported transport"
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &netErr) && netErr.Timeout():
+		return "timeout"
+	case errors.As(err, &dnsErr):
+		return "DNS lookup failed"
+	case errors.As(err, &certErr):
+		return "TLS certificate verification failed"
+	case errors.Is(err, errUnexpectedStatus):
+		return "unexpected HTTP status"
+	case errors.Is(err, errUnexpectedContentType):
+		return "not an MCP endpoint"
+	case errors.As(err, &netErr):
+		return "connection failed"
+	default:
+		return "invalid MCP response"
+	}
+}
+
+// Probe performs the MCP initialize handshake with a remote, then lists what it offers
+func (p *Prober) Probe(ctx context.Context, remote model.Transport) (*Result, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	var s session
+	switch remote.Type {
+	case model.TransportTypeStreamableHTTP:
+		s = &streamableHTTPSession{prober: p, endpoint: remote.URL}
+	case model.TransportTypeSSE:
+		sse, err := p.openSSESession(ctx, remote.URL)
+		if err != nil {
+			return nil, err
+		}
+		s = sse
+	default:
+		return nil, fmt.Errorf("%w: %s", errUnsupportedTransport, remote.Type)
+	}
+	defer s.close()
+
+	result, err := initialize(ctx, s)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.notify(ctx, "notifications/initialized"); err == nil {
+		result.Manifest, _ = listCapabilities(ctx, s, result.Capabilities)
+	}
+	return result, nil
+}
+
+// session exchanges JSON-RPC messages with a remote server
+type session interface {
+	// call sends a request and returns the result of the response to it
+	call(ctx context.Context, method string, params any) (json.RawMessage, error)
+	// notify sends a notification
+	notify(ctx context.Context, method string) error
+	// close ends the session. It is best effort.
+	close()
+}
+
+type initializeParams struct {
+	ProtocolVersion string         `json:"protocolVersion"`
+	Capabilities    map[string]any `json:"capabilities"`
+	ClientInfo      ServerInfo     `json:"clientInfo"`
+}
+
+type initializeResult struct {
+	ProtocolVersion string         `json:"protocolVersion"`
+	Capabilities    map[string]any `json:"capabilities"`
+	ServerInfo      ServerInfo     `json:"serverInfo"`
+}
+
+func initialize(ctx context.Context, s session) (*Result, error) {
+	data, err := s.call(ctx, "initialize", initializeParams{
+		ProtocolVersion: ProtocolVersion,
+		Capabilities:    map[string]any{},
+		ClientInfo:      ServerInfo{Name: "mcp-registry-prober", Version: "1.0"},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result initializeResult
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("invalid initialize result: %w", err)
+	}
+	if result.ProtocolVersion == "" {
+		return nil, errors.New("initialize result has no protocol version")
+	}
+
+	return &Result{
+		ProtocolVersion: result.ProtocolVersion,
+		Capabilities:    result.Capabilities,
+		ServerInfo:      result.ServerInfo,
+	}, nil
+}
+
+// listCapabilities lists the tools, prompts and resources of the capabilities a remote server announced
+func listCapabilities(ctx context.Context, s session, capabilities map[string]any) (*apiv0.CapabilityManifest, error) {
+	manifest := &apiv0.CapabilityManifest{}
+	var err error
+	if _, ok := capabilities["tools"]; ok {
+		if manifest.Tools, err = list[apiv0.ToolCapability](ctx, s, "tools"); err != nil {
+			return nil, err
+		}
+	}
+	if _, ok := capabilities["prompts"]; ok {
+		if manifest.Prompts, err = list[apiv0.PromptCapability](ctx, s, "prompts"); err != nil {
+			return nil, err
+		}
+	}
+	if _, ok := capabilities["resources"]; ok {
+		if manifest.Resources, err = list[apiv0.ResourceCapability](ctx, s, "resources"); err != nil {
+			return nil, err
+		}
+	}
+	return manifest, nil
+}
+
+// list pages through the items of a list method, such as tools/list for kind tools
+func list[T any](ctx context.Context, s session, kind string) ([]T, error) {
+	var items []T
+	cursor := ""
+	for range maxListPages {
+		var params any
+		if cursor != "" {
+			params = map[string]string{"cursor": cursor}
+		}
+		data, err := s.call(ctx, kind+"/list", params)
+		if err != nil {
+			return nil, err
+		}
+
+		var page map[string]json.RawMessage
+		if err := json.Unmarshal(data, &page); err != nil {
+			return nil, fmt.Errorf("invalid %s/list result: %w", kind, err)
+		}
+		var pageItems []T
+		if err := json.Unmarshal(page[kind], &pageItems); err != nil {
+			return nil, fmt.Errorf("invalid %s/list result: %w", kind, err)
+		}
+		items = append(items, pageItems...)
+
+		cursor = ""
+		if next, ok := page["nextCursor"]; ok {
+			_ = json.Unmarshal(next, &cursor)
+		}
+		if cursor == "" {
+			return items, nil
+		}
+	}
+	return nil, fmt.Errorf("%s/list has more than %d pages", kind, maxListPages)
+}
+
+// streamableHTTPSession posts messages to the endpoint, which answers requests with either a JSON
+// response or an event stream carrying it
+type streamableHTTPSession struct {
+	prober          *Prober
+	endpoint        string
+	sessionID       string
+	protocolVersion string
+	lastID          int
+}
+
+func (s *streamableHTTPSession) call(ctx context.Context, method string, params any) (json.RawMessage, error) {
+	s.lastID++
+	id := s.lastID
+	resp, err := s.post(ctx, jsonrpcRequest{JSONRPC: "2.0", ID: &id, Method: method, Params: params})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var result json.RawMessage
+	switch mediaType(resp) {
+	case "application/json":
+		data, err := io.ReadAll(io.LimitReader(resp.Body, maxMessageSize))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s response: %w", method, err)
+		}
+		var ok bool
+		result, ok, err = parseResponse(data, id)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, fmt.Errorf("response does not answer the %s request", method)
+		}
+	case "text/event-stream":
+		result, err = readResponse(newEventReader(resp.Body), id)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("%w '%s'", errUnexpectedContentType, resp.Header.Get("Content-Type"))
+	}
+
+	// Later requests must carry the negotiated protocol version
+	if method == "initialize" {
+		var initialized initializeResult
+		if json.Unmarshal(result, &initialized) == nil {
+			s.protocolVersion = initialized.ProtocolVersion
+		}
+	}
+	return result, nil
+}
+
+func (s *streamableHTTPSession) notify(ctx context.Context, method string) error {
+	resp, err := s.post(ctx, jsonrpcRequest{JSONRPC: "2.0", Method: method})
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// post sends a message, keeping the session the remote server assigns with its first response
+func (s *streamableHTTPSession) post(ctx context.Context, message jsonrpcRequest) (*http.Response, error) {
+	body, err := json.Marshal(message)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json, text/event-stream")
+	s.setSessionHeaders(req)
+
+	resp, err := s.prober.do(req)
+	if err != nil {
+		return nil, err
+	}
+	if s.sessionID == "" {
+		s.sessionID = resp.Header.Get("Mcp-Session-Id")
+	}
+	if err := checkStatus(resp); err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *streamableHTTPSession) setSessionHeaders(req *http.Request) {
+	if s.sessionID != "" {
+		req.Header.Set("Mcp-Session-Id", s.sessionID)
+	}
+	if s.protocolVersion != "" {
+		req.Header.Set("Mcp-Protocol-Version", s.protocolVersion)
+	}
+}
+
+// close terminates the session the remote server created for the probe
+func (s *streamableHTTPSession) close() {
+	if s.sessionID == "" {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.prober.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, s.endpoint, nil)
+	if err != nil {
+		return
+	}
+	s.setSessionHeaders(req)
+	if resp, err := s.prober.do(req); err == nil {
+		resp.Body.Close()
+	}
+}
+
+// sseSession posts messages to the endpoint announced on the event stream of the remote server,
+// which answers requests on the event stream
+type sseSession struct {
+	prober     *Prober
+	stream     io.Closer
+	events     *eventReader
+	messageURL string
+	lastID     int
+}
+
+// openSSESession opens the event stream of the endpoint, which first announces where to post messages
+func (p *Prober) openSSESession(ctx context.Context, endpoint string) (*sseSession, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+
+	resp, err := p.do(req)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkStatus(resp); err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	if mediaType(resp) != "text/event-stream" {
+		resp.Body.Close()
+		return nil, fmt.Errorf("%w '%s'", errUnexpectedContentType, resp.Header.Get("Content-Type"))
+	}
+
+	events := newEventReader(resp.Body)
+	messageURL, err := readEndpoint(events, resp.Request.URL)
+	if err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+
+	return &sseSession{prober: p, stream: resp.Body, events: events, messageURL: messageURL.String()}, nil
+}
+
+func (s *sseSession) call(ctx context.Context, method string, params any) (json.RawMessage, error) {
+	s.lastID++
+	id := s.lastID
+	if err := s.post(ctx, jsonrpcRequest{JSONRPC: "2.0", ID: &id, Method: method, Params: params}); err != nil {
+		return nil, err
+	}
+	return readResponse(s.events, id)
+}
+
+func (s *sseSession) notify(ctx context.Context, method string) error {
+	return s.post(ctx, jsonrpcRequest{JSONRPC: "2.0", Method: method})
+}
+
+func (s *sseSession) post(ctx context.Context, message jsonrpcRequest) error {
+	body, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.messageURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.prober.do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return checkStatus(resp)
+}
+
+func (s *sseSession) close() {
+	s.stream.Close()
+}
+
+// readEndpoint reads the endpoint event of an SSE stream. Endpoints on other origins are refused,
+// so that remote servers cannot point probes elsewhere.
+func readEndpoint(events *eventReader, streamURL *url.URL) (*url.URL, error) {
+	for {
+		ev, err := events.next()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read endpoint event: %w", err)
+		}
+		if ev.name != "endpoint" {
+			continue
+		}
+
+		ref, err := url.Parse(strings.TrimSpace(ev.data))
+		if err != nil {
+			return nil, fmt.Errorf("invalid endpoint '%s': %w", ev.data, err)
+		}
+		endpoint := streamURL.ResolveReference(ref)
+		if endpoint.Scheme != streamURL.Scheme || endpoint.Host != streamURL.Host {
+			return nil, fmt.Errorf("endpoint '%s' is on a different origin than the event stream", endpoint)
+		}
+		return endpoint, nil
+	}
+}
+
+func (p *Prober) do(req *http.Request) (*http.Response, error) {
+	req.Header.Set("User-Agent", userAgent)
+	return p.client.Do(req)
+}
+
+// checkStatus fails for responses that are not successful
+func checkStatus(resp *http.Response) error {
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return fmt.Errorf("%w (status: %d)", ErrAuthRequired, resp.StatusCode)
+	case resp.StatusCode < 200 || resp.StatusCode > 299:
+		return fmt.Errorf("%w: %d", errUnexpectedStatus, resp.StatusCode)
+	default:
+		return nil
+	}
+}
+
+func mediaType(resp *http.Response) string {
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	return mediaType
+}
+
+// jsonrpcRequest is a JSON-RPC request, or a notification if it has no ID
+type jsonrpcRequest struct {
+	JSONRPC string `json:"jsonrpc"`
+	ID      *int   `json:"id,omitempty"`
+	Method  string `json:"method"`
+	Params  any    `json:"params,omitempty"`
+}
+
+type jsonrpcResponse struct {
+	ID     json.RawMessage `json:"id"`
+	Result json.RawMessage `json:"result"`
+	Error  *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// parseResponse parses a JSON-RPC message, and reports whether it is the response to the request
+// with the given ID. Remote servers may send notifications or requests of their own before answering.
+func parseResponse(data []byte, id int) (json.RawMessage, bool, error) {
+	var resp jsonrpcResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, false, fmt.Errorf("invalid JSON-RPC message: %w", err)
+	}
+	if string(bytes.TrimSpace(resp.ID)) != fmt.Sprint(id) {
+		return nil, false, nil
+	}
+	if resp.Error != nil {
+		return nil, false, fmt.Errorf("request failed: %s (code: %d)", resp.Error.Message, resp.Error.Code)
+	}
+	return resp.Result, true, nil
+}
+
+// readResponse reads message events until one answers the request with the given ID
+func readResponse(events *eventReader, id int) (json.RawMessage, error) {
+	for {
+		ev, err := events.next()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response: %w", err)
+		}
+		if ev.name != "message" {
+			continue
+		}
+
+		result, ok, err := parseResponse([]byte(ev.data), id)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			return result, nil
+		}
+	}
+}
+
+// event is a server-sent event
+type event struct {
+	name string
+	data string
+}
+
+// eventReader reads server-sent events from a stream
+type eventReader struct {
+	scanner *bufio.Scanner
+}
+
+func newEventReader(r io.Reader) *eventReader {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 4096), maxMessageSize)
+	return &eventReader{scanner: scanner}
+}
+
+// next returns the next event, or io.EOF once the stream ended
+func (r *eventReader) next() (event, error) {
+	var ev event
+	var data []string
+	for r.scanner.Scan() {
+		line := r.scanner.Text()
+		if line == "" {
+			// A blank line dispatches the event, unless it has no data
+			if data == nil {
+				ev = event{}
+				continue
+			}
+			ev.data = strings.Join(data, "\n")
+			if ev.name == "" {
+				ev.name = "message"
+			}
+			return ev, nil
+		}
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "event":
+			ev.name = value
+		case "data":
+			data = append(data, value)
+		}
+	}
+	if err := r.scanner.Err(); err != nil {
+		return event{}, err
+	}
+	return event{}, io.EOF
+}
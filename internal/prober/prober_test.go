@@ -0,0 +1,269 @@
+package prober_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/prober"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// handleMessage answers a JSON-RPC message the way an MCP server offering two tools across two
+// pages would. Notifications are not answered.
+func handleMessage(t *testing.T, r *http.Request) []byte {
+	t.Helper()
+	var req struct {
+		ID     *int   `json:"id"`
+		Method string `json:"method"`
+		Params struct {
+			ProtocolVersion string `json:"protocolVersion"`
+			Cursor          string `json:"cursor"`
+		} `json:"params"`
+	}
+	require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+	if req.ID == nil {
+		assert.Equal(t, "notifications/initialized", req.Method)
+		return nil
+	}
+
+	var result any
+	switch req.Method {
+	case "initialize":
+		result = map[string]any{
+			"protocolVersion": req.Params.ProtocolVersion,
+			"capabilities":    map[string]any{"tools": map[string]any{"listChanged": true}},
+			"serverInfo":      map[string]string{"name": "fake-server", "version": "1.2.3"},
+		}
+	case "tools/list":
+		if r.URL.Path != "/messages" {
+			// Requests over streamable HTTP carry the negotiated protocol version
+			assert.Equal(t, prober.ProtocolVersion, r.Header.Get("Mcp-Protocol-Version"))
+		}
+		if req.Params.Cursor == "" {
+			result = map[string]any{
+				"tools":      []map[string]any{{"name": "get_weather", "description": "Get the current weather", "inputSchema": map[string]any{"type": "object"}}},
+				"nextCursor": "page-2",
+			}
+		} else {
+			result = map[string]any{
+				"tools": []map[string]any{{"name": "get_forecast", "inputSchema": map[string]any{"type": "object"}}},
+			}
+		}
+	default:
+		t.Errorf("unexpected method %s", req.Method)
+	}
+
+	data, err := json.Marshal(map[string]any{"jsonrpc": "2.0", "id": *req.ID, "result": result})
+	require.NoError(t, err)
+	return data
+}
+
+// newFakeMCPServer starts an in-process MCP server, serving the streamable HTTP transport at /mcp
+// and /mcp-stream and the SSE transport at /sse, along with misbehaving endpoints
+func newFakeMCPServer(t *testing.T) (*httptest.Server, *atomic.Int64) {
+	t.Helper()
+	var endedSessions atomic.Int64
+	messages := make(chan []byte, 1)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /mcp", func(w http.ResponseWriter, r *http.Request) {
+		response := handleMessage(t, r)
+		if response == nil {
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Mcp-Session-Id", "session-1")
+		_, _ = w.Write(response)
+	})
+	mux.HandleFunc("DELETE /mcp", func(_ http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Mcp-Session-Id") == "session-1" {
+			endedSessions.Add(1)
+		}
+	})
+	mux.HandleFunc("POST /mcp-stream", func(w http.ResponseWriter, r *http.Request) {
+		response := handleMessage(t, r)
+		if response == nil {
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = fmt.Fprintf(w, ": keep-alive\n\nevent: message\ndata: {\"jsonrpc\":\"2.0\",\"method\":\"notifications/message\"}\n\n")
+		_, _ = fmt.Fprintf(w, "data: %s\n\n", response)
+	})
+	mux.HandleFunc("GET /sse", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = fmt.Fprintf(w, "event: endpoint\ndata: /messages?session=1\n\n")
+		w.(http.Flusher).Flush()
+		for {
+			select {
+			case message := <-messages:
+				_, _ = fmt.Fprintf(w, "event: message\ndata: %s\n\n", message)
+				w.(http.Flusher).Flush()
+			case <-r.Context().Done():
+				return
+			}
+		}
+	})
+	mux.HandleFunc("POST /messages", func(w http.ResponseWriter, r *http.Request) {
+		if response := handleMessage(t, r); response != nil {
+			messages <- response
+		}
+		w.WriteHeader(http.StatusAccepted)
+	})
+	mux.HandleFunc("GET /sse-elsewhere", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = fmt.Fprintf(w, "event: endpoint\ndata: https://attacker.example.com/messages\n\n")
+	})
+	mux.HandleFunc("/auth", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("WWW-Authenticate", `Bearer resource_metadata="https://example.com/.well-known/oauth-protected-resource"`)
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+	mux.HandleFunc("/website", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte("<html></html>"))
+	})
+	mux.HandleFunc("/rpc-error", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"error":{"code":-32602,"message":"unsupported protocol version"}}`))
+	})
+	mux.HandleFunc("/redirect", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/mcp", http.StatusTemporaryRedirect)
+	})
+	mux.HandleFunc("/hang", func(_ http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server, &endedSessions
+}
+
+func TestProbe(t *testing.T) {
+	server, endedSessions := newFakeMCPServer(t)
+	p := prober.New(500*time.Millisecond, true)
+
+	tests := []struct {
+		name          string
+		transportType string
+		path          string
+		expectError   string
+	}{
+		{name: "streamable HTTP with JSON response", transportType: model.TransportTypeStreamableHTTP, path: "/mcp"},
+		{name: "streamable HTTP with event stream response", transportType: model.TransportTypeStreamableHTTP, path: "/mcp-stream"},
+		{name: "SSE", transportType: model.TransportTypeSSE, path: "/sse"},
+		{name: "SSE endpoint on another origin", transportType: model.TransportTypeSSE, path: "/sse-elsewhere", expectError: "different origin"},
+		{name: "authorization required", transportType: model.TransportTypeStreamableHTTP, path: "/auth", expectError: "requires authorization"},
+		{name: "not an MCP server", transportType: model.TransportTypeStreamableHTTP, path: "/website", expectError: "unexpected content type"},
+		{name: "missing endpoint", transportType: model.TransportTypeStreamableHTTP, path: "/missing", expectError: "unexpected status: 404"},
+		{name: "redirects are not followed", transportType: model.TransportTypeStreamableHTTP, path: "/redirect", expectError: "unexpected status: 307"},
+		{name: "initialize error", transportType: model.TransportTypeStreamableHTTP, path: "/rpc-error", expectError: "unsupported protocol version"},
+		{name: "unresponsive server", transportType: model.TransportTypeSSE, path: "/hang", expectError: "deadline exceeded"},
+		{name: "unsupported transport", transportType: model.TransportTypeStdio, path: "/mcp", expectError: "unsupported transport type"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := p.Probe(context.Background(), model.Transport{Type: tt.transportType, URL: server.URL + tt.path})
+			if tt.expectError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectError)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, prober.ProtocolVersion, result.ProtocolVersion)
+			assert.Contains(t, result.Capabilities, "tools")
+			assert.Equal(t, prober.ServerInfo{Name: "fake-server", Version: "1.2.3"}, result.ServerInfo)
+			require.NotNil(t, result.Manifest)
+			assert.Equal(t, []apiv0.ToolCapability{
+				{Name: "get_weather", Description: "Get the current weather"},
+				{Name: "get_forecast"},
+			}, result.Manifest.Tools)
+			assert.Empty(t, result.Manifest.Prompts, "prompts are not listed unless announced")
+		})
+	}
+
+	t.Run("authorization required is distinguishable", func(t *testing.T) {
+		_, err := p.Probe(context.Background(), model.Transport{Type: model.TransportTypeStreamableHTTP, URL: server.URL + "/auth"})
+		assert.ErrorIs(t, err, prober.ErrAuthRequired)
+	})
+
+	t.Run("sessions are ended after probing", func(t *testing.T) {
+		assert.Equal(t, int64(1), endedSessions.Load())
+	})
+}
+
+func TestProbe_PrivateNetworks(t *testing.T) {
+	server, _ := newFakeMCPServer(t)
+	p := prober.New(500*time.Millisecond, false)
+
+	for _, url := range []string{
+		server.URL + "/mcp",
+		"http://[::1]:1/mcp",
+		"http://169.254.169.254/mcp",
+		"http://10.0.0.1/mcp",
+		"http://0.0.0.0/mcp",
+		"http://0.1.2.3/mcp",
+		"http://100.100.100.200/mcp",
+		"http://198.18.0.1/mcp",
+		"http://224.0.0.1/mcp",
+		"http://255.255.255.255/mcp",
+		"http://[64:ff9b::a00:1]/mcp",    // NAT64 for 10.0.0.1
+		"http://[2002:a9fe:a9fe::1]/mcp", // 6to4 for 169.254.169.254
+		"http://[fd00::1]/mcp",
+		"http://[ff02::1]/mcp",
+	} {
+		t.Run(url, func(t *testing.T) {
+			_, err := p.Probe(context.Background(), model.Transport{Type: model.TransportTypeStreamableHTTP, URL: url})
+			assert.ErrorIs(t, err, prober.ErrDisallowedAddress)
+			assert.Equal(t, "disallowed address", prober.ErrorCategory(err))
+		})
+	}
+}
+
+func TestErrorCategory(t *testing.T) {
+	server, _ := newFakeMCPServer(t)
+	p := prober.New(500*time.Millisecond, true)
+
+	tests := []struct {
+		path     string
+		category string
+	}{
+		{path: "/missing", category: "unexpected HTTP status"},
+		{path: "/website", category: "not an MCP endpoint"},
+		{path: "/rpc-error", category: "invalid MCP response"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			_, err := p.Probe(context.Background(), model.Transport{Type: model.TransportTypeStreamableHTTP, URL: server.URL + tt.path})
+			require.Error(t, err)
+			assert.Equal(t, tt.category, prober.ErrorCategory(err))
+		})
+	}
+
+	t.Run("unreachable remote", func(t *testing.T) {
+		closed := httptest.NewServer(http.NotFoundHandler())
+		closed.Close()
+		_, err := p.Probe(context.Background(), model.Transport{Type: model.TransportTypeStreamableHTTP, URL: closed.URL + "/mcp"})
+		require.Error(t, err)
+		assert.Equal(t, "connection failed", prober.ErrorCategory(err))
+	})
+
+	t.Run("unresponsive remote", func(t *testing.T) {
+		_, err := p.Probe(context.Background(), model.Transport{Type: model.TransportTypeSSE, URL: server.URL + "/hang"})
+		require.Error(t, err)
+		assert.Equal(t, "timeout", prober.ErrorCategory(err))
+	})
+}
@@ -0,0 +1,59 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// sweepInterval controls how often expired windows are dropped from memory
+const sweepInterval = time.Minute
+
+type memoryWindow struct {
+	start   time.Time
+	count   int
+	expires time.Time
+}
+
+// MemoryLimiter is an in-process Limiter. Counts are not shared between replicas.
+type MemoryLimiter struct {
+	mu        sync.Mutex
+	windows   map[string]*memoryWindow
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// NewMemoryLimiter creates a new in-memory limiter
+func NewMemoryLimiter() *MemoryLimiter {
+	return &MemoryLimiter{
+		windows: make(map[string]*memoryWindow),
+		now:     time.Now,
+	}
+}
+
+// Allow records a request for the given key and reports whether it fits within the budget
+func (m *MemoryLimiter) Allow(_ context.Context, key string, budget Budget) (Result, error) {
+	now := m.now()
+	start := windowStart(now, budget.Window)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if now.Sub(m.lastSweep) >= sweepInterval {
+		for k, w := range m.windows {
+			if !now.Before(w.expires) {
+				delete(m.windows, k)
+			}
+		}
+		m.lastSweep = now
+	}
+
+	w, ok := m.windows[key]
+	if !ok || !w.start.Equal(start) {
+		w = &memoryWindow{start: start, expires: start.Add(budget.Window)}
+		m.windows[key] = w
+	}
+	w.count++
+
+	return newResult(w.count, start, budget), nil
+}
@@ -0,0 +1,75 @@
+package ratelimit_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/ratelimit"
+)
+
+func TestMemoryLimiter_Allow(t *testing.T) {
+	ctx := context.Background()
+	limiter := ratelimit.NewMemoryLimiter()
+	budget := ratelimit.Budget{Limit: 2, Window: time.Hour}
+
+	first, err := limiter.Allow(ctx, "ip:1.2.3.4", budget)
+	require.NoError(t, err)
+	assert.True(t, first.Allowed)
+	assert.Equal(t, 1, first.Remaining)
+	assert.Equal(t, 2, first.Limit)
+
+	second, err := limiter.Allow(ctx, "ip:1.2.3.4", budget)
+	require.NoError(t, err)
+	assert.True(t, second.Allowed)
+	assert.Equal(t, 0, second.Remaining)
+
+	third, err := limiter.Allow(ctx, "ip:1.2.3.4", budget)
+	require.NoError(t, err)
+	assert.False(t, third.Allowed)
+	assert.Equal(t, 0, third.Remaining)
+	assert.True(t, third.ResetAt.After(time.Now()))
+
+	// Other keys have their own budget
+	other, err := limiter.Allow(ctx, "ip:5.6.7.8", budget)
+	require.NoError(t, err)
+	assert.True(t, other.Allowed)
+}
+
+type fakeCounterStore struct {
+	counts  map[string]int
+	deleted []time.Time
+}
+
+func (f *fakeCounterStore) IncrementRateLimit(_ context.Context, key string, windowStart time.Time) (int, error) {
+	k := key + "@" + windowStart.String()
+	f.counts[k]++
+	return f.counts[k], nil
+}
+
+func (f *fakeCounterStore) DeleteRateLimitsBefore(_ context.Context, before time.Time) (int64, error) {
+	f.deleted = append(f.deleted, before)
+	return 0, nil
+}
+
+func TestPostgresLimiter_Allow(t *testing.T) {
+	ctx := context.Background()
+	store := &fakeCounterStore{counts: map[string]int{}}
+	limiter := ratelimit.NewPostgresLimiter(store)
+	budget := ratelimit.Budget{Limit: 1, Window: time.Hour}
+
+	first, err := limiter.Allow(ctx, "sub:github-at:octocat", budget)
+	require.NoError(t, err)
+	assert.True(t, first.Allowed)
+
+	second, err := limiter.Allow(ctx, "sub:github-at:octocat", budget)
+	require.NoError(t, err)
+	assert.False(t, second.Allowed)
+
+	// Stale windows are swept at most once a minute, keeping the longest window seen
+	require.Len(t, store.deleted, 1)
+	assert.WithinDuration(t, time.Now().Add(-time.Hour), store.deleted[0], time.Minute)
+}
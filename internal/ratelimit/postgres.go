@@ -0,0 +1,69 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// CounterStore persists rate limit counters so they can be shared between replicas
+type CounterStore interface {
+	// IncrementRateLimit increments the counter for key in the window starting at windowStart
+	// and returns the new count
+	IncrementRateLimit(ctx context.Context, key string, windowStart time.Time) (int, error)
+	// DeleteRateLimitsBefore deletes the counters of windows starting before the given time
+	DeleteRateLimitsBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+// PostgresLimiter is a Limiter backed by a shared counter store (the registry's PostgreSQL database)
+type PostgresLimiter struct {
+	store CounterStore
+	now   func() time.Time
+
+	mu        sync.Mutex
+	maxWindow time.Duration
+	lastSweep time.Time
+}
+
+// NewPostgresLimiter creates a new limiter that stores counters in the given store
+func NewPostgresLimiter(store CounterStore) *PostgresLimiter {
+	return &PostgresLimiter{
+		store: store,
+		now:   time.Now,
+	}
+}
+
+// Allow records a request for the given key and reports whether it fits within the budget
+func (p *PostgresLimiter) Allow(ctx context.Context, key string, budget Budget) (Result, error) {
+	start := windowStart(p.now(), budget.Window)
+
+	count, err := p.store.IncrementRateLimit(ctx, key, start)
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to increment rate limit counter: %w", err)
+	}
+
+	p.sweep(ctx, budget.Window)
+	return newResult(count, start, budget), nil
+}
+
+// sweep deletes the counters of windows that started longer ago than the longest window seen,
+// at most once every sweepInterval. Those windows have ended, so their counters are never read again.
+func (p *PostgresLimiter) sweep(ctx context.Context, window time.Duration) {
+	now := p.now()
+
+	p.mu.Lock()
+	p.maxWindow = max(p.maxWindow, window)
+	if now.Sub(p.lastSweep) < sweepInterval {
+		p.mu.Unlock()
+		return
+	}
+	p.lastSweep = now
+	before := now.Add(-p.maxWindow)
+	p.mu.Unlock()
+
+	if _, err := p.store.DeleteRateLimitsBefore(ctx, before); err != nil {
+		log.Printf("Failed to delete stale rate limit counters: %v", err)
+	}
+}
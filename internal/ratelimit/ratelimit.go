@@ -0,0 +1,46 @@
+// Package ratelimit provides fixed-window rate limiting with pluggable backends
+package ratelimit
+
+import (
+	"context"
+	"time"
+)
+
+// Budget is the number of requests allowed for a key within a window
+type Budget struct {
+	Limit  int
+	Window time.Duration
+}
+
+// Result describes the state of a key after a request has been counted
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	ResetAt   time.Time
+}
+
+// Limiter counts requests against a budget
+type Limiter interface {
+	// Allow records a request for the given key and reports whether it fits within the budget
+	Allow(ctx context.Context, key string, budget Budget) (Result, error)
+}
+
+// windowStart returns the start of the fixed window containing now
+func windowStart(now time.Time, window time.Duration) time.Time {
+	return now.Truncate(window)
+}
+
+// newResult builds a Result from the number of requests seen in the current window
+func newResult(count int, start time.Time, budget Budget) Result {
+	remaining := budget.Limit - count
+	if remaining < 0 {
+		remaining = 0
+	}
+	return Result{
+		Allowed:   count <= budget.Limit,
+		Limit:     budget.Limit,
+		Remaining: remaining,
+		ResetAt:   start.Add(budget.Window),
+	}
+}
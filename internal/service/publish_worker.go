@@ -0,0 +1,187 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/validators/registries"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+const (
+	// publishRequestLease bounds an attempt at a publish request, after which another worker may claim it
+	publishRequestLease = 10 * time.Minute
+	// publishPollInterval is how often idle workers check for publish requests that are due
+	publishPollInterval = 2 * time.Second
+	// publishSweepInterval is how often completed publish requests past their retention are deleted
+	publishSweepInterval = time.Hour
+)
+
+// errIncompleteValidation is the reason for retrying publishes whose validation passed despite transient registry failures
+var errIncompleteValidation = errors.New("package registries failed transiently, so validation was incomplete")
+
+// PublishWorker validates pending publish requests against package registries, then publishes
+// the server version or rejects the request with a reason. Requests that fail transiently, such
+// as when a registry rate limits us, are retried with exponential backoff.
+type PublishWorker struct {
+	service *registryServiceImpl
+}
+
+// NewPublishWorker creates a worker for the publish requests stored in the database
+func NewPublishWorker(db database.Database, cfg *config.Config) *PublishWorker {
+	return &PublishWorker{service: &registryServiceImpl{db: db, cfg: cfg}}
+}
+
+// Run processes publish requests with the configured number of concurrent workers, and deletes
+// completed ones past their retention, until ctx is cancelled
+func (w *PublishWorker) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for range w.service.cfg.PublishValidationWorkers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w.work(ctx)
+		}()
+	}
+	if w.service.cfg.PublishRequestRetention > 0 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w.sweep(ctx)
+		}()
+	}
+	wg.Wait()
+}
+
+// sweep periodically deletes completed publish requests past their retention
+func (w *PublishWorker) sweep(ctx context.Context) {
+	ticker := time.NewTicker(publishSweepInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := w.Sweep(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("Failed to delete completed publish requests: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sweep deletes active and rejected publish requests completed longer than the retention ago, after
+// which they can no longer be polled, and returns how many were deleted
+func (w *PublishWorker) Sweep(ctx context.Context) (int64, error) {
+	deleted, err := w.service.db.DeletePublishRequestsBefore(ctx, nil, time.Now().Add(-w.service.cfg.PublishRequestRetention))
+	if err != nil {
+		return 0, err
+	}
+	if deleted > 0 {
+		log.Printf("Deleted %d completed publish requests", deleted)
+	}
+	return deleted, nil
+}
+
+func (w *PublishWorker) work(ctx context.Context) {
+	for ctx.Err() == nil {
+		processed, err := w.ProcessNext(ctx)
+		if err != nil {
+			log.Printf("Failed to process publish request: %v", err)
+		}
+		if processed && err == nil {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(publishPollInterval):
+		}
+	}
+}
+
+// ProcessNext attempts the pending publish request that has been due the longest, and reports
+// whether there was one
+func (w *PublishWorker) ProcessNext(ctx context.Context) (bool, error) {
+	request, err := w.service.db.ClaimPublishRequest(ctx, nil, publishRequestLease)
+	if errors.Is(err, database.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, w.process(ctx, request)
+}
+
+func (w *PublishWorker) process(ctx context.Context, request *database.PublishRequest) error {
+	attemptCtx, cancel := context.WithTimeout(ctx, publishRequestLease)
+	defer cancel()
+	trackedCtx, failedTransiently := registries.TrackTransientFailures(attemptCtx)
+
+	server := request.Server
+	packageDigests, verified, err := w.service.validatePublish(trackedCtx, &server)
+	if err != nil {
+		if failedTransiently() || attemptCtx.Err() != nil {
+			return w.retry(ctx, request, err)
+		}
+		return w.reject(ctx, request, err)
+	}
+	// Some validators pass packages they could not check because the registry rate limited us
+	if failedTransiently() {
+		return w.retry(ctx, request, errIncompleteValidation)
+	}
+
+	_, err = database.InTransactionT(attemptCtx, w.service.db, func(ctx context.Context, tx pgx.Tx) (*apiv0.ServerResponse, error) {
+		published, err := w.service.createServerInTransaction(ctx, tx, &server, packageDigests, verified)
+		if err != nil {
+			return nil, err
+		}
+		return published, w.service.db.CompletePublishRequest(ctx, tx, request.ID, model.StatusActive, "")
+	})
+	switch {
+	case err == nil:
+		log.Printf("Published server %s version %s", server.Name, server.Version)
+		return nil
+	case errors.Is(err, database.ErrInvalidVersion), errors.Is(err, database.ErrMaxServersReached), errors.Is(err, ErrRemoteURLInUse):
+		return w.reject(ctx, request, err)
+	default:
+		return w.retry(ctx, request, err)
+	}
+}
+
+// retry schedules another attempt of a request that failed transiently, or rejects it once it
+// has used up its attempts. Requests interrupted by shutdown are retried when their lease expires.
+func (w *PublishWorker) retry(ctx context.Context, request *database.PublishRequest, cause error) error {
+	if ctx.Err() != nil {
+		return nil
+	}
+	if request.Attempts >= w.service.cfg.PublishValidationMaxAttempts {
+		return w.reject(ctx, request, cause)
+	}
+
+	retryAt := time.Now().Add(w.service.cfg.PublishValidationRetryBackoff << (request.Attempts - 1))
+	log.Printf("Retrying publish of server %s version %s at %s: %v", request.Server.Name, request.Server.Version, retryAt.Format(time.RFC3339), cause)
+	if err := w.service.db.RetryPublishRequest(ctx, nil, request.ID, retryAt, cause.Error()); err != nil && !errors.Is(err, database.ErrNotFound) {
+		return err
+	}
+	return nil
+}
+
+// reject marks a request as rejected. Requests another worker completed meanwhile are left as they are.
+func (w *PublishWorker) reject(ctx context.Context, request *database.PublishRequest, cause error) error {
+	log.Printf("Rejected publish of server %s version %s: %v", request.Server.Name, request.Server.Version, cause)
+	if err := w.service.db.CompletePublishRequest(ctx, nil, request.ID, model.StatusRejected, cause.Error()); err != nil && !errors.Is(err, database.ErrNotFound) {
+		return err
+	}
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log"
 	"time"
 
 	"github.com/jackc/pgx/v5"
@@ -16,6 +17,9 @@ import (
 
 const maxServerVersionsPerServer = 10000
 
+// ErrRemoteURLInUse is returned when a remote URL is already used by another server
+var ErrRemoteURLInUse = errors.New("duplicate remote URL")
+
 // registryServiceImpl implements the RegistryService interface using our Database
 type registryServiceImpl struct {
 	db  database.Database
@@ -76,21 +80,149 @@ func (s *registryServiceImpl) GetAllVersionsByServerName(ctx context.Context, se
 	return serverRecords, nil
 }
 
-// CreateServer creates a new server version
+// CreateServer creates a new server version. With asynchronous publish validation, the version is
+// accepted as pending instead, and published once a PublishWorker has validated its packages.
 func (s *registryServiceImpl) CreateServer(ctx context.Context, req *apiv0.ServerJSON) (*apiv0.ServerResponse, error) {
+	if s.cfg.AsyncPublishValidation {
+		return s.enqueuePublish(ctx, req)
+	}
+
+	// Validate before opening the transaction, so slow package registries do not hold a connection
+	packageDigests, verified, err := s.validatePublish(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
 	// Wrap the entire operation in a transaction
 	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*apiv0.ServerResponse, error) {
-		return s.createServerInTransaction(ctx, tx, req)
+		return s.createServerInTransaction(ctx, tx, req, packageDigests, verified)
 	})
 }
 
-// createServerInTransaction contains the actual CreateServer logic within a transaction
-func (s *registryServiceImpl) createServerInTransaction(ctx context.Context, tx pgx.Tx, req *apiv0.ServerJSON) (*apiv0.ServerResponse, error) {
-	// Validate the request
-	if err := validators.ValidatePublishRequest(ctx, *req, s.cfg); err != nil {
+// validatePublish validates a publish request, including against package registries, and returns
+// the digests OCI packages resolved to and whether the packages' provenance was verified
+func (s *registryServiceImpl) validatePublish(ctx context.Context, req *apiv0.ServerJSON) (map[string]string, bool, error) {
+	packageDigests, err := validators.ValidatePublishRequest(ctx, *req, s.cfg)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// Provenance is optional: servers whose packages do not verify are published without the badge
+	verified := false
+	if s.cfg.VerifyPackageProvenance {
+		if err := validators.VerifyPackageProvenance(ctx, *req, packageDigests); err != nil {
+			log.Printf("Server %s version %s is not verified: %v", req.Name, req.Version, err)
+		} else {
+			verified = true
+		}
+	}
+
+	return packageDigests, verified, nil
+}
+
+// enqueuePublish accepts a publish request as pending after the checks that do not contact package registries
+func (s *registryServiceImpl) enqueuePublish(ctx context.Context, req *apiv0.ServerJSON) (*apiv0.ServerResponse, error) {
+	if err := validators.ValidatePublishRequestOffline(*req, s.cfg); err != nil {
+		return nil, err
+	}
+
+	versionExists, err := s.db.CheckVersionExists(ctx, nil, req.Name, req.Version)
+	if err != nil {
 		return nil, err
 	}
+	if versionExists {
+		return nil, database.ErrInvalidVersion
+	}
+
+	request, err := s.db.CreatePublishRequest(ctx, nil, req)
+	if err != nil {
+		if errors.Is(err, database.ErrAlreadyExists) {
+			return nil, database.ErrInvalidVersion
+		}
+		return nil, err
+	}
+
+	return &apiv0.ServerResponse{
+		Server: request.Server,
+		Meta: apiv0.ResponseMeta{
+			Official: &apiv0.RegistryExtensions{
+				Status:      model.StatusPending,
+				PublishedAt: request.CreatedAt,
+				UpdatedAt:   request.UpdatedAt,
+				PublishID:   request.ID,
+			},
+		},
+	}, nil
+}
+
+// GetPublishStatus retrieves the outcome of a publish validated asynchronously
+func (s *registryServiceImpl) GetPublishStatus(ctx context.Context, id string) (*apiv0.PublishStatus, error) {
+	request, err := s.db.GetPublishRequest(ctx, nil, id)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &apiv0.PublishStatus{
+		ID:         request.ID,
+		ServerName: request.Server.Name,
+		Version:    request.Server.Version,
+		Status:     request.Status,
+		Reason:     request.Reason,
+		CreatedAt:  request.CreatedAt,
+		UpdatedAt:  request.UpdatedAt,
+	}
+	if request.Status == model.StatusActive {
+		status.Server, err = s.db.GetServerByNameAndVersion(ctx, nil, request.Server.Name, request.Server.Version)
+		if err != nil {
+			return nil, err
+		}
+	}
 
+	return status, nil
+}
+
+// GetRemoteStatus retrieve the liveness of the remotes of a server version, with their latest probes
+func (s *registryServiceImpl) GetRemoteStatus(ctx context.Context, serverName, version string) (*apiv0.RemoteStatusResponse, error) {
+	server, err := s.db.GetServerByNameAndVersion(ctx, nil, serverName, version)
+	if err != nil {
+		return nil, err
+	}
+
+	since := time.Now().Add(-s.cfg.RemoteProbeRetention)
+	remotes, err := s.db.ListRemoteStatus(ctx, nil, serverName, version, since, remoteProbeHistoryLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &apiv0.RemoteStatusResponse{
+		ServerName: server.Server.Name,
+		Version:    server.Server.Version,
+		Remotes:    remotes,
+	}, nil
+}
+
+// GetServerCapabilities retrieve the tools, prompts and resources a server version offers
+func (s *registryServiceImpl) GetServerCapabilities(ctx context.Context, serverName, version string) (*apiv0.ServerCapabilitiesResponse, error) {
+	server, err := s.db.GetServerByNameAndVersion(ctx, nil, serverName, version)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest, source, err := s.db.GetServerCapabilities(ctx, nil, serverName, version)
+	if err != nil {
+		return nil, err
+	}
+
+	return &apiv0.ServerCapabilitiesResponse{
+		ServerName:   server.Server.Name,
+		Version:      server.Server.Version,
+		Source:       source,
+		Capabilities: *manifest,
+	}, nil
+}
+
+// createServerInTransaction contains the actual CreateServer logic within a transaction
+func (s *registryServiceImpl) createServerInTransaction(ctx context.Context, tx pgx.Tx, req *apiv0.ServerJSON, packageDigests map[string]string, verified bool) (*apiv0.ServerResponse, error) {
 	publishTime := time.Now()
 	serverJSON := *req
 
@@ -152,14 +284,39 @@ func (s *registryServiceImpl) createServerInTransaction(ctx context.Context, tx
 
 	// Create metadata for the new server
 	officialMeta := &apiv0.RegistryExtensions{
-		Status:      model.StatusActive, /* New versions are active by default */
-		PublishedAt: publishTime,
-		UpdatedAt:   publishTime,
-		IsLatest:    isNewLatest,
+		Status:          model.StatusActive, /* New versions are active by default */
+		PublishedAt:     publishTime,
+		UpdatedAt:       publishTime,
+		IsLatest:        isNewLatest,
+		Verified:        verified,
+		ValidationRules: validators.CurrentValidationRules,
+	}
+	if len(packageDigests) > 0 {
+		officialMeta.PackageDigests = packageDigests
 	}
 
 	// Insert new server version
-	return s.db.CreateServer(ctx, tx, &serverJSON, officialMeta)
+	created, err := s.db.CreateServer(ctx, tx, &serverJSON, officialMeta)
+	if err != nil {
+		return nil, err
+	}
+
+	// Store the capabilities the publisher supplied, so that servers can be searched by them
+	if manifest := capabilityManifest(serverJSON); manifest != nil {
+		if err := s.db.SetServerCapabilities(ctx, tx, serverJSON.Name, serverJSON.Version, manifest, apiv0.CapabilitySourcePublisher); err != nil {
+			return nil, err
+		}
+	}
+
+	return created, nil
+}
+
+// capabilityManifest returns the capability manifest the publisher supplied in _meta, if any
+func capabilityManifest(serverJSON apiv0.ServerJSON) *apiv0.CapabilityManifest {
+	if serverJSON.Meta == nil {
+		return nil
+	}
+	return serverJSON.Meta.Capabilities
 }
 
 // validateNoDuplicateRemoteURLs checks that no other server is using the same remote URLs
@@ -177,7 +334,7 @@ func (s *registryServiceImpl) validateNoDuplicateRemoteURLs(ctx context.Context,
 		// Check if any conflicting server has a different name
 		for _, conflictingServer := range conflictingServers {
 			if conflictingServer.Server.Name != serverDetail.Name {
-				return fmt.Errorf("remote URL %s is already used by server %s", remote.URL, conflictingServer.Server.Name)
+				return fmt.Errorf("%w: remote URL %s is already used by server %s", ErrRemoteURLInUse, remote.URL, conflictingServer.Server.Name)
 			}
 		}
 	}
@@ -232,6 +389,11 @@ func (s *registryServiceImpl) updateServerInTransaction(ctx context.Context, tx
 		return nil, err
 	}
 
+	// Replace the capabilities the publisher supplied, or remove them if the manifest was dropped
+	if err := s.db.SetServerCapabilities(ctx, tx, serverName, version, capabilityManifest(updatedServer), apiv0.CapabilitySourcePublisher); err != nil {
+		return nil, err
+	}
+
 	// Handle status change if provided
 	if newStatus != nil {
 		updatedWithStatus, err := s.db.SetServerStatus(ctx, tx, serverName, version, *newStatus)
@@ -258,7 +420,7 @@ func (s *registryServiceImpl) validateUpdateRequest(ctx context.Context, req api
 
 	// Perform registry validation for all packages
 	for i, pkg := range req.Packages {
-		if err := validators.ValidatePackage(ctx, pkg, req.Name); err != nil {
+		if err := validators.ValidatePackage(ctx, pkg, req.Name, req.Version); err != nil {
 			return fmt.Errorf("registry validation failed for package %d (%s): %w", i, pkg.Identifier, err)
 		}
 	}
@@ -3,13 +3,20 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/modelcontextprotocol/registry/internal/config"
 	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/validators"
+	"github.com/modelcontextprotocol/registry/internal/validators/registries"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
 	"github.com/modelcontextprotocol/registry/pkg/model"
 	"github.com/stretchr/testify/assert"
@@ -786,6 +793,348 @@ func TestVersionComparison(t *testing.T) {
 	assert.Equal(t, 1, latestCount, "Exactly one version should be marked as latest")
 }
 
+func TestCreateServer_AsyncPublishValidation(t *testing.T) {
+	ctx := context.Background()
+	testDB := database.NewTestDB(t)
+	cfg := &config.Config{
+		AsyncPublishValidation:        true,
+		PublishValidationWorkers:      1,
+		PublishValidationMaxAttempts:  3,
+		PublishValidationRetryBackoff: time.Minute,
+	}
+	service := NewRegistryService(testDB, cfg)
+	worker := NewPublishWorker(testDB, cfg)
+
+	serverJSON := func(version string) *apiv0.ServerJSON {
+		return &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        "com.example/async-server",
+			Description: "A server validated in the background",
+			Version:     version,
+		}
+	}
+
+	t.Run("publish is pending until validated", func(t *testing.T) {
+		pending, err := service.CreateServer(ctx, serverJSON("1.0.0"))
+		require.NoError(t, err)
+		assert.Equal(t, model.StatusPending, pending.Meta.Official.Status)
+		require.NotEmpty(t, pending.Meta.Official.PublishID)
+
+		_, err = service.GetServerByNameAndVersion(ctx, "com.example/async-server", "1.0.0")
+		assert.ErrorIs(t, err, database.ErrNotFound)
+
+		_, err = service.CreateServer(ctx, serverJSON("1.0.0"))
+		assert.ErrorIs(t, err, database.ErrInvalidVersion, "the version already has a pending publish")
+
+		processed, err := worker.ProcessNext(ctx)
+		require.NoError(t, err)
+		assert.True(t, processed)
+
+		status, err := service.GetPublishStatus(ctx, pending.Meta.Official.PublishID)
+		require.NoError(t, err)
+		assert.Equal(t, model.StatusActive, status.Status)
+		require.NotNil(t, status.Server)
+		assert.Equal(t, model.StatusActive, status.Server.Meta.Official.Status)
+		assert.True(t, status.Server.Meta.Official.IsLatest)
+
+		processed, err = worker.ProcessNext(ctx)
+		require.NoError(t, err)
+		assert.False(t, processed)
+	})
+
+	t.Run("publish is rejected with a reason", func(t *testing.T) {
+		pending, err := service.CreateServer(ctx, serverJSON("2.0.0"))
+		require.NoError(t, err)
+
+		// Another replica publishes the same version synchronously meanwhile
+		_, err = NewRegistryService(testDB, &config.Config{}).CreateServer(ctx, serverJSON("2.0.0"))
+		require.NoError(t, err)
+
+		processed, err := worker.ProcessNext(ctx)
+		require.NoError(t, err)
+		assert.True(t, processed)
+
+		status, err := service.GetPublishStatus(ctx, pending.Meta.Official.PublishID)
+		require.NoError(t, err)
+		assert.Equal(t, model.StatusRejected, status.Status)
+		assert.Contains(t, status.Reason, "cannot publish duplicate version")
+		assert.Nil(t, status.Server)
+	})
+
+	t.Run("publish validated despite rate limiting is retried", func(t *testing.T) {
+		// OCI validation passes images it could not fetch because the registry rate limited us
+		registry := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		}))
+		t.Cleanup(registry.Close)
+		client, err := registries.NewHTTPClient(registries.HTTPClientConfig{Timeout: 5 * time.Second})
+		require.NoError(t, err)
+		validators.RegisterPackageValidator(model.RegistryTypeOCI, registries.NewOCIValidator(registries.Config{
+			Client:            client,
+			PrivateRegistries: []registries.PrivateRegistry{{RegistryType: model.RegistryTypeOCI, BaseURL: registry.URL}},
+		}))
+		t.Cleanup(func() {
+			validators.RegisterPackageValidator(model.RegistryTypeOCI, registries.NewOCIValidator(registries.Config{}))
+		})
+
+		validatingCfg := *cfg
+		validatingCfg.EnableRegistryValidation = true
+		oci := serverJSON("4.0.0")
+		oci.Packages = []model.Package{{
+			RegistryType: model.RegistryTypeOCI,
+			Identifier:   strings.TrimPrefix(registry.URL, "http://") + "/acme/weather:1.0.0@sha256:" + strings.Repeat("a", 64),
+			Transport:    model.Transport{Type: "stdio"},
+		}}
+		pending, err := NewRegistryService(testDB, &validatingCfg).CreateServer(ctx, oci)
+		require.NoError(t, err)
+
+		processed, err := NewPublishWorker(testDB, &validatingCfg).ProcessNext(ctx)
+		require.NoError(t, err)
+		assert.True(t, processed)
+
+		status, err := service.GetPublishStatus(ctx, pending.Meta.Official.PublishID)
+		require.NoError(t, err)
+		assert.Equal(t, model.StatusPending, status.Status)
+		assert.Contains(t, status.Reason, "validation was incomplete")
+	})
+
+	t.Run("invalid server is rejected before it is pending", func(t *testing.T) {
+		invalid := serverJSON("3.0.0")
+		invalid.Remotes = []model.Transport{{Type: "stdio", URL: "https://example.com/mcp"}}
+		_, err := service.CreateServer(ctx, invalid)
+		assert.ErrorContains(t, err, "unsupported transport type for remotes")
+	})
+
+	t.Run("unknown publish", func(t *testing.T) {
+		_, err := service.GetPublishStatus(ctx, "not-a-publish-id")
+		assert.ErrorIs(t, err, database.ErrNotFound)
+	})
+}
+
+func TestPackageRevalidator(t *testing.T) {
+	ctx := context.Background()
+	testDB := database.NewTestDB(t)
+	cfg := &config.Config{PackageRevalidationFlagAfter: 2}
+	service := NewRegistryService(testDB, cfg)
+
+	// npm stand-in whose package can be unpublished and rate limited
+	var state atomic.Value
+	state.Store("published")
+	npm := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		switch state.Load() {
+		case "unpublished":
+			w.WriteHeader(http.StatusNotFound)
+		case "rate-limited":
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			_ = json.NewEncoder(w).Encode(map[string]string{"mcpName": "com.example/revalidated-server"})
+		}
+	}))
+	t.Cleanup(npm.Close)
+	client, err := registries.NewHTTPClient(registries.HTTPClientConfig{Timeout: 5 * time.Second})
+	require.NoError(t, err)
+	validators.RegisterPackageValidator(model.RegistryTypeNPM, registries.NewNPMValidator(registries.Config{BaseURL: npm.URL, Client: client}))
+	t.Cleanup(func() {
+		validators.RegisterPackageValidator(model.RegistryTypeNPM, registries.NewNPMValidator(registries.Config{}))
+	})
+
+	_, err = service.CreateServer(ctx, &apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "com.example/revalidated-server",
+		Description: "A server whose package is re-validated",
+		Version:     "1.0.0",
+		Packages: []model.Package{{
+			RegistryType: model.RegistryTypeNPM,
+			Identifier:   "revalidated-server",
+			Version:      "1.0.0",
+			Transport:    model.Transport{Type: model.TransportTypeStdio},
+		}},
+	})
+	require.NoError(t, err)
+
+	revalidator := NewPackageRevalidator(testDB, cfg)
+	health := func() *apiv0.PackageHealth {
+		server, err := service.GetServerByNameAndVersion(ctx, "com.example/revalidated-server", "1.0.0")
+		require.NoError(t, err)
+		return server.Meta.Official.Health
+	}
+
+	t.Run("passing packages are recorded as healthy", func(t *testing.T) {
+		require.NoError(t, revalidator.RevalidateAll(ctx))
+		require.NotNil(t, health())
+		assert.Empty(t, health().LastError)
+	})
+
+	t.Run("transient failures are not recorded", func(t *testing.T) {
+		checkedAt := health().LastCheckedAt
+		state.Store("rate-limited")
+		require.NoError(t, revalidator.RevalidateAll(ctx))
+		assert.Equal(t, checkedAt, health().LastCheckedAt)
+	})
+
+	t.Run("repeatedly failing packages are flagged for review", func(t *testing.T) {
+		state.Store("unpublished")
+		require.NoError(t, revalidator.RevalidateAll(ctx))
+		assert.Contains(t, health().LastError, "not found")
+		assert.False(t, health().FlaggedForReview)
+
+		require.NoError(t, revalidator.RevalidateAll(ctx))
+		assert.True(t, health().FlaggedForReview)
+	})
+
+	t.Run("republished packages clear the flag", func(t *testing.T) {
+		state.Store("published")
+		require.NoError(t, revalidator.RevalidateAll(ctx))
+		assert.Empty(t, health().LastError)
+		assert.False(t, health().FlaggedForReview)
+	})
+
+	t.Run("cached validations are not reused", func(t *testing.T) {
+		validators.SetValidationCache(time.Hour, time.Hour)
+		t.Cleanup(func() { validators.SetValidationCache(0, 0) })
+
+		pkg := model.Package{RegistryType: model.RegistryTypeNPM, Identifier: "revalidated-server", Version: "1.0.0"}
+		require.NoError(t, validators.ValidatePackage(ctx, pkg, "com.example/revalidated-server", "1.0.0"))
+
+		state.Store("unpublished")
+		t.Cleanup(func() { state.Store("published") })
+		require.NoError(t, revalidator.RevalidateAll(ctx))
+		assert.Contains(t, health().LastError, "not found")
+	})
+}
+
+func TestRemoteProber(t *testing.T) {
+	ctx := context.Background()
+	testDB := database.NewTestDB(t)
+	cfg := &config.Config{RemoteProbeTimeout: 5 * time.Second, RemoteProbeRetention: time.Hour, RemoteProbeAllowPrivateNetworks: true}
+	service := NewRegistryService(testDB, cfg)
+
+	// In-process MCP server offering a tool over streamable HTTP, next to a broken remote
+	mcp := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/mcp" {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		var req struct {
+			ID     *int   `json:"id"`
+			Method string `json:"method"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.ID == nil {
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch req.Method {
+		case "initialize":
+			_, _ = fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%d,"result":{"protocolVersion":"2025-06-18","capabilities":{"tools":{}},"serverInfo":{"name":"fake","version":"1.0.0"}}}`, *req.ID)
+		case "tools/list":
+			_, _ = fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%d,"result":{"tools":[{"name":"get_weather","inputSchema":{"type":"object"}}]}}`, *req.ID)
+		default:
+			_, _ = fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%d,"error":{"code":-32601,"message":"method not found"}}`, *req.ID)
+		}
+	}))
+	t.Cleanup(mcp.Close)
+
+	// Remotes on localhost do not pass publish validation, so the server is stored directly
+	_, err := testDB.CreateServer(ctx, nil, &apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "com.example/probed-server",
+		Description: "A server whose remotes are probed",
+		Version:     "1.0.0",
+		Remotes: []model.Transport{
+			{Type: model.TransportTypeStreamableHTTP, URL: mcp.URL + "/mcp"},
+			{Type: model.TransportTypeStreamableHTTP, URL: mcp.URL + "/down"},
+		},
+	}, &apiv0.RegistryExtensions{
+		Status:      model.StatusActive,
+		PublishedAt: time.Now(),
+		UpdatedAt:   time.Now(),
+		IsLatest:    true,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, NewRemoteProber(testDB, cfg).ProbeAll(ctx))
+
+	server, err := service.GetServerByNameAndVersion(ctx, "com.example/probed-server", "1.0.0")
+	require.NoError(t, err)
+	require.Len(t, server.Meta.Official.RemoteStatus, 2)
+
+	down, live := server.Meta.Official.RemoteStatus[0], server.Meta.Official.RemoteStatus[1]
+	assert.False(t, down.Live)
+	assert.Equal(t, "unexpected HTTP status", down.LastError)
+	assert.Zero(t, down.Uptime)
+	assert.True(t, live.Live)
+	assert.Equal(t, "2025-06-18", live.ProtocolVersion)
+	assert.Contains(t, live.Capabilities, "tools")
+	assert.InDelta(t, 1.0, live.Uptime, 0.001)
+
+	status, err := service.GetRemoteStatus(ctx, "com.example/probed-server", "1.0.0")
+	require.NoError(t, err)
+	require.Len(t, status.Remotes, 2)
+	assert.Len(t, status.Remotes[1].History, 1)
+
+	capabilities, err := service.GetServerCapabilities(ctx, "com.example/probed-server", "1.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, apiv0.CapabilitySourceProbe, capabilities.Source)
+	assert.Equal(t, []apiv0.ToolCapability{{Name: "get_weather"}}, capabilities.Capabilities.Tools)
+
+	_, err = service.GetRemoteStatus(ctx, "com.example/missing-server", "1.0.0")
+	assert.ErrorIs(t, err, database.ErrNotFound)
+}
+
+func TestServerCapabilities(t *testing.T) {
+	ctx := context.Background()
+	testDB := database.NewTestDB(t)
+	service := NewRegistryService(testDB, &config.Config{EnableRegistryValidation: false})
+
+	serverJSON := apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "com.example/capable-server",
+		Description: "A server declaring its capabilities",
+		Version:     "1.0.0",
+		Meta: &apiv0.ServerMeta{
+			Capabilities: &apiv0.CapabilityManifest{
+				Tools:     []apiv0.ToolCapability{{Name: "search_issues", Description: "Search issues"}},
+				Resources: []apiv0.ResourceCapability{{URI: "repo://issues"}},
+			},
+		},
+	}
+	_, err := service.CreateServer(ctx, &serverJSON)
+	require.NoError(t, err)
+
+	capabilities, err := service.GetServerCapabilities(ctx, serverJSON.Name, "1.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, apiv0.CapabilitySourcePublisher, capabilities.Source)
+	assert.Equal(t, *serverJSON.Meta.Capabilities, capabilities.Capabilities)
+
+	servers, _, err := service.ListServers(ctx, &database.ServerFilter{ToolName: stringPtr("search")}, "", 10)
+	require.NoError(t, err)
+	require.Len(t, servers, 1)
+	assert.Equal(t, serverJSON.Name, servers[0].Server.Name)
+
+	// Updating the server without a manifest drops the declared capabilities
+	serverJSON.Meta = nil
+	_, err = service.UpdateServer(ctx, serverJSON.Name, "1.0.0", &serverJSON, nil)
+	require.NoError(t, err)
+
+	capabilities, err = service.GetServerCapabilities(ctx, serverJSON.Name, "1.0.0")
+	require.NoError(t, err)
+	assert.Empty(t, capabilities.Source)
+	assert.Empty(t, capabilities.Capabilities.Tools)
+
+	_, err = service.GetServerCapabilities(ctx, "com.example/missing-server", "1.0.0")
+	assert.ErrorIs(t, err, database.ErrNotFound)
+
+	t.Run("invalid manifests are rejected", func(t *testing.T) {
+		invalid := serverJSON
+		invalid.Version = "1.0.1"
+		invalid.Meta = &apiv0.ServerMeta{Capabilities: &apiv0.CapabilityManifest{Tools: []apiv0.ToolCapability{{Name: "dup"}, {Name: "dup"}}}}
+		_, err := service.CreateServer(ctx, &invalid)
+		assert.ErrorIs(t, err, validators.ErrInvalidCapabilityManifest)
+	})
+}
+
 // Helper functions
 func stringPtr(s string) *string {
 	return &s
@@ -0,0 +1,152 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/prober"
+	"github.com/modelcontextprotocol/registry/internal/validators"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// remoteProbeHistoryLimit is how many of the latest probes of each remote the status endpoint returns
+const remoteProbeHistoryLimit = 100
+
+// RemoteProber periodically probes the remotes of active latest server versions with an MCP
+// initialize handshake. The liveness, protocol version and capabilities of each remote, and its
+// uptime over the retained probes, are recorded as remote status in the official metadata. The
+// tools, prompts and resources listed by a live remote are stored as the capabilities of the
+// server version, unless its publisher supplied them.
+type RemoteProber struct {
+	db     database.Database
+	cfg    *config.Config
+	prober *prober.Prober
+}
+
+// NewRemoteProber creates a prober for the remotes of the servers stored in the database
+func NewRemoteProber(db database.Database, cfg *config.Config) *RemoteProber {
+	return &RemoteProber{db: db, cfg: cfg, prober: prober.New(cfg.RemoteProbeTimeout, cfg.RemoteProbeAllowPrivateNetworks)}
+}
+
+// Run probes remotes every configured interval until ctx is cancelled
+func (r *RemoteProber) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.cfg.RemoteProbeInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if err := r.ProbeAll(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("Failed to probe remotes: %v", err)
+		}
+	}
+}
+
+// ProbeAll probes the remotes of every active latest server version once
+func (r *RemoteProber) ProbeAll(ctx context.Context) error {
+	isLatest := true
+	filter := &database.ServerFilter{IsLatest: &isLatest}
+
+	probed, down := 0, 0
+	cursor := ""
+	for {
+		servers, nextCursor, err := r.db.ListServers(ctx, nil, filter, cursor, revalidationPageSize)
+		if err != nil {
+			return err
+		}
+
+		for _, server := range servers {
+			if server.Meta.Official == nil || server.Meta.Official.Status != model.StatusActive || len(server.Server.Remotes) == 0 {
+				continue
+			}
+
+			probes := make([]database.RemoteProbe, 0, len(server.Server.Remotes))
+			var manifest *apiv0.CapabilityManifest
+			for _, remote := range server.Server.Remotes {
+				probe, harvested := r.probe(ctx, remote)
+				probes = append(probes, probe)
+				if manifest == nil {
+					manifest = harvested
+				}
+			}
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			since := time.Now().Add(-r.cfg.RemoteProbeRetention)
+			err := r.db.InTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+				if _, err := r.db.RecordRemoteProbes(ctx, tx, server.Server.Name, server.Server.Version, probes, since); err != nil {
+					return err
+				}
+				if manifest == nil {
+					return nil
+				}
+				return r.db.SetServerCapabilities(ctx, tx, server.Server.Name, server.Server.Version, manifest, apiv0.CapabilitySourceProbe)
+			})
+			if err != nil {
+				return err
+			}
+
+			for _, probe := range probes {
+				probed++
+				if !probe.Live {
+					down++
+					log.Printf("Remote %s of server %s version %s is down: %s", probe.URL, server.Server.Name, server.Server.Version, probe.Error)
+				}
+			}
+		}
+
+		if nextCursor == "" {
+			break
+		}
+		cursor = nextCursor
+	}
+
+	log.Printf("Probed %d remotes, %d down", probed, down)
+	return nil
+}
+
+// probe probes a remote, returning the capability manifest harvested from it if it is valid.
+// Remotes requiring authorization are reachable, so they count as live.
+func (r *RemoteProber) probe(ctx context.Context, remote model.Transport) (database.RemoteProbe, *apiv0.CapabilityManifest) {
+	checkedAt := time.Now()
+	result, err := r.prober.Probe(ctx, remote)
+	probe := database.RemoteProbe{
+		URL:       remote.URL,
+		CheckedAt: checkedAt,
+		Latency:   time.Since(checkedAt),
+	}
+
+	switch {
+	case err == nil:
+		probe.Live = true
+		probe.ProtocolVersion = result.ProtocolVersion
+		probe.Capabilities = result.Capabilities
+	case errors.Is(err, prober.ErrAuthRequired):
+		probe.Live = true
+		probe.AuthRequired = true
+	default:
+		// Only a coarse category is recorded, as probe errors may reveal details of the remote
+		log.Printf("Probe of remote %s failed: %v", remote.URL, err)
+		probe.Error = prober.ErrorCategory(err)
+	}
+
+	if result == nil || result.Manifest == nil {
+		return probe, nil
+	}
+	if err := validators.ValidateCapabilityManifest(result.Manifest); err != nil {
+		log.Printf("Ignored capabilities harvested from remote %s: %v", remote.URL, err)
+		return probe, nil
+	}
+	return probe, result.Manifest
+}
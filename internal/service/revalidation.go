@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/validators"
+	"github.com/modelcontextprotocol/registry/internal/validators/registries"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// revalidationPageSize is how many servers are listed at a time while re-validating
+const revalidationPageSize = 100
+
+// PackageRevalidator periodically re-validates the packages of active latest server versions, so
+// that packages which disappeared or no longer name their server after publishing are noticed.
+// Outcomes are recorded as the health in the official metadata, and servers failing repeatedly
+// are flagged for admin review.
+type PackageRevalidator struct {
+	db  database.Database
+	cfg *config.Config
+}
+
+// NewPackageRevalidator creates a re-validator for the servers stored in the database
+func NewPackageRevalidator(db database.Database, cfg *config.Config) *PackageRevalidator {
+	return &PackageRevalidator{db: db, cfg: cfg}
+}
+
+// Run re-validates packages every configured interval until ctx is cancelled
+func (r *PackageRevalidator) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.cfg.PackageRevalidationInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if err := r.RevalidateAll(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("Failed to re-validate packages: %v", err)
+		}
+	}
+}
+
+// RevalidateAll re-validates the packages of every active latest server version once
+func (r *PackageRevalidator) RevalidateAll(ctx context.Context) error {
+	isLatest := true
+	filter := &database.ServerFilter{IsLatest: &isLatest}
+
+	checked, failing := 0, 0
+	cursor := ""
+	for {
+		servers, nextCursor, err := r.db.ListServers(ctx, nil, filter, cursor, revalidationPageSize)
+		if err != nil {
+			return err
+		}
+
+		for _, server := range servers {
+			if server.Meta.Official == nil || server.Meta.Official.Status != model.StatusActive || len(server.Server.Packages) == 0 {
+				continue
+			}
+
+			// Transient registry failures and disabled registry types say nothing about the packages,
+			// even if validation passed because checks were skipped after a failure
+			trackedCtx, failedTransiently := registries.TrackTransientFailures(ctx)
+			checkErr := checkPackages(trackedCtx, server)
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if failedTransiently() {
+				log.Printf("Skipped re-validation of server %s version %s after a transient registry failure", server.Server.Name, server.Server.Version)
+				continue
+			}
+			if errors.Is(checkErr, validators.ErrRegistryTypeDisabled) {
+				log.Printf("Skipped re-validation of server %s version %s: %v", server.Server.Name, server.Server.Version, checkErr)
+				continue
+			}
+
+			health, err := r.db.RecordPackageHealth(ctx, nil, server.Server.Name, server.Server.Version, checkErr, r.cfg.PackageRevalidationFlagAfter)
+			if err != nil {
+				return err
+			}
+			checked++
+			if checkErr != nil {
+				failing++
+				log.Printf("Server %s version %s failed re-validation (flagged for review: %t): %v", server.Server.Name, server.Server.Version, health.FlaggedForReview, checkErr)
+			}
+		}
+
+		if nextCursor == "" {
+			break
+		}
+		cursor = nextCursor
+	}
+
+	log.Printf("Re-validated packages of %d servers, %d failing", checked, failing)
+	return nil
+}
+
+// checkPackages validates the packages of a server version as at publish time, under the rules it
+// was published under. OCI packages are validated at the digests their references resolved to
+// when published.
+func checkPackages(ctx context.Context, server *apiv0.ServerResponse) error {
+	for i, pkg := range server.Server.Packages {
+		if digest, ok := server.Meta.Official.PackageDigests[pkg.Identifier]; ok && !strings.Contains(pkg.Identifier, "@") {
+			pkg.Identifier += "@" + digest
+		}
+		if err := validators.RevalidatePackage(ctx, pkg, server.Server.Name, server.Server.Version, server.Meta.Official.ValidationRules); err != nil {
+			return fmt.Errorf("package %d (%s): %w", i, server.Server.Packages[i].Identifier, err)
+		}
+	}
+	return nil
+}
@@ -19,6 +19,12 @@ type RegistryService interface {
 	GetAllVersionsByServerName(ctx context.Context, serverName string) ([]*apiv0.ServerResponse, error)
 	// CreateServer creates a new server version
 	CreateServer(ctx context.Context, req *apiv0.ServerJSON) (*apiv0.ServerResponse, error)
+	// GetPublishStatus retrieve the outcome of a publish validated asynchronously
+	GetPublishStatus(ctx context.Context, id string) (*apiv0.PublishStatus, error)
+	// GetRemoteStatus retrieve the liveness of the remotes of a server version, as probed by the registry
+	GetRemoteStatus(ctx context.Context, serverName, version string) (*apiv0.RemoteStatusResponse, error)
+	// GetServerCapabilities retrieve the tools, prompts and resources a server version offers
+	GetServerCapabilities(ctx context.Context, serverName, version string) (*apiv0.ServerCapabilitiesResponse, error)
 	// UpdateServer updates an existing server and optionally its status
 	UpdateServer(ctx context.Context, serverName, version string, req *apiv0.ServerJSON, newStatus *string) (*apiv0.ServerResponse, error)
 }
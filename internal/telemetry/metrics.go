@@ -32,6 +32,18 @@ type Metrics struct {
 
 	// Up tracks the health of the service
 	Up metric.Int64Gauge
+
+	// RateLimited tracks the number of requests rejected by the rate limiter
+	RateLimited metric.Int64Counter
+
+	// PackageRegistryRequests tracks the number of requests made to package registries during validation
+	PackageRegistryRequests metric.Int64Counter
+
+	// PackageRegistryRateLimited tracks the number of package registry requests that were rate limited
+	PackageRegistryRateLimited metric.Int64Counter
+
+	// PackageValidationCache tracks package validations served from or missing the validation cache
+	PackageValidationCache metric.Int64Counter
 }
 
 // ShutdownFunc is a delegate that shuts down the OpenTelemetry components.
@@ -73,11 +85,47 @@ func NewMetrics(meter metric.Meter) (*Metrics, error) {
 		return nil, fmt.Errorf("failed to create service up gauge: %w", err)
 	}
 
+	rateLimited, err := meter.Int64Counter(
+		Namespace+".http.rate_limited",
+		metric.WithDescription("Total number of HTTP requests rejected by the rate limiter"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create rate limited counter: %w", err)
+	}
+
+	registryRequests, err := meter.Int64Counter(
+		Namespace+".package_registry.requests",
+		metric.WithDescription("Total number of requests made to package registries during validation"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create package registry request counter: %w", err)
+	}
+
+	registryRateLimited, err := meter.Int64Counter(
+		Namespace+".package_registry.rate_limited",
+		metric.WithDescription("Total number of package registry requests that were rate limited"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create package registry rate limited counter: %w", err)
+	}
+
+	validationCache, err := meter.Int64Counter(
+		Namespace+".package_validation.cache",
+		metric.WithDescription("Total number of package validation cache lookups, by result (hit or miss)"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create package validation cache counter: %w", err)
+	}
+
 	return &Metrics{
-		Requests:        req,
-		RequestDuration: reqDuration,
-		ErrorCount:      errCount,
-		Up:              up,
+		Requests:                   req,
+		RequestDuration:            reqDuration,
+		ErrorCount:                 errCount,
+		Up:                         up,
+		RateLimited:                rateLimited,
+		PackageRegistryRequests:    registryRequests,
+		PackageRegistryRateLimited: registryRateLimited,
+		PackageValidationCache:     validationCache,
 	}, nil
 }
 
@@ -37,6 +37,9 @@ func TestNewMetrics(t *testing.T) {
 			assert.NoError(t, err)
 			assert.NotNil(t, metrics)
 			assert.NotNil(t, metrics.Requests)
+			assert.NotNil(t, metrics.PackageRegistryRequests)
+			assert.NotNil(t, metrics.PackageRegistryRateLimited)
+			assert.NotNil(t, metrics.PackageValidationCache)
 		})
 	}
 }
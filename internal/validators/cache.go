@@ -0,0 +1,153 @@
+package validators
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/modelcontextprotocol/registry/internal/telemetry"
+	"github.com/modelcontextprotocol/registry/internal/validators/registries"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// maxValidationCacheEntries bounds the memory held by the validation cache
+const maxValidationCacheEntries = 10000
+
+// validationCacheKey identifies a package validation. Besides the package's registry type,
+// identifier and version, it holds every other input the result depends on.
+type validationCacheKey struct {
+	registryType    string
+	registryBaseURL string
+	identifier      string
+	version         string
+	fileSHA256      string
+	serverName      string
+	serverVersion   string
+}
+
+type validationCacheEntry struct {
+	err     error
+	expires time.Time
+}
+
+// validationCache caches package validation results across requests, so that publishes, updates and
+// imports referencing the same package version do not fetch it from its registry again
+type validationCache struct {
+	mu          sync.Mutex
+	ttl         time.Duration
+	negativeTTL time.Duration
+	entries     map[validationCacheKey]validationCacheEntry
+	metrics     *telemetry.Metrics
+}
+
+var packageValidationCache = &validationCache{entries: map[validationCacheKey]validationCacheEntry{}}
+
+// SetValidationCache sets how long successful and failed package validations are cached, and clears
+// the cache. A TTL of zero disables caching of the respective results.
+func SetValidationCache(ttl, negativeTTL time.Duration) {
+	packageValidationCache.mu.Lock()
+	defer packageValidationCache.mu.Unlock()
+	packageValidationCache.ttl = ttl
+	packageValidationCache.negativeTTL = negativeTTL
+	packageValidationCache.entries = map[validationCacheKey]validationCacheEntry{}
+}
+
+// setValidationCacheMetrics sets the metrics that validation cache lookups are recorded in. Nil
+// disables recording.
+func setValidationCacheMetrics(metrics *telemetry.Metrics) {
+	packageValidationCache.mu.Lock()
+	defer packageValidationCache.mu.Unlock()
+	packageValidationCache.metrics = metrics
+}
+
+// clearValidationCache drops all cached validation results
+func clearValidationCache() {
+	packageValidationCache.mu.Lock()
+	defer packageValidationCache.mu.Unlock()
+	packageValidationCache.entries = map[validationCacheKey]validationCacheEntry{}
+}
+
+func newValidationCacheKey(pkg model.Package, serverName, serverVersion string) validationCacheKey {
+	return validationCacheKey{
+		registryType:    pkg.RegistryType,
+		registryBaseURL: pkg.RegistryBaseURL,
+		identifier:      pkg.Identifier,
+		version:         pkg.Version,
+		fileSHA256:      pkg.FileSHA256,
+		serverName:      serverName,
+		serverVersion:   serverVersion,
+	}
+}
+
+// validate returns the cached result for the package, or validates it and caches the result.
+// Results obtained despite network errors, rate limiting or unavailable registries are not cached,
+// including validations that were skipped because of them.
+func (c *validationCache) validate(ctx context.Context, validator PackageValidator, pkg model.Package, serverName, serverVersion string) error {
+	key := newValidationCacheKey(pkg, serverName, serverVersion)
+	now := time.Now()
+
+	c.mu.Lock()
+	enabled := c.ttl > 0 || c.negativeTTL > 0
+	entry, hit := c.entries[key]
+	hit = hit && now.Before(entry.expires)
+	metrics := c.metrics
+	c.mu.Unlock()
+
+	if !enabled {
+		return validator.ValidatePackage(ctx, pkg, serverName, serverVersion)
+	}
+
+	if metrics != nil {
+		result := "miss"
+		if hit {
+			result = "hit"
+		}
+		metrics.PackageValidationCache.Add(ctx, 1, metric.WithAttributes(
+			attribute.String("registry_type", pkg.RegistryType),
+			attribute.String("result", result),
+		))
+	}
+	if hit {
+		return entry.err
+	}
+
+	trackedCtx, failedTransiently := registries.TrackTransientFailures(ctx)
+	err := validator.ValidatePackage(trackedCtx, pkg, serverName, serverVersion)
+	if failedTransiently() || ctx.Err() != nil {
+		return err
+	}
+
+	ttl := c.ttl
+	if err != nil {
+		ttl = c.negativeTTL
+	}
+	if ttl > 0 {
+		c.store(key, validationCacheEntry{err: err, expires: time.Now().Add(ttl)})
+	}
+	return err
+}
+
+// store caches an entry, evicting expired entries and then arbitrary ones when the cache is full
+func (c *validationCache) store(key validationCacheKey, entry validationCacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.entries) >= maxValidationCacheEntries {
+		now := time.Now()
+		for k, e := range c.entries {
+			if !now.Before(e.expires) {
+				delete(c.entries, k)
+			}
+		}
+		for k := range c.entries {
+			if len(c.entries) < maxValidationCacheEntries {
+				break
+			}
+			delete(c.entries, k)
+		}
+	}
+	c.entries[key] = entry
+}
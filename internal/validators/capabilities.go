@@ -0,0 +1,72 @@
+package validators
+
+import (
+	"fmt"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+const (
+	// maxCapabilitiesPerKind bounds the tools, prompts and resources of a manifest each
+	maxCapabilitiesPerKind         = 1000
+	maxCapabilityNameLength        = 128
+	maxCapabilityDescriptionLength = 1024
+	maxResourceURILength           = 2048
+)
+
+// ValidateCapabilityManifest checks that the tools, prompts and resources of a manifest are named
+// uniquely, and bounds the size of the manifest so that it stays cheap to store and search
+func ValidateCapabilityManifest(manifest *apiv0.CapabilityManifest) error {
+	tools := make([]capability, len(manifest.Tools))
+	for i, tool := range manifest.Tools {
+		tools[i] = capability{key: tool.Name, name: tool.Name, description: tool.Description}
+	}
+	if err := validateCapabilities("tool", tools); err != nil {
+		return err
+	}
+
+	prompts := make([]capability, len(manifest.Prompts))
+	for i, prompt := range manifest.Prompts {
+		prompts[i] = capability{key: prompt.Name, name: prompt.Name, description: prompt.Description}
+	}
+	if err := validateCapabilities("prompt", prompts); err != nil {
+		return err
+	}
+
+	resources := make([]capability, len(manifest.Resources))
+	for i, resource := range manifest.Resources {
+		if resource.URI == "" || len(resource.URI) > maxResourceURILength {
+			return fmt.Errorf("%w: resource %d must have a URI of at most %d characters", ErrInvalidCapabilityManifest, i, maxResourceURILength)
+		}
+		resources[i] = capability{key: resource.URI, name: resource.Name, description: resource.Description}
+	}
+	return validateCapabilities("resource", resources)
+}
+
+// capability is a tool, prompt or resource, identified by its key
+type capability struct {
+	key         string
+	name        string
+	description string
+}
+
+func validateCapabilities(kind string, capabilities []capability) error {
+	if len(capabilities) > maxCapabilitiesPerKind {
+		return fmt.Errorf("%w: more than %d %ss", ErrInvalidCapabilityManifest, maxCapabilitiesPerKind, kind)
+	}
+
+	seen := make(map[string]bool, len(capabilities))
+	for i, c := range capabilities {
+		if c.name == "" || len(c.name) > maxCapabilityNameLength {
+			return fmt.Errorf("%w: %s %d must have a name of at most %d characters", ErrInvalidCapabilityManifest, kind, i, maxCapabilityNameLength)
+		}
+		if len(c.description) > maxCapabilityDescriptionLength {
+			return fmt.Errorf("%w: description of %s '%s' exceeds %d characters", ErrInvalidCapabilityManifest, kind, c.name, maxCapabilityDescriptionLength)
+		}
+		if seen[c.key] {
+			return fmt.Errorf("%w: duplicate %s '%s'", ErrInvalidCapabilityManifest, kind, c.key)
+		}
+		seen[c.key] = true
+	}
+	return nil
+}
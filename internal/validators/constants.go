@@ -12,6 +12,9 @@ var (
 	ErrPackageNameHasSpaces  = errors.New("package name cannot contain spaces")
 	ErrReservedVersionString = errors.New("version string 'latest' is reserved and cannot be used")
 	ErrVersionLooksLikeRange = errors.New("version must be a specific version, not a range")
+	ErrInvalidPackageRef     = errors.New("invalid package reference")
+	ErrInvalidRuntimeHint    = errors.New("runtime hint is not valid for registry type")
+	ErrOCIDigestRequired     = errors.New("OCI packages must be pinned by digest")
 
 	// Remote validation errors
 	ErrInvalidRemoteURL = errors.New("invalid remote URL")
@@ -19,6 +22,8 @@ var (
 	// Registry validation errors
 	ErrUnsupportedRegistryBaseURL   = errors.New("unsupported registry base URL")
 	ErrMismatchedRegistryTypeAndURL = errors.New("registry type and base URL do not match")
+	ErrRegistryTypeDisabled         = errors.New("registry type is disabled on this registry")
+	ErrRegistryRateLimited          = errors.New("package registry rate limited validation, retry the publish later")
 
 	// Argument validation errors
 	ErrNamedArgumentNameRequired     = errors.New("named argument name is required")
@@ -26,6 +31,9 @@ var (
 	ErrArgumentValueStartsWithName   = errors.New("argument value cannot start with the argument name")
 	ErrArgumentDefaultStartsWithName = errors.New("argument default cannot start with the argument name")
 
+	// Capability manifest validation errors
+	ErrInvalidCapabilityManifest = errors.New("invalid capability manifest")
+
 	// Server name validation errors
 	ErrMultipleSlashesInServerName = errors.New("server name cannot contain multiple slashes")
 	ErrInvalidServerNameFormat     = errors.New("server name format is invalid")
@@ -3,27 +3,246 @@ package validators
 import (
 	"context"
 	"fmt"
+	"strings"
+	"sync"
 
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/telemetry"
 	"github.com/modelcontextprotocol/registry/internal/validators/registries"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
 	"github.com/modelcontextprotocol/registry/pkg/model"
 )
 
+// PackageValidator validates the packages of one registry type
+type PackageValidator interface {
+	// ValidatePackage checks that the package is on an allowed registry and owned by the server
+	ValidatePackage(ctx context.Context, pkg model.Package, serverName, serverVersion string) error
+}
+
+// PackageValidatorFunc adapts a function to a PackageValidator
+type PackageValidatorFunc func(ctx context.Context, pkg model.Package, serverName, serverVersion string) error
+
+// ValidatePackage calls f(ctx, pkg, serverName, serverVersion)
+func (f PackageValidatorFunc) ValidatePackage(ctx context.Context, pkg model.Package, serverName, serverVersion string) error {
+	return f(ctx, pkg, serverName, serverVersion)
+}
+
+// ProvenanceVerifier is implemented by package validators that can verify that a package was
+// built from the server's repository
+type ProvenanceVerifier interface {
+	VerifyProvenance(ctx context.Context, pkg model.Package, repositoryURL string) error
+}
+
+// IntegrityVerifier is implemented by package validators that can check a package under the
+// legacy validation rules, only for being reachable and matching its hash
+type IntegrityVerifier interface {
+	VerifyIntegrity(ctx context.Context, pkg model.Package) error
+}
+
+// Package validation rule sets. Each server version records the rule set it was published under,
+// so that re-validation does not fail versions published before stricter rules applied.
+const (
+	// LegacyValidationRules predate the manifest and ownership checks of MCPB bundles
+	LegacyValidationRules = 0
+	// MCPBOwnershipValidationRules check that MCPB manifests match the server and that bundles
+	// are owned by the publisher
+	MCPBOwnershipValidationRules = 1
+	// CurrentValidationRules are the rules new server versions are validated under
+	CurrentValidationRules = MCPBOwnershipValidationRules
+)
+
+// DigestResolver is implemented by validators of OCI packages that can resolve a package
+// reference to the digest of its manifest
+type DigestResolver interface {
+	ResolveDigest(ctx context.Context, identifier string) (string, error)
+}
+
+// newPackageValidators returns the built-in validator of each registry type
+func newPackageValidators(cfg registries.Config) map[string]PackageValidator {
+	return map[string]PackageValidator{
+		model.RegistryTypeNPM:      registries.NewNPMValidator(cfg),
+		model.RegistryTypePyPI:     registries.NewPyPIValidator(cfg),
+		model.RegistryTypeNuGet:    registries.NewNuGetValidator(cfg),
+		model.RegistryTypeOCI:      registries.NewOCIValidator(cfg),
+		model.RegistryTypeMCPB:     registries.NewMCPBValidator(cfg),
+		model.RegistryTypeCargo:    registries.NewCargoValidator(cfg),
+		model.RegistryTypeGo:       registries.NewGoValidator(cfg),
+		model.RegistryTypeRubyGems: registries.NewRubyGemsValidator(cfg),
+		model.RegistryTypeMaven:    registries.NewMavenValidator(cfg),
+	}
+}
+
+var (
+	packageValidatorsMu   sync.RWMutex
+	packageValidators     = newPackageValidators(registries.Config{})
+	disabledRegistryTypes = map[string]bool{}
+)
+
+// RegisterPackageValidator sets the validator for a registry type, replacing any existing one.
+// A nil validator removes support for the registry type.
+func RegisterPackageValidator(registryType string, validator PackageValidator) {
+	packageValidatorsMu.Lock()
+	defer packageValidatorsMu.Unlock()
+	defer clearValidationCache()
+	if validator == nil {
+		delete(packageValidators, registryType)
+		return
+	}
+	packageValidators[registryType] = validator
+}
+
+// SetDisabledRegistryTypes sets the registry types whose packages cannot be published
+func SetDisabledRegistryTypes(registryTypes []string) {
+	packageValidatorsMu.Lock()
+	defer packageValidatorsMu.Unlock()
+	disabledRegistryTypes = make(map[string]bool, len(registryTypes))
+	for _, registryType := range registryTypes {
+		disabledRegistryTypes[strings.TrimSpace(registryType)] = true
+	}
+}
+
+// packageValidator returns the validator registered for a registry type
+func packageValidator(registryType string) (PackageValidator, bool) {
+	packageValidatorsMu.RLock()
+	defer packageValidatorsMu.RUnlock()
+	validator, ok := packageValidators[registryType]
+	return validator, ok
+}
+
+// checkRegistryTypeEnabled returns an error if packages of the registry type cannot be published
+func checkRegistryTypeEnabled(registryType string) error {
+	packageValidatorsMu.RLock()
+	defer packageValidatorsMu.RUnlock()
+	if disabledRegistryTypes[registryType] {
+		return fmt.Errorf("%w: %s", ErrRegistryTypeDisabled, registryType)
+	}
+	return nil
+}
+
 // ValidatePackage validates that the package referenced in the server configuration is:
 // 1. allowed on the official registry (based on registry base url); and
 // 2. owned by the publisher, by checking for a matching server name in the package metadata
-func ValidatePackage(ctx context.Context, pkg model.Package, serverName string) error {
-	switch pkg.RegistryType {
-	case model.RegistryTypeNPM:
-		return registries.ValidateNPM(ctx, pkg, serverName)
-	case model.RegistryTypePyPI:
-		return registries.ValidatePyPI(ctx, pkg, serverName)
-	case model.RegistryTypeNuGet:
-		return registries.ValidateNuGet(ctx, pkg, serverName)
-	case model.RegistryTypeOCI:
-		return registries.ValidateOCI(ctx, pkg, serverName)
-	case model.RegistryTypeMCPB:
-		return registries.ValidateMCPB(ctx, pkg, serverName)
-	default:
+//
+// The server version is checked against packages that declare their own, such as MCPB manifests.
+// Packages are validated by the validator registered for their registry type, and results are
+// cached as configured with SetValidationCache.
+func ValidatePackage(ctx context.Context, pkg model.Package, serverName, serverVersion string) error {
+	if err := checkRegistryTypeEnabled(pkg.RegistryType); err != nil {
+		return err
+	}
+
+	validator, ok := packageValidator(pkg.RegistryType)
+	if !ok {
 		return fmt.Errorf("unsupported registry type: %s", pkg.RegistryType)
 	}
+
+	return packageValidationCache.validate(ctx, validator, pkg, serverName, serverVersion)
+}
+
+// RevalidatePackage validates a published package again under the rules its server version was
+// published under. Unlike ValidatePackage, results are neither taken from nor stored in the cache,
+// so that packages which changed since they were validated are noticed.
+func RevalidatePackage(ctx context.Context, pkg model.Package, serverName, serverVersion string, rules int) error {
+	if err := checkRegistryTypeEnabled(pkg.RegistryType); err != nil {
+		return err
+	}
+
+	validator, ok := packageValidator(pkg.RegistryType)
+	if !ok {
+		return fmt.Errorf("unsupported registry type: %s", pkg.RegistryType)
+	}
+
+	if verifier, ok := validator.(IntegrityVerifier); ok && rules < MCPBOwnershipValidationRules {
+		return verifier.VerifyIntegrity(ctx, pkg)
+	}
+	return validator.ValidatePackage(ctx, pkg, serverName, serverVersion)
+}
+
+// VerifyPackageProvenance verifies that every package of the server was built from the server's
+// repository, through Sigstore signatures or provenance attestations:
+//   - npm: SLSA provenance attestation of the tarball
+//   - pypi: trusted publisher attestations of every distribution file
+//   - oci: keyless cosign signature of the image digest
+//
+// OCI packages are verified at the digests they resolved to during validation. Servers without a
+// repository, without packages, or with packages of other registry types cannot be verified.
+func VerifyPackageProvenance(ctx context.Context, req apiv0.ServerJSON, packageDigests map[string]string) error {
+	if req.Repository.URL == "" {
+		return fmt.Errorf("server has no repository to verify packages against")
+	}
+	if len(req.Packages) == 0 {
+		return fmt.Errorf("server has no packages to verify")
+	}
+
+	for i, pkg := range req.Packages {
+		validator, _ := packageValidator(pkg.RegistryType)
+		verifier, ok := validator.(ProvenanceVerifier)
+		if !ok {
+			return fmt.Errorf("package %d (%s): provenance verification is not supported for registry type '%s'", i, pkg.Identifier, pkg.RegistryType)
+		}
+		if digest, ok := packageDigests[pkg.Identifier]; ok && !strings.Contains(pkg.Identifier, "@") {
+			pkg.Identifier += "@" + digest
+		}
+		if err := verifier.VerifyProvenance(ctx, pkg, req.Repository.URL); err != nil {
+			return fmt.Errorf("package %d (%s): %w", i, req.Packages[i].Identifier, err)
+		}
+	}
+
+	return nil
+}
+
+// ConfigureRegistries applies the package registry configuration: the registry types that can
+// be published, and the validation cache. It replaces the validator of each built-in registry type
+// with one sharing an HTTP client that records requests in metrics, allowing the private registries
+// in addition to the public registries, resolving Go modules through the configured proxy, verifying
+// provenance against the Sigstore trusted root, and MCPB signatures with mcpbSignatures.
+func ConfigureRegistries(cfg *config.Config, metrics *telemetry.Metrics, mcpbSignatures registries.MCPBSignatureVerifier) error {
+	client, err := registries.NewHTTPClient(registries.HTTPClientConfig{
+		Timeout:      cfg.PackageValidationTimeout,
+		MaxRetries:   cfg.PackageValidationMaxRetries,
+		RetryBackoff: cfg.PackageValidationRetryBackoff,
+		ProxyURL:     cfg.PackageValidationProxy,
+		Metrics:      metrics,
+	})
+	if err != nil {
+		return err
+	}
+
+	privateRegistries := make([]registries.PrivateRegistry, 0, len(cfg.PackageRegistries))
+	for _, registry := range cfg.PackageRegistries {
+		privateRegistries = append(privateRegistries, registries.PrivateRegistry{
+			RegistryType: registry.Type,
+			BaseURL:      registry.BaseURL,
+			Username:     registry.Username,
+			Password:     registry.Password,
+			Token:        registry.Token,
+			AuthHosts:    registry.AuthHosts,
+		})
+	}
+	registryConfig := registries.Config{
+		Client:                client,
+		PrivateRegistries:     privateRegistries,
+		MCPBSignatureVerifier: mcpbSignatures,
+	}
+	// A nil root must leave the interface nil, which selects the public-good instance
+	if cfg.SigstoreTrustedRoot != nil {
+		registryConfig.SigstoreTrustedMaterial = cfg.SigstoreTrustedRoot
+	}
+
+	validators := newPackageValidators(registryConfig)
+	// Go modules on the public proxy may be resolved through a mirror
+	goConfig := registryConfig
+	goConfig.BaseURL = cfg.GoModuleProxy
+	validators[model.RegistryTypeGo] = registries.NewGoValidator(goConfig)
+
+	packageValidatorsMu.Lock()
+	for registryType, validator := range validators {
+		packageValidators[registryType] = validator
+	}
+	packageValidatorsMu.Unlock()
+
+	SetValidationCache(cfg.PackageValidationCacheTTL, cfg.PackageValidationNegativeCacheTTL)
+	SetDisabledRegistryTypes(cfg.DisabledPackageRegistryTypes)
+	setValidationCacheMetrics(metrics)
+	return nil
 }
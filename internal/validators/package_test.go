@@ -0,0 +1,294 @@
+package validators_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/validators"
+	"github.com/modelcontextprotocol/registry/internal/validators/registries"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestValidatePublishRequest_OCIDigests(t *testing.T) {
+	ctx := context.Background()
+
+	// OCI registry stand-in serving acme/weather:1.0.0, retrievable by tag or by digest
+	index := []byte(`{"manifests":[{"digest":"sha256:amd64"}]}`)
+	sum := sha256.Sum256(index)
+	indexDigest := "sha256:" + hex.EncodeToString(sum[:])
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/acme/weather/manifests/1.0.0", "/v2/acme/weather/manifests/" + indexDigest:
+			_, _ = w.Write(index)
+		case "/v2/acme/weather/manifests/sha256:amd64":
+			_, _ = w.Write([]byte(`{"config":{"digest":"sha256:config"}}`))
+		case "/v2/acme/weather/blobs/sha256:config":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"config": map[string]any{"Labels": map[string]string{"io.modelcontextprotocol.server.name": "com.acme/weather"}},
+			})
+		case "/v2/acme/limited/manifests/1.0.0":
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	host := strings.TrimPrefix(server.URL, "http://")
+
+	validators.RegisterPackageValidator(model.RegistryTypeOCI, registries.NewOCIValidator(registries.Config{
+		PrivateRegistries: []registries.PrivateRegistry{{RegistryType: model.RegistryTypeOCI, BaseURL: server.URL}},
+	}))
+	t.Cleanup(func() {
+		validators.RegisterPackageValidator(model.RegistryTypeOCI, registries.NewOCIValidator(registries.Config{}))
+	})
+
+	serverJSON := func(identifier string) apiv0.ServerJSON {
+		return apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        "com.acme/weather",
+			Description: "A test server",
+			Version:     "1.0.0",
+			Packages: []model.Package{
+				{RegistryType: model.RegistryTypeOCI, Identifier: identifier, Transport: model.Transport{Type: "stdio"}},
+			},
+		}
+	}
+	tagged := host + "/acme/weather:1.0.0"
+	pinned := tagged + "@" + indexDigest
+
+	t.Run("tag is resolved and recorded", func(t *testing.T) {
+		digests, err := validators.ValidatePublishRequest(ctx, serverJSON(tagged), &config.Config{EnableRegistryValidation: true})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{tagged: indexDigest}, digests)
+	})
+
+	t.Run("pinned reference is recorded", func(t *testing.T) {
+		digests, err := validators.ValidatePublishRequest(ctx, serverJSON(pinned), &config.Config{EnableRegistryValidation: true})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{pinned: indexDigest}, digests)
+	})
+
+	t.Run("rate limited resolution fails the publish", func(t *testing.T) {
+		_, err := validators.ValidatePublishRequest(ctx, serverJSON(host+"/acme/limited:1.0.0"), &config.Config{EnableRegistryValidation: true})
+		assert.ErrorIs(t, err, validators.ErrRegistryRateLimited)
+	})
+
+	t.Run("policy rejects tags without digest", func(t *testing.T) {
+		_, err := validators.ValidatePublishRequest(ctx, serverJSON(tagged), &config.Config{RequireOCIDigest: true})
+		assert.ErrorIs(t, err, validators.ErrOCIDigestRequired)
+	})
+
+	t.Run("policy accepts pinned references", func(t *testing.T) {
+		_, err := validators.ValidatePublishRequest(ctx, serverJSON(pinned), &config.Config{RequireOCIDigest: true, EnableRegistryValidation: true})
+		assert.NoError(t, err)
+	})
+}
+
+func TestVerifyPackageProvenance_Unverifiable(t *testing.T) {
+	ctx := context.Background()
+	repository := model.Repository{URL: "https://github.com/acme/weather", Source: "github"}
+	npmPackage := model.Package{RegistryType: model.RegistryTypeNPM, Identifier: "acme-weather", Version: "1.0.0"}
+
+	tests := []struct {
+		name         string
+		server       apiv0.ServerJSON
+		errorMessage string
+	}{
+		{
+			name:         "server without repository",
+			server:       apiv0.ServerJSON{Name: "com.acme/weather", Packages: []model.Package{npmPackage}},
+			errorMessage: "server has no repository",
+		},
+		{
+			name:         "server without packages",
+			server:       apiv0.ServerJSON{Name: "com.acme/weather", Repository: repository},
+			errorMessage: "server has no packages",
+		},
+		{
+			name: "package type without provenance support",
+			server: apiv0.ServerJSON{Name: "com.acme/weather", Repository: repository, Packages: []model.Package{
+				{RegistryType: model.RegistryTypeNuGet, Identifier: "Acme.Weather", Version: "1.0.0"},
+			}},
+			errorMessage: "not supported for registry type 'nuget'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validators.VerifyPackageProvenance(ctx, tt.server, nil)
+			assert.ErrorContains(t, err, tt.errorMessage)
+		})
+	}
+}
+
+func TestPackageValidatorRegistry(t *testing.T) {
+	ctx := context.Background()
+	customPackage := model.Package{RegistryType: "conda", Identifier: "acme-weather", Version: "1.0.0"}
+	npmPackage := model.Package{RegistryType: model.RegistryTypeNPM, Identifier: "acme-weather", Version: "1.0.0", Transport: model.Transport{Type: "stdio"}}
+
+	t.Run("unknown registry type is unsupported", func(t *testing.T) {
+		err := validators.ValidatePackage(ctx, customPackage, "com.acme/weather", "1.0.0")
+		assert.ErrorContains(t, err, "unsupported registry type: conda")
+	})
+
+	t.Run("registered validator is used", func(t *testing.T) {
+		var validated model.Package
+		validators.RegisterPackageValidator("conda", validators.PackageValidatorFunc(
+			func(_ context.Context, pkg model.Package, serverName, serverVersion string) error {
+				validated = pkg
+				assert.Equal(t, "com.acme/weather", serverName)
+				assert.Equal(t, "1.0.0", serverVersion)
+				return nil
+			}))
+		t.Cleanup(func() { validators.RegisterPackageValidator("conda", nil) })
+
+		require.NoError(t, validators.ValidatePackage(ctx, customPackage, "com.acme/weather", "1.0.0"))
+		assert.Equal(t, customPackage, validated)
+	})
+
+	t.Run("disabled registry type is rejected even without registry validation", func(t *testing.T) {
+		validators.SetDisabledRegistryTypes([]string{model.RegistryTypeNPM})
+		t.Cleanup(func() { validators.SetDisabledRegistryTypes(nil) })
+
+		err := validators.ValidatePackage(ctx, npmPackage, "com.acme/weather", "1.0.0")
+		assert.ErrorIs(t, err, validators.ErrRegistryTypeDisabled)
+
+		_, err = validators.ValidatePublishRequest(ctx, apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        "com.acme/weather",
+			Description: "A test server",
+			Version:     "1.0.0",
+			Packages:    []model.Package{npmPackage},
+		}, &config.Config{})
+		assert.ErrorIs(t, err, validators.ErrRegistryTypeDisabled)
+	})
+}
+
+func TestValidatePackage_Cache(t *testing.T) {
+	ctx := context.Background()
+	validators.SetValidationCache(time.Hour, time.Hour)
+	t.Cleanup(func() { validators.SetValidationCache(0, 0) })
+
+	var calls atomic.Int64
+	validators.RegisterPackageValidator("conda", validators.PackageValidatorFunc(
+		func(_ context.Context, pkg model.Package, _, _ string) error {
+			calls.Add(1)
+			if pkg.Version == "0.0.0" {
+				return errors.New("package not found")
+			}
+			return nil
+		}))
+	t.Cleanup(func() { validators.RegisterPackageValidator("conda", nil) })
+
+	pkg := model.Package{RegistryType: "conda", Identifier: "acme-weather", Version: "1.0.0"}
+
+	t.Run("successful validation is cached", func(t *testing.T) {
+		calls.Store(0)
+		require.NoError(t, validators.ValidatePackage(ctx, pkg, "com.acme/weather", "1.0.0"))
+		require.NoError(t, validators.ValidatePackage(ctx, pkg, "com.acme/weather", "1.0.0"))
+		assert.Equal(t, int64(1), calls.Load())
+	})
+
+	t.Run("failed validation is cached", func(t *testing.T) {
+		calls.Store(0)
+		missing := pkg
+		missing.Version = "0.0.0"
+		assert.ErrorContains(t, validators.ValidatePackage(ctx, missing, "com.acme/weather", "1.0.0"), "package not found")
+		assert.ErrorContains(t, validators.ValidatePackage(ctx, missing, "com.acme/weather", "1.0.0"), "package not found")
+		assert.Equal(t, int64(1), calls.Load())
+	})
+
+	t.Run("other versions and servers are validated", func(t *testing.T) {
+		calls.Store(0)
+		other := pkg
+		other.Version = "2.0.0"
+		require.NoError(t, validators.ValidatePackage(ctx, other, "com.acme/weather", "1.0.0"))
+		require.NoError(t, validators.ValidatePackage(ctx, pkg, "com.acme/forecast", "1.0.0"))
+		assert.Equal(t, int64(2), calls.Load())
+	})
+
+	t.Run("registering a validator clears the cache", func(t *testing.T) {
+		validators.RegisterPackageValidator("conda", validators.PackageValidatorFunc(
+			func(context.Context, model.Package, string, string) error {
+				return errors.New("package withdrawn")
+			}))
+		assert.ErrorContains(t, validators.ValidatePackage(ctx, pkg, "com.acme/weather", "1.0.0"), "package withdrawn")
+	})
+
+	t.Run("transient registry failures are not cached", func(t *testing.T) {
+
+		var available atomic.Bool
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			if !available.Load() {
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"mcpName": "com.acme/weather"})
+		}))
+		t.Cleanup(server.Close)
+		client, err := registries.NewHTTPClient(registries.HTTPClientConfig{Timeout: 5 * time.Second, RetryBackoff: time.Millisecond})
+		require.NoError(t, err)
+		validators.RegisterPackageValidator(model.RegistryTypeNPM, registries.NewNPMValidator(registries.Config{BaseURL: server.URL, Client: client}))
+		t.Cleanup(func() {
+			validators.RegisterPackageValidator(model.RegistryTypeNPM, registries.NewNPMValidator(registries.Config{}))
+		})
+
+		npmPackage := model.Package{RegistryType: model.RegistryTypeNPM, Identifier: "acme-weather", Version: "1.0.0"}
+		assert.Error(t, validators.ValidatePackage(ctx, npmPackage, "com.acme/weather", "1.0.0"))
+
+		available.Store(true)
+		assert.NoError(t, validators.ValidatePackage(ctx, npmPackage, "com.acme/weather", "1.0.0"))
+	})
+
+	t.Run("validations skipped after transient registry failures are not cached", func(t *testing.T) {
+		// OCI validation is skipped while the registry rate limits, and then finds another owner
+		var available atomic.Bool
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !available.Load() {
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+			switch r.URL.Path {
+			case "/v2/acme/weather/manifests/1.0.0":
+				_, _ = w.Write([]byte(`{"config":{"digest":"sha256:config"}}`))
+			case "/v2/acme/weather/blobs/sha256:config":
+				_ = json.NewEncoder(w).Encode(map[string]any{
+					"config": map[string]any{"Labels": map[string]string{"io.modelcontextprotocol.server.name": "com.other/server"}},
+				})
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+		t.Cleanup(server.Close)
+		client, err := registries.NewHTTPClient(registries.HTTPClientConfig{Timeout: 5 * time.Second, RetryBackoff: time.Millisecond})
+		require.NoError(t, err)
+		validators.RegisterPackageValidator(model.RegistryTypeOCI, registries.NewOCIValidator(registries.Config{
+			Client:            client,
+			PrivateRegistries: []registries.PrivateRegistry{{RegistryType: model.RegistryTypeOCI, BaseURL: server.URL}},
+		}))
+		t.Cleanup(func() {
+			validators.RegisterPackageValidator(model.RegistryTypeOCI, registries.NewOCIValidator(registries.Config{}))
+		})
+
+		ociPackage := model.Package{RegistryType: model.RegistryTypeOCI, Identifier: strings.TrimPrefix(server.URL, "http://") + "/acme/weather:1.0.0"}
+		assert.NoError(t, validators.ValidatePackage(ctx, ociPackage, "com.acme/weather", "1.0.0"))
+
+		available.Store(true)
+		assert.ErrorContains(t, validators.ValidatePackage(ctx, ociPackage, "com.acme/weather", "1.0.0"), "ownership validation failed")
+	})
+}
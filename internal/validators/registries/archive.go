@@ -0,0 +1,113 @@
+package registries
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+)
+
+const (
+	// maxArchiveSize bounds package archives downloaded to read their README
+	maxArchiveSize = 50 << 20
+	// maxReadmeSize bounds the README read from a package archive
+	maxReadmeSize = 5 << 20
+)
+
+// fetchLimited fetches a URL, failing unless the response is 200 OK and at most limit bytes
+func fetchLimited(ctx context.Context, client *http.Client, registry PrivateRegistry, requestURL string, limit int64) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	registry.authorize(req)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("status: %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > limit {
+		return nil, fmt.Errorf("response exceeds %d bytes", limit)
+	}
+	return data, nil
+}
+
+// isReadme reports whether name, relative to the archive root, is a README file at the root
+func isReadme(name string) bool {
+	if strings.Contains(name, "/") {
+		return false
+	}
+	return strings.ToLower(strings.TrimSuffix(name, path.Ext(name))) == "readme"
+}
+
+// readZipReadme returns the README stored directly under prefix in a zip archive.
+// It reports false if there is none.
+func readZipReadme(data []byte, prefix string) (string, bool, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return "", false, fmt.Errorf("failed to open archive: %w", err)
+	}
+
+	for _, file := range archive.File {
+		name, ok := strings.CutPrefix(file.Name, prefix)
+		if !ok || !isReadme(name) {
+			continue
+		}
+
+		f, err := file.Open()
+		if err != nil {
+			return "", false, fmt.Errorf("failed to read %s from archive: %w", file.Name, err)
+		}
+		content, err := io.ReadAll(io.LimitReader(f, maxReadmeSize))
+		f.Close()
+		if err != nil {
+			return "", false, fmt.Errorf("failed to read %s from archive: %w", file.Name, err)
+		}
+		return string(content), true, nil
+	}
+
+	return "", false, nil
+}
+
+// readTarReadme returns the README stored directly under prefix in a tar stream.
+// It reports false if there is none.
+func readTarReadme(r io.Reader, prefix string) (string, bool, error) {
+	archive := tar.NewReader(r)
+	for {
+		header, err := archive.Next()
+		if errors.Is(err, io.EOF) {
+			return "", false, nil
+		}
+		if err != nil {
+			return "", false, fmt.Errorf("failed to read archive: %w", err)
+		}
+
+		name, ok := strings.CutPrefix(strings.TrimPrefix(header.Name, "./"), prefix)
+		if !ok || header.Typeflag != tar.TypeReg || !isReadme(name) {
+			continue
+		}
+
+		content, err := io.ReadAll(io.LimitReader(archive, maxReadmeSize))
+		if err != nil {
+			return "", false, fmt.Errorf("failed to read %s from archive: %w", header.Name, err)
+		}
+		return string(content), true, nil
+	}
+}
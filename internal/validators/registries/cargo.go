@@ -0,0 +1,132 @@
+package registries
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+var (
+	ErrMissingIdentifierForCargo = errors.New("package identifier is required for Cargo packages")
+	ErrMissingVersionForCargo    = errors.New("package version is required for Cargo packages")
+)
+
+// maxCargoReadmeSize bounds the README downloaded from crates.io
+const maxCargoReadmeSize = 5 << 20
+
+// CargoVersionResponse represents the structure returned by the crates.io version API
+type CargoVersionResponse struct {
+	Version struct {
+		Description string `json:"description"`
+		Yanked      bool   `json:"yanked"`
+	} `json:"version"`
+}
+
+// CargoValidator validates crates
+type CargoValidator struct {
+	registries registrySet
+}
+
+// NewCargoValidator returns a validator for crates
+func NewCargoValidator(cfg Config) *CargoValidator {
+	return &CargoValidator{registries: newRegistrySet(model.RegistryTypeCargo, model.RegistryURLCrates, cfg)}
+}
+
+// ValidateCargo validates a crate on crates.io, see CargoValidator.ValidatePackage
+func ValidateCargo(ctx context.Context, pkg model.Package, serverName string) error {
+	return NewCargoValidator(Config{}).ValidatePackage(ctx, pkg, serverName, "")
+}
+
+// ValidatePackage validates that a crate contains the correct MCP server name, either
+// as 'mcp-name: <name>' in the crate README or in the crate description
+func (v *CargoValidator) ValidatePackage(ctx context.Context, pkg model.Package, serverName, _ string) error {
+	// Set default registry base URL if empty
+	if pkg.RegistryBaseURL == "" {
+		pkg.RegistryBaseURL = model.RegistryURLCrates
+	}
+
+	if pkg.Identifier == "" {
+		return ErrMissingIdentifierForCargo
+	}
+
+	if pkg.Version == "" {
+		return ErrMissingVersionForCargo
+	}
+
+	// Validate that MCPB-specific fields are not present
+	if pkg.FileSHA256 != "" {
+		return fmt.Errorf("Cargo packages must not have 'fileSha256' field - this is only for MCPB packages")
+	}
+
+	// Validate that the registry base URL is the public registry or an allowed private registry
+	registry, err := v.registries.resolve(pkg.RegistryBaseURL)
+	if err != nil {
+		return err
+	}
+
+	client := v.registries.httpClient()
+	versionURL := fmt.Sprintf("%s/api/v1/crates/%s/%s", registry.BaseURL, url.PathEscape(pkg.Identifier), url.PathEscape(pkg.Version))
+
+	resp, err := cargoGet(ctx, client, registry, versionURL, "application/json")
+	if err != nil {
+		return fmt.Errorf("failed to fetch crate metadata from crates.io: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Cargo crate '%s' version '%s' not found (status: %d)", pkg.Identifier, pkg.Version, resp.StatusCode)
+	}
+
+	var versionResp CargoVersionResponse
+	if err := json.NewDecoder(resp.Body).Decode(&versionResp); err != nil {
+		return fmt.Errorf("failed to parse crate metadata: %w", err)
+	}
+
+	if versionResp.Version.Yanked {
+		return fmt.Errorf("Cargo crate '%s' version '%s' has been yanked", pkg.Identifier, pkg.Version)
+	}
+
+	mcpNamePattern := "mcp-name: " + serverName
+	if strings.Contains(versionResp.Version.Description, mcpNamePattern) {
+		return nil // Found in the crate description
+	}
+
+	// crates.io redirects to the rendered README of this version
+	readmeResp, err := cargoGet(ctx, client, registry, versionURL+"/readme", "text/html")
+	if err != nil {
+		return fmt.Errorf("failed to fetch README from crates.io: %w", err)
+	}
+	defer readmeResp.Body.Close()
+
+	if readmeResp.StatusCode == http.StatusOK {
+		readmeBytes, err := io.ReadAll(io.LimitReader(readmeResp.Body, maxCargoReadmeSize))
+		if err != nil {
+			return fmt.Errorf("failed to read README content: %w", err)
+		}
+
+		if strings.Contains(string(readmeBytes), mcpNamePattern) {
+			return nil // Found as mcp-name: format
+		}
+	}
+
+	return fmt.Errorf("Cargo crate '%s' ownership validation failed. The server name '%s' must appear as 'mcp-name: %s' in the crate README or description", pkg.Identifier, serverName, serverName)
+}
+
+func cargoGet(ctx context.Context, client *http.Client, registry PrivateRegistry, requestURL, accept string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Accept", accept)
+	registry.authorize(req)
+
+	return client.Do(req)
+}
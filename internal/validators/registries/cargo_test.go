@@ -0,0 +1,147 @@
+package registries_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/modelcontextprotocol/registry/internal/validators/registries"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestValidateCargo(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	// Stand-in for the crates.io API, redirecting README requests like crates.io does
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/crates/{name}/{version}", func(w http.ResponseWriter, r *http.Request) {
+		versions := map[string]map[string]any{
+			"weather-mcp/1.0.0":     {"description": "Weather MCP server"},
+			"described-mcp/1.0.0":   {"description": "An MCP server. mcp-name: com.acme/described"},
+			"yanked-mcp/1.0.0":      {"description": "mcp-name: com.acme/yanked", "yanked": true},
+			"weather-mcp/2.0.0-rc1": {"description": ""},
+		}
+		version, ok := versions[r.PathValue("name")+"/"+r.PathValue("version")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"version": version})
+	})
+	mux.HandleFunc("GET /api/v1/crates/weather-mcp/1.0.0/readme", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/readmes/weather-mcp/weather-mcp-1.0.0.html", http.StatusFound)
+	})
+	mux.HandleFunc("GET /readmes/weather-mcp/weather-mcp-1.0.0.html", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("<h1>Weather</h1>\n<p>mcp-name: com.acme/weather</p>"))
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	validator := registries.NewCargoValidator(registries.Config{
+		PrivateRegistries: []registries.PrivateRegistry{{RegistryType: model.RegistryTypeCargo, BaseURL: server.URL}},
+	})
+
+	tests := []struct {
+		name         string
+		identifier   string
+		version      string
+		baseURL      string
+		serverName   string
+		errorMessage string
+	}{
+		{
+			name:         "empty identifier should fail",
+			version:      "1.0.0",
+			baseURL:      server.URL,
+			serverName:   "com.acme/weather",
+			errorMessage: "package identifier is required for Cargo packages",
+		},
+		{
+			name:         "empty version should fail",
+			identifier:   "weather-mcp",
+			baseURL:      server.URL,
+			serverName:   "com.acme/weather",
+			errorMessage: "package version is required for Cargo packages",
+		},
+		{
+			name:         "other registry should fail",
+			identifier:   "weather-mcp",
+			version:      "1.0.0",
+			baseURL:      "https://registry.npmjs.org",
+			serverName:   "com.acme/weather",
+			errorMessage: "registry type and base URL do not match",
+		},
+		{
+			name:       "name in README should pass",
+			identifier: "weather-mcp",
+			version:    "1.0.0",
+			baseURL:    server.URL,
+			serverName: "com.acme/weather",
+		},
+		{
+			name:       "name in description should pass",
+			identifier: "described-mcp",
+			version:    "1.0.0",
+			baseURL:    server.URL,
+			serverName: "com.acme/described",
+		},
+		{
+			name:         "different server name should fail",
+			identifier:   "weather-mcp",
+			version:      "1.0.0",
+			baseURL:      server.URL,
+			serverName:   "com.other/weather",
+			errorMessage: "ownership validation failed",
+		},
+		{
+			name:         "version without README should fail",
+			identifier:   "weather-mcp",
+			version:      "2.0.0-rc1",
+			baseURL:      server.URL,
+			serverName:   "com.acme/weather",
+			errorMessage: "ownership validation failed",
+		},
+		{
+			name:         "yanked version should fail",
+			identifier:   "yanked-mcp",
+			version:      "1.0.0",
+			baseURL:      server.URL,
+			serverName:   "com.acme/yanked",
+			errorMessage: "has been yanked",
+		},
+		{
+			name:         "non-existent crate should fail",
+			identifier:   "missing-mcp",
+			version:      "1.0.0",
+			baseURL:      server.URL,
+			serverName:   "com.acme/missing",
+			errorMessage: "not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			pkg := model.Package{
+				RegistryType:    model.RegistryTypeCargo,
+				RegistryBaseURL: tt.baseURL,
+				Identifier:      tt.identifier,
+				Version:         tt.version,
+			}
+
+			err := validator.ValidatePackage(ctx, pkg, tt.serverName, "")
+			if tt.errorMessage != "" {
+				assert.ErrorContains(t, err, tt.errorMessage)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,213 @@
+package registries
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	protobundle "github.com/sigstore/protobuf-specs/gen/pb-go/bundle/v1"
+	protocommon "github.com/sigstore/protobuf-specs/gen/pb-go/common/v1"
+	protorekor "github.com/sigstore/protobuf-specs/gen/pb-go/rekor/v1"
+	"github.com/sigstore/sigstore-go/pkg/bundle"
+	"github.com/sigstore/sigstore-go/pkg/verify"
+
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// Cosign stores the signatures of an image as layers of a manifest tagged after the image digest
+const (
+	cosignSimpleSigningMediaType = "application/vnd.dev.cosign.simplesigning.v1+json"
+	cosignSignatureAnnotation    = "dev.cosignproject.cosign/signature"
+	cosignCertificateAnnotation  = "dev.sigstore.cosign/certificate"
+	cosignChainAnnotation        = "dev.sigstore.cosign/chain"
+	cosignBundleAnnotation       = "dev.sigstore.cosign/bundle"
+)
+
+// cosignPayload is the signed payload of a cosign image signature
+type cosignPayload struct {
+	Critical struct {
+		Image struct {
+			DockerManifestDigest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+	} `json:"critical"`
+}
+
+// VerifyProvenance verifies that an image, referenced by digest, has a keyless cosign signature
+// made by a workflow in repositoryURL
+func (v *OCIValidator) VerifyProvenance(ctx context.Context, pkg model.Package, repositoryURL string) error {
+	identifier := pkg.Identifier
+	ociRef, err := ParseOCIReference(identifier)
+	if err != nil {
+		return fmt.Errorf("invalid OCI reference: %w", err)
+	}
+	if ociRef.Digest == "" {
+		return fmt.Errorf("OCI image '%s' must be referenced by digest to verify its signature", identifier)
+	}
+
+	registryConfig := v.registryConfig(ociRef)
+	if registryConfig == nil {
+		return unsupportedOCIRegistryError(ociRef)
+	}
+
+	client := v.registries.httpClient()
+	repository := ociRef.Repository()
+
+	// Signatures of sha256:<hex> are tagged sha256-<hex>.sig
+	signatureTag := strings.Replace(ociRef.Digest, ":", "-", 1) + ".sig"
+	manifest, _, err := fetchImageManifest(ctx, client, registryConfig, repository, signatureTag)
+	if err != nil {
+		return fmt.Errorf("OCI image '%s' has no cosign signature: %w", identifier, err)
+	}
+
+	verifyErr := errors.New("no cosign signature layers")
+	for _, layer := range manifest.Layers {
+		if layer.MediaType != cosignSimpleSigningMediaType {
+			continue
+		}
+		if err := v.verifyCosignLayer(ctx, client, registryConfig, repository, layer, ociRef.Digest, repositoryURL); err != nil {
+			verifyErr = err
+			continue
+		}
+		return nil
+	}
+
+	return fmt.Errorf("OCI image '%s' signature verification failed: %w", identifier, verifyErr)
+}
+
+// verifyCosignLayer verifies one cosign signature: its payload must name the image digest and be
+// signed with a Sigstore certificate issued to a workflow in repositoryURL, and logged in Rekor
+func (v *OCIValidator) verifyCosignLayer(ctx context.Context, client *http.Client, registryConfig *RegistryConfig, repository string, layer OCIDescriptor, imageDigest, repositoryURL string) error {
+	resp, err := registryConfig.get(ctx, client, "/v2/"+repository+"/blobs/"+layer.Digest, cosignSimpleSigningMediaType)
+	if err != nil {
+		return fmt.Errorf("failed to fetch signature payload: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("signature payload not found (status: %d)", resp.StatusCode)
+	}
+
+	payload, err := io.ReadAll(io.LimitReader(resp.Body, maxProvenanceSize))
+	if err != nil {
+		return fmt.Errorf("failed to read signature payload: %w", err)
+	}
+	sum := sha256.Sum256(payload)
+	if "sha256:"+hex.EncodeToString(sum[:]) != layer.Digest {
+		return fmt.Errorf("signature payload does not match its digest")
+	}
+
+	var signed cosignPayload
+	if err := json.Unmarshal(payload, &signed); err != nil {
+		return fmt.Errorf("failed to parse signature payload: %w", err)
+	}
+	if signed.Critical.Image.DockerManifestDigest != imageDigest {
+		return fmt.Errorf("signature is for image '%s', not '%s'", signed.Critical.Image.DockerManifestDigest, imageDigest)
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(layer.Annotations[cosignSignatureAnnotation])
+	if err != nil {
+		return fmt.Errorf("invalid signature encoding: %w", err)
+	}
+	b, err := cosignBundle(layer.Annotations, payload, signature)
+	if err != nil {
+		return err
+	}
+	_, err = verifySigstoreEntity(v.sigstore, b, verify.WithArtifact(bytes.NewReader(payload)), repositoryURL)
+	return err
+}
+
+// cosignRekorBundle is the Rekor inclusion promise cosign attaches to a signature
+type cosignRekorBundle struct {
+	SignedEntryTimestamp []byte `json:"SignedEntryTimestamp"`
+	Payload              struct {
+		Body           []byte `json:"body"`
+		IntegratedTime int64  `json:"integratedTime"`
+		LogIndex       int64  `json:"logIndex"`
+		LogID          string `json:"logID"`
+	} `json:"Payload"`
+}
+
+// cosignBundle converts a cosign signature layer into the equivalent Sigstore bundle: the signing
+// certificate and its chain, the signature of the payload, and the payload's Rekor entry
+func cosignBundle(annotations map[string]string, payload, signature []byte) (*bundle.Bundle, error) {
+	certs, err := parsePEMCertificates([]byte(annotations[cosignCertificateAnnotation]))
+	if err != nil || len(certs) == 0 {
+		return nil, fmt.Errorf("signature has no valid signing certificate; only keyless signatures can be tied to a repository")
+	}
+	chain, err := parsePEMCertificates([]byte(annotations[cosignChainAnnotation]))
+	if err != nil {
+		return nil, fmt.Errorf("invalid certificate chain: %w", err)
+	}
+	// The chain starts with the signing certificate
+	certificates := []*protocommon.X509Certificate{{RawBytes: certs[0]}}
+	for _, cert := range chain {
+		certificates = append(certificates, &protocommon.X509Certificate{RawBytes: cert})
+	}
+
+	var rekorBundle cosignRekorBundle
+	if err := json.Unmarshal([]byte(annotations[cosignBundleAnnotation]), &rekorBundle); err != nil || len(rekorBundle.SignedEntryTimestamp) == 0 {
+		return nil, fmt.Errorf("signature has no transparency log entry")
+	}
+	var body struct {
+		Kind       string `json:"kind"`
+		APIVersion string `json:"apiVersion"`
+	}
+	if err := json.Unmarshal(rekorBundle.Payload.Body, &body); err != nil {
+		return nil, fmt.Errorf("invalid transparency log entry: %w", err)
+	}
+	logID, err := hex.DecodeString(rekorBundle.Payload.LogID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid transparency log ID: %w", err)
+	}
+
+	digest := sha256.Sum256(payload)
+	return bundle.NewBundle(&protobundle.Bundle{
+		// Cosign signatures only carry an inclusion promise, which version 0.1 bundles are verified with
+		MediaType: "application/vnd.dev.sigstore.bundle+json;version=0.1",
+		VerificationMaterial: &protobundle.VerificationMaterial{
+			Content: &protobundle.VerificationMaterial_X509CertificateChain{
+				X509CertificateChain: &protocommon.X509CertificateChain{Certificates: certificates},
+			},
+			TlogEntries: []*protorekor.TransparencyLogEntry{{
+				LogIndex:          rekorBundle.Payload.LogIndex,
+				LogId:             &protocommon.LogId{KeyId: logID},
+				KindVersion:       &protorekor.KindVersion{Kind: body.Kind, Version: body.APIVersion},
+				IntegratedTime:    rekorBundle.Payload.IntegratedTime,
+				InclusionPromise:  &protorekor.InclusionPromise{SignedEntryTimestamp: rekorBundle.SignedEntryTimestamp},
+				CanonicalizedBody: rekorBundle.Payload.Body,
+			}},
+		},
+		Content: &protobundle.Bundle_MessageSignature{
+			MessageSignature: &protocommon.MessageSignature{
+				MessageDigest: &protocommon.HashOutput{Algorithm: protocommon.HashAlgorithm_SHA2_256, Digest: digest[:]},
+				Signature:     signature,
+			},
+		},
+	})
+}
+
+// parsePEMCertificates returns the DER encoding of the certificates in PEM data
+func parsePEMCertificates(data []byte) ([][]byte, error) {
+	var certs [][]byte
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return certs, nil
+		}
+		if _, err := x509.ParseCertificate(block.Bytes); err != nil {
+			return nil, err
+		}
+		certs = append(certs, block.Bytes)
+	}
+}
@@ -0,0 +1,8 @@
+package registries
+
+import "context"
+
+// VerifyBundle exposes verifyBundle to tests, which serve bundles from non-allowlisted hosts
+func (v *MCPBValidator) VerifyBundle(ctx context.Context, bundleURL, fileSHA256, serverName, serverVersion string) error {
+	return v.verifyBundle(ctx, v.registries.httpClient(), bundleURL, fileSHA256, serverName, serverVersion)
+}
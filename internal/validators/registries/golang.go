@@ -0,0 +1,133 @@
+package registries
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
+
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+var (
+	ErrMissingIdentifierForGo = errors.New("package identifier is required for Go modules")
+	ErrMissingVersionForGo    = errors.New("package version is required for Go modules")
+)
+
+// GoModuleInfo represents the structure returned by the module proxy .info endpoint
+type GoModuleInfo struct {
+	Version string    `json:"Version"`
+	Time    time.Time `json:"Time"`
+}
+
+// GoValidator validates Go modules
+type GoValidator struct {
+	registries registrySet
+}
+
+// NewGoValidator returns a validator for Go modules
+func NewGoValidator(cfg Config) *GoValidator {
+	return &GoValidator{registries: newRegistrySet(model.RegistryTypeGo, model.RegistryURLGo, cfg)}
+}
+
+// ValidateGo validates a Go module on the public module proxy, see GoValidator.ValidatePackage
+func ValidateGo(ctx context.Context, pkg model.Package, serverName string) error {
+	return NewGoValidator(Config{}).ValidatePackage(ctx, pkg, serverName, "")
+}
+
+// ValidatePackage validates that a Go module contains the correct MCP server name in its README.
+// The module is resolved through the module proxy protocol (https://go.dev/ref/mod#goproxy-protocol).
+func (v *GoValidator) ValidatePackage(ctx context.Context, pkg model.Package, serverName, _ string) error {
+	// Set default registry base URL if empty
+	if pkg.RegistryBaseURL == "" {
+		pkg.RegistryBaseURL = model.RegistryURLGo
+	}
+
+	if pkg.Identifier == "" {
+		return ErrMissingIdentifierForGo
+	}
+
+	if pkg.Version == "" {
+		return ErrMissingVersionForGo
+	}
+
+	// Validate that MCPB-specific fields are not present
+	if pkg.FileSHA256 != "" {
+		return fmt.Errorf("Go modules must not have 'fileSha256' field - this is only for MCPB packages")
+	}
+
+	// Module versions are canonical semantic versions with a v prefix, e.g. v1.2.3
+	if !semver.IsValid(pkg.Version) || semver.Canonical(pkg.Version) != strings.TrimSuffix(pkg.Version, "+incompatible") {
+		return fmt.Errorf("Go module version '%s' must be a canonical semantic version such as v1.2.3", pkg.Version)
+	}
+	if err := module.Check(pkg.Identifier, pkg.Version); err != nil {
+		return fmt.Errorf("invalid Go module: %w", err)
+	}
+
+	// Validate that the registry base URL is the public proxy or an allowed private proxy
+	registry, err := v.registries.resolve(pkg.RegistryBaseURL)
+	if err != nil {
+		return err
+	}
+
+	escapedPath, err := module.EscapePath(pkg.Identifier)
+	if err != nil {
+		return fmt.Errorf("invalid Go module path: %w", err)
+	}
+	escapedVersion, err := module.EscapeVersion(pkg.Version)
+	if err != nil {
+		return fmt.Errorf("invalid Go module version: %w", err)
+	}
+	versionURL := fmt.Sprintf("%s/%s/@v/%s", registry.BaseURL, escapedPath, escapedVersion)
+
+	client := v.registries.httpClient()
+
+	// Resolve the version
+	infoData, err := fetchLimited(ctx, client, registry, versionURL+".info", 1<<20)
+	if err != nil {
+		return fmt.Errorf("Go module '%s@%s' not found: %w", pkg.Identifier, pkg.Version, err)
+	}
+	var info GoModuleInfo
+	if err := json.Unmarshal(infoData, &info); err != nil {
+		return fmt.Errorf("failed to parse Go module info: %w", err)
+	}
+	if info.Version != pkg.Version {
+		return fmt.Errorf("Go module proxy resolved '%s@%s' to version '%s'", pkg.Identifier, pkg.Version, info.Version)
+	}
+
+	// Check that the go.mod declares the module path
+	modData, err := fetchLimited(ctx, client, registry, versionURL+".mod", 1<<20)
+	if err != nil {
+		return fmt.Errorf("failed to fetch go.mod for '%s@%s': %w", pkg.Identifier, pkg.Version, err)
+	}
+	if modulePath := modfile.ModulePath(modData); modulePath != pkg.Identifier && !strings.HasSuffix(pkg.Version, "+incompatible") {
+		return fmt.Errorf("Go module '%s@%s' declares module path '%s'", pkg.Identifier, pkg.Version, modulePath)
+	}
+
+	// Read the README from the module zip
+	zipData, err := fetchLimited(ctx, client, registry, versionURL+".zip", maxArchiveSize)
+	if err != nil {
+		return fmt.Errorf("failed to fetch module zip for '%s@%s': %w", pkg.Identifier, pkg.Version, err)
+	}
+	// Module zips store all files under "<module path>@<version>/"
+	readme, found, err := readZipReadme(zipData, pkg.Identifier+"@"+pkg.Version+"/")
+	if err != nil {
+		return err
+	}
+	if !found {
+		return fmt.Errorf("Go module '%s@%s' has no README at the module root", pkg.Identifier, pkg.Version)
+	}
+
+	mcpNamePattern := "mcp-name: " + serverName
+	if strings.Contains(readme, mcpNamePattern) {
+		return nil // Found as mcp-name: format
+	}
+
+	return fmt.Errorf("Go module '%s' ownership validation failed. The server name '%s' must appear as 'mcp-name: %s' in the README at the module root", pkg.Identifier, serverName, serverName)
+}
@@ -0,0 +1,179 @@
+package registries_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/validators/registries"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// moduleZip builds a module zip with the given files under "<module>@<version>/"
+func moduleZip(t *testing.T, modulePath, version string, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for name, content := range files {
+		f, err := w.Create(modulePath + "@" + version + "/" + name)
+		require.NoError(t, err)
+		_, err = f.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func TestValidateGo(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	// Module proxy stand-in. Upper case letters in module paths are escaped as !lower.
+	proxy := map[string][]byte{
+		"/github.com/acme/weather/@v/v1.0.0.info": []byte(`{"Version":"v1.0.0","Time":"2025-01-01T00:00:00Z"}`),
+		"/github.com/acme/weather/@v/v1.0.0.mod":  []byte("module github.com/acme/weather\n\ngo 1.24\n"),
+		"/github.com/acme/weather/@v/v1.0.0.zip": moduleZip(t, "github.com/acme/weather", "v1.0.0", map[string]string{
+			"README.md":        "# Weather\n\n<!-- mcp-name: com.acme/weather -->\n",
+			"go.mod":           "module github.com/acme/weather\n",
+			"internal/main.go": "package main\n",
+		}),
+		"/github.com/!acme/nested/@v/v1.0.0.info": []byte(`{"Version":"v1.0.0"}`),
+		"/github.com/!acme/nested/@v/v1.0.0.mod":  []byte("module github.com/Acme/nested\n"),
+		"/github.com/!acme/nested/@v/v1.0.0.zip": moduleZip(t, "github.com/Acme/nested", "v1.0.0", map[string]string{
+			"docs/README.md": "mcp-name: com.acme/nested",
+		}),
+		"/github.com/acme/renamed/@v/v1.0.0.info": []byte(`{"Version":"v1.0.0"}`),
+		"/github.com/acme/renamed/@v/v1.0.0.mod":  []byte("module github.com/acme/original\n"),
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, ok := proxy[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(data)
+	}))
+	t.Cleanup(server.Close)
+
+	// Resolve public modules through the stand-in, like an internal mirror of proxy.golang.org
+	validator := registries.NewGoValidator(registries.Config{BaseURL: server.URL})
+
+	tests := []struct {
+		name         string
+		identifier   string
+		version      string
+		baseURL      string
+		serverName   string
+		errorMessage string
+	}{
+		{
+			name:         "empty identifier should fail",
+			version:      "v1.0.0",
+			serverName:   "com.acme/weather",
+			errorMessage: "package identifier is required for Go modules",
+		},
+		{
+			name:         "empty version should fail",
+			identifier:   "github.com/acme/weather",
+			serverName:   "com.acme/weather",
+			errorMessage: "package version is required for Go modules",
+		},
+		{
+			name:         "version without v prefix should fail",
+			identifier:   "github.com/acme/weather",
+			version:      "1.0.0",
+			serverName:   "com.acme/weather",
+			errorMessage: "canonical semantic version",
+		},
+		{
+			name:         "non-canonical version should fail",
+			identifier:   "github.com/acme/weather",
+			version:      "v1.0",
+			serverName:   "com.acme/weather",
+			errorMessage: "canonical semantic version",
+		},
+		{
+			name:         "major version mismatch should fail",
+			identifier:   "github.com/acme/weather",
+			version:      "v2.0.0",
+			serverName:   "com.acme/weather",
+			errorMessage: "invalid Go module",
+		},
+		{
+			name:         "other proxy should fail",
+			identifier:   "github.com/acme/weather",
+			version:      "v1.0.0",
+			baseURL:      "https://goproxy.example.com",
+			serverName:   "com.acme/weather",
+			errorMessage: "registry type and base URL do not match",
+		},
+		{
+			name:       "name in README should pass",
+			identifier: "github.com/acme/weather",
+			version:    "v1.0.0",
+			serverName: "com.acme/weather",
+		},
+		{
+			name:       "explicit public proxy should pass",
+			identifier: "github.com/acme/weather",
+			version:    "v1.0.0",
+			baseURL:    model.RegistryURLGo,
+			serverName: "com.acme/weather",
+		},
+		{
+			name:         "different server name should fail",
+			identifier:   "github.com/acme/weather",
+			version:      "v1.0.0",
+			serverName:   "com.other/weather",
+			errorMessage: "ownership validation failed",
+		},
+		{
+			name:         "README outside module root should fail",
+			identifier:   "github.com/Acme/nested",
+			version:      "v1.0.0",
+			serverName:   "com.acme/nested",
+			errorMessage: "has no README at the module root",
+		},
+		{
+			name:         "go.mod with different module path should fail",
+			identifier:   "github.com/acme/renamed",
+			version:      "v1.0.0",
+			serverName:   "com.acme/renamed",
+			errorMessage: "declares module path 'github.com/acme/original'",
+		},
+		{
+			name:         "unknown version should fail",
+			identifier:   "github.com/acme/weather",
+			version:      "v1.0.1",
+			serverName:   "com.acme/weather",
+			errorMessage: "not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			pkg := model.Package{
+				RegistryType:    model.RegistryTypeGo,
+				RegistryBaseURL: tt.baseURL,
+				Identifier:      tt.identifier,
+				Version:         tt.version,
+			}
+
+			err := validator.ValidatePackage(ctx, pkg, tt.serverName, "")
+			if tt.errorMessage != "" {
+				assert.ErrorContains(t, err, tt.errorMessage)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,185 @@
+package registries
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/modelcontextprotocol/registry/internal/telemetry"
+)
+
+// maxRetryDelay bounds the wait between retries, including delays asked for with Retry-After
+const maxRetryDelay = 30 * time.Second
+
+// HTTPClientConfig configures an HTTP client for package validators
+type HTTPClientConfig struct {
+	// Timeout bounds each request to a registry, including its retries
+	Timeout time.Duration
+	// MaxRetries is how often GET requests are retried after network errors, 429 and 5xx responses
+	MaxRetries int
+	// RetryBackoff is the wait before the first retry, doubled for each further retry
+	RetryBackoff time.Duration
+	// ProxyURL is the proxy for registry requests. If empty, HTTP_PROXY and HTTPS_PROXY are used.
+	ProxyURL string
+	// UserAgent identifies the registry to package registries
+	UserAgent string
+	// Metrics records the requests to package registries, if set
+	Metrics *telemetry.Metrics
+}
+
+// DefaultHTTPClientConfig configures the client of validators created without one
+var DefaultHTTPClientConfig = HTTPClientConfig{
+	Timeout:      30 * time.Second,
+	MaxRetries:   2,
+	RetryBackoff: 500 * time.Millisecond,
+	UserAgent:    "MCP-Registry-Validator/1.0",
+}
+
+// defaultHTTPClient is the client of validators created without one
+var defaultHTTPClient = newHTTPClient(DefaultHTTPClientConfig, http.ProxyFromEnvironment)
+
+type transientFailureKey struct{}
+
+// TrackTransientFailures returns a context that records whether a registry request made with it
+// failed with a network error, 429 or 5xx response after exhausting its retries, and a function
+// reporting whether one did. Validation results obtained despite such failures may not hold on retry.
+func TrackTransientFailures(ctx context.Context) (context.Context, func() bool) {
+	failed := new(atomic.Bool)
+	return context.WithValue(ctx, transientFailureKey{}, failed), failed.Load
+}
+
+// NewHTTPClient returns an HTTP client for package validators, which retries requests that failed
+// with transient errors
+func NewHTTPClient(cfg HTTPClientConfig) (*http.Client, error) {
+	proxy := http.ProxyFromEnvironment
+	if cfg.ProxyURL != "" {
+		proxyURL, err := url.Parse(cfg.ProxyURL)
+		if err != nil || proxyURL.Host == "" {
+			return nil, fmt.Errorf("invalid package validation proxy URL '%s'", cfg.ProxyURL)
+		}
+		proxy = http.ProxyURL(proxyURL)
+	}
+	if cfg.UserAgent == "" {
+		cfg.UserAgent = DefaultHTTPClientConfig.UserAgent
+	}
+	return newHTTPClient(cfg, proxy), nil
+}
+
+func newHTTPClient(cfg HTTPClientConfig, proxy func(*http.Request) (*url.URL, error)) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = proxy
+	return &http.Client{
+		Timeout: cfg.Timeout,
+		Transport: &retryTransport{
+			base:       transport,
+			userAgent:  cfg.UserAgent,
+			maxRetries: cfg.MaxRetries,
+			backoff:    cfg.RetryBackoff,
+			metrics:    cfg.Metrics,
+		},
+	}
+}
+
+// retryTransport sets the User-Agent of registry requests and retries GET requests that failed
+// with transient errors, with exponential backoff
+type retryTransport struct {
+	base       http.RoundTripper
+	userAgent  string
+	maxRetries int
+	backoff    time.Duration
+	metrics    *telemetry.Metrics
+	// registryType labels recorded requests, so that the metrics have one series per registry
+	// type rather than per host
+	registryType string
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	// Some registries, such as crates.io, reject requests without a User-Agent
+	req.Header.Set("User-Agent", t.userAgent)
+	retryable := req.Method == http.MethodGet && req.Body == nil
+
+	for attempt := 0; ; attempt++ {
+		resp, err := t.base.RoundTrip(req)
+		t.record(req, resp, err)
+		transient := isTransientFailure(resp, err)
+		if !retryable || attempt >= t.maxRetries || !transient || req.Context().Err() != nil {
+			if transient {
+				markTransientFailure(req.Context())
+			}
+			return resp, err
+		}
+
+		delay := min(t.backoff<<attempt, maxRetryDelay)
+		if resp != nil {
+			if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds >= 0 {
+				delay = min(time.Duration(seconds)*time.Second, maxRetryDelay)
+			}
+			_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			markTransientFailure(req.Context())
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// isTransientFailure reports whether a request may succeed if retried
+func isTransientFailure(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// markTransientFailure records a transient failure in a context from TrackTransientFailures
+func markTransientFailure(ctx context.Context) {
+	if failed, ok := ctx.Value(transientFailureKey{}).(*atomic.Bool); ok {
+		failed.Store(true)
+	}
+}
+
+// record records a request to a package registry, and whether it was rate limited
+func (t *retryTransport) record(req *http.Request, resp *http.Response, err error) {
+	metrics := t.metrics
+	if metrics == nil {
+		return
+	}
+
+	status := "error"
+	if err == nil {
+		status = strconv.Itoa(resp.StatusCode)
+	}
+	registryType := t.registryType
+	if registryType == "" {
+		registryType = "other"
+	}
+	ctx := req.Context()
+	metrics.PackageRegistryRequests.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("registry_type", registryType),
+		attribute.String("status", status),
+	))
+	if err == nil && resp.StatusCode == http.StatusTooManyRequests {
+		metrics.PackageRegistryRateLimited.Add(ctx, 1, metric.WithAttributes(attribute.String("registry_type", registryType)))
+	}
+}
@@ -0,0 +1,126 @@
+package registries_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/validators/registries"
+)
+
+func TestHTTPClient(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	client, err := registries.NewHTTPClient(registries.HTTPClientConfig{
+		Timeout:      5 * time.Second,
+		MaxRetries:   2,
+		RetryBackoff: time.Millisecond,
+		UserAgent:    "test-registry/1.0",
+	})
+	require.NoError(t, err)
+
+	// Each path fails with its status until it has been requested the given number of times
+	var requests sync.Map
+	failures := map[string]struct {
+		status int
+		count  int64
+	}{
+		"/unavailable":  {http.StatusServiceUnavailable, 2},
+		"/rate-limited": {http.StatusTooManyRequests, 1},
+		"/down":         {http.StatusBadGateway, 10},
+		"/missing":      {http.StatusNotFound, 10},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("User-Agent") != "test-registry/1.0" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		counter, _ := requests.LoadOrStore(r.Method+r.URL.Path, new(atomic.Int64))
+		n := counter.(*atomic.Int64).Add(1)
+		if failure, ok := failures[r.URL.Path]; ok && n <= failure.count {
+			if failure.status == http.StatusTooManyRequests {
+				w.Header().Set("Retry-After", "0")
+			}
+			w.WriteHeader(failure.status)
+			return
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	t.Cleanup(server.Close)
+
+	requestCount := func(key string) int64 {
+		counter, ok := requests.Load(key)
+		if !ok {
+			return 0
+		}
+		return counter.(*atomic.Int64).Load()
+	}
+
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		status   int
+		requests int64
+	}{
+		{name: "success is not retried", method: http.MethodGet, path: "/ok", status: http.StatusOK, requests: 1},
+		{name: "unavailable is retried", method: http.MethodGet, path: "/unavailable", status: http.StatusOK, requests: 3},
+		{name: "rate limit is retried after Retry-After", method: http.MethodGet, path: "/rate-limited", status: http.StatusOK, requests: 2},
+		{name: "retries are bounded", method: http.MethodGet, path: "/down", status: http.StatusBadGateway, requests: 3},
+		{name: "client errors are not retried", method: http.MethodGet, path: "/missing", status: http.StatusNotFound, requests: 1},
+		{name: "requests other than GET are not retried", method: http.MethodPost, path: "/unavailable", status: http.StatusServiceUnavailable, requests: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req, err := http.NewRequestWithContext(ctx, tt.method, server.URL+tt.path, nil)
+			require.NoError(t, err)
+
+			resp, err := client.Do(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.status, resp.StatusCode)
+			assert.Equal(t, tt.requests, requestCount(tt.method+tt.path))
+		})
+	}
+
+	t.Run("invalid proxy URL", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := registries.NewHTTPClient(registries.HTTPClientConfig{ProxyURL: "not a url"})
+		assert.ErrorContains(t, err, "invalid package validation proxy URL")
+	})
+
+	t.Run("requests go through the proxy", func(t *testing.T) {
+		t.Parallel()
+
+		var proxied atomic.Bool
+		proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			proxied.Store(strings.HasPrefix(r.RequestURI, "http://registry.example.com/"))
+			_, _ = w.Write([]byte("ok"))
+		}))
+		t.Cleanup(proxy.Close)
+		proxyClient, err := registries.NewHTTPClient(registries.HTTPClientConfig{Timeout: 5 * time.Second, ProxyURL: proxy.URL})
+		require.NoError(t, err)
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://registry.example.com/package", nil)
+		require.NoError(t, err)
+		resp, err := proxyClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.True(t, proxied.Load())
+	})
+}
@@ -0,0 +1,127 @@
+package registries
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+var (
+	ErrMissingIdentifierForMaven = errors.New("package identifier is required for Maven packages")
+	ErrMissingVersionForMaven    = errors.New("package version is required for Maven packages")
+)
+
+// MavenPOM represents the parts of a Maven POM used for validation
+type MavenPOM struct {
+	Description string `xml:"description"`
+	Properties  struct {
+		MCPName string `xml:"mcpName"`
+	} `xml:"properties"`
+}
+
+// MavenValidator validates Maven artifacts
+type MavenValidator struct {
+	registries registrySet
+}
+
+// NewMavenValidator returns a validator for Maven artifacts
+func NewMavenValidator(cfg Config) *MavenValidator {
+	return &MavenValidator{registries: newRegistrySet(model.RegistryTypeMaven, model.RegistryURLMaven, cfg)}
+}
+
+// ValidateMaven validates a Maven artifact on Maven Central, see MavenValidator.ValidatePackage
+func ValidateMaven(ctx context.Context, pkg model.Package, serverName string) error {
+	return NewMavenValidator(Config{}).ValidatePackage(ctx, pkg, serverName, "")
+}
+
+// ValidatePackage validates that a Maven artifact contains the correct MCP server name, either as
+// the mcpName property in the POM or as 'mcp-name: <name>' in the POM description or the jar README.
+// The identifier is the artifact's "groupId:artifactId".
+func (v *MavenValidator) ValidatePackage(ctx context.Context, pkg model.Package, serverName, _ string) error {
+	// Set default registry base URL if empty
+	if pkg.RegistryBaseURL == "" {
+		pkg.RegistryBaseURL = model.RegistryURLMaven
+	}
+
+	if pkg.Identifier == "" {
+		return ErrMissingIdentifierForMaven
+	}
+
+	if pkg.Version == "" {
+		return ErrMissingVersionForMaven
+	}
+
+	// Validate that MCPB-specific fields are not present
+	if pkg.FileSHA256 != "" {
+		return fmt.Errorf("Maven packages must not have 'fileSha256' field - this is only for MCPB packages")
+	}
+
+	groupID, artifactID, found := strings.Cut(pkg.Identifier, ":")
+	if !found || groupID == "" || artifactID == "" || strings.ContainsAny(artifactID, `:/\`) || strings.ContainsAny(groupID, `/\`) || strings.Contains(pkg.Identifier, "..") {
+		return fmt.Errorf("Maven package identifier '%s' must be in the form 'groupId:artifactId'", pkg.Identifier)
+	}
+
+	// The version is a path segment of the repository layout, so it must not leave its directory
+	if strings.ContainsAny(pkg.Version, `/\`) || strings.Contains(pkg.Version, "..") {
+		return fmt.Errorf("Maven package version '%s' must not contain '/', '\\' or '..'", pkg.Version)
+	}
+
+	// Validate that the registry base URL is the public registry or an allowed private registry
+	registry, err := v.registries.resolve(pkg.RegistryBaseURL)
+	if err != nil {
+		return err
+	}
+
+	client := v.registries.httpClient()
+
+	// Maven repository layout: group/path/artifact/version/artifact-version.ext
+	groupPath := strings.Split(groupID, ".")
+	for i, part := range groupPath {
+		groupPath[i] = url.PathEscape(part)
+	}
+	artifact, version := url.PathEscape(artifactID), url.PathEscape(pkg.Version)
+	artifactURL := fmt.Sprintf("%s/%s/%s/%s/%s-%s", registry.BaseURL, strings.Join(groupPath, "/"), artifact, version, artifact, version)
+
+	pomData, err := fetchLimited(ctx, client, registry, artifactURL+".pom", 1<<20)
+	if err != nil {
+		return fmt.Errorf("Maven package '%s' version '%s' not found: %w", pkg.Identifier, pkg.Version, err)
+	}
+
+	var pom MavenPOM
+	if err := xml.Unmarshal(pomData, &pom); err != nil {
+		return fmt.Errorf("failed to parse Maven POM: %w", err)
+	}
+
+	// Check the mcpName property, like mcpName in package.json
+	if mcpName := strings.TrimSpace(pom.Properties.MCPName); mcpName != "" {
+		if mcpName != serverName {
+			return fmt.Errorf("Maven package ownership validation failed. Expected POM property 'mcpName' '%s', got '%s'", serverName, mcpName)
+		}
+		return nil
+	}
+
+	mcpNamePattern := "mcp-name: " + serverName
+	if strings.Contains(pom.Description, mcpNamePattern) {
+		return nil // Found in the POM description
+	}
+
+	// Check the README packaged at the root of the jar
+	jar, err := fetchLimited(ctx, client, registry, artifactURL+".jar", maxArchiveSize)
+	if err != nil {
+		return fmt.Errorf("failed to download jar for '%s:%s': %w", pkg.Identifier, pkg.Version, err)
+	}
+	readme, _, err := readZipReadme(jar, "")
+	if err != nil {
+		return err
+	}
+	if strings.Contains(readme, mcpNamePattern) {
+		return nil // Found as mcp-name: format
+	}
+
+	return fmt.Errorf("Maven package '%s' ownership validation failed. Add <mcpName>%s</mcpName> to the <properties> of your POM, or 'mcp-name: %s' to a README at the root of the jar", pkg.Identifier, serverName, serverName)
+}
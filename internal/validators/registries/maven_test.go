@@ -0,0 +1,186 @@
+package registries_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/validators/registries"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// jarFile builds a jar with the given files
+func jarFile(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for name, content := range files {
+		f, err := w.Create(name)
+		require.NoError(t, err)
+		_, err = f.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func TestValidateMaven(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	// Maven repository stand-in
+	responses := map[string][]byte{
+		"/com/acme/weather/1.0.0/weather-1.0.0.pom": []byte(`<project><properties><mcpName>com.acme/weather</mcpName></properties></project>`),
+		"/com/acme/info/1.0.0/info-1.0.0.pom":       []byte(`<project><description>Weather tools. mcp-name: com.acme/info</description></project>`),
+		"/com/acme/readme/1.0.0/readme-1.0.0.pom":   []byte(`<project><description>Weather tools</description></project>`),
+		"/com/acme/readme/1.0.0/readme-1.0.0.jar": jarFile(t, map[string]string{
+			"README.md":            "# Weather\n\n<!-- mcp-name: com.acme/readme -->\n",
+			"META-INF/MANIFEST.MF": "Manifest-Version: 1.0\n",
+		}),
+		"/com/acme/nested/1.0.0/nested-1.0.0.pom": []byte(`<project><description>Weather tools</description></project>`),
+		"/com/acme/nested/1.0.0/nested-1.0.0.jar": jarFile(t, map[string]string{
+			"META-INF/README.md": "mcp-name: com.acme/nested",
+		}),
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, ok := responses[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(data)
+	}))
+	t.Cleanup(server.Close)
+
+	validator := registries.NewMavenValidator(registries.Config{
+		PrivateRegistries: []registries.PrivateRegistry{{RegistryType: model.RegistryTypeMaven, BaseURL: server.URL}},
+	})
+
+	tests := []struct {
+		name         string
+		identifier   string
+		version      string
+		baseURL      string
+		serverName   string
+		errorMessage string
+	}{
+		{
+			name:         "empty identifier should fail",
+			version:      "1.0.0",
+			baseURL:      server.URL,
+			serverName:   "com.acme/weather",
+			errorMessage: "package identifier is required for Maven packages",
+		},
+		{
+			name:         "empty version should fail",
+			identifier:   "com.acme:weather",
+			baseURL:      server.URL,
+			serverName:   "com.acme/weather",
+			errorMessage: "package version is required for Maven packages",
+		},
+		{
+			name:         "identifier without group should fail",
+			identifier:   "weather",
+			version:      "1.0.0",
+			baseURL:      server.URL,
+			serverName:   "com.acme/weather",
+			errorMessage: "must be in the form 'groupId:artifactId'",
+		},
+		{
+			name:         "version leaving its directory should fail",
+			identifier:   "com.acme:weather",
+			version:      "1.0/../../../../attacker/x/1.0/x-1.0.pom?",
+			baseURL:      server.URL,
+			serverName:   "com.acme/weather",
+			errorMessage: "must not contain '/', '\\' or '..'",
+		},
+		{
+			name:         "artifactId leaving its directory should fail",
+			identifier:   "com.acme:..",
+			version:      "1.0.0",
+			baseURL:      server.URL,
+			serverName:   "com.acme/weather",
+			errorMessage: "must be in the form 'groupId:artifactId'",
+		},
+		{
+			name:         "other repository should fail",
+			identifier:   "com.acme:weather",
+			version:      "1.0.0",
+			baseURL:      "https://maven.example.com",
+			serverName:   "com.acme/weather",
+			errorMessage: "registry type and base URL do not match",
+		},
+		{
+			name:       "name in POM property should pass",
+			identifier: "com.acme:weather",
+			version:    "1.0.0",
+			baseURL:    server.URL,
+			serverName: "com.acme/weather",
+		},
+		{
+			name:         "different name in POM property should fail",
+			identifier:   "com.acme:weather",
+			version:      "1.0.0",
+			baseURL:      server.URL,
+			serverName:   "com.other/weather",
+			errorMessage: "Expected POM property 'mcpName' 'com.other/weather', got 'com.acme/weather'",
+		},
+		{
+			name:       "name in POM description should pass",
+			identifier: "com.acme:info",
+			version:    "1.0.0",
+			baseURL:    server.URL,
+			serverName: "com.acme/info",
+		},
+		{
+			name:       "name in jar README should pass",
+			identifier: "com.acme:readme",
+			version:    "1.0.0",
+			baseURL:    server.URL,
+			serverName: "com.acme/readme",
+		},
+		{
+			name:         "README outside jar root should fail",
+			identifier:   "com.acme:nested",
+			version:      "1.0.0",
+			baseURL:      server.URL,
+			serverName:   "com.acme/nested",
+			errorMessage: "ownership validation failed",
+		},
+		{
+			name:         "unknown version should fail",
+			identifier:   "com.acme:weather",
+			version:      "1.0.1",
+			baseURL:      server.URL,
+			serverName:   "com.acme/weather",
+			errorMessage: "not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			pkg := model.Package{
+				RegistryType:    model.RegistryTypeMaven,
+				RegistryBaseURL: tt.baseURL,
+				Identifier:      tt.identifier,
+				Version:         tt.version,
+			}
+
+			err := validator.ValidatePackage(ctx, pkg, tt.serverName, "")
+			if tt.errorMessage != "" {
+				assert.ErrorContains(t, err, tt.errorMessage)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
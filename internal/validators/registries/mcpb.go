@@ -1,10 +1,17 @@
 package registries
 
 import (
+	"archive/zip"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
+	"os"
+	"path"
 	"regexp"
 	"strings"
 	"time"
@@ -17,7 +24,48 @@ var (
 	ErrMissingFileSHA256ForMCPB = fmt.Errorf("must include a fileSha256 hash for integrity verification")
 )
 
-func ValidateMCPB(ctx context.Context, pkg model.Package, _ string) error {
+const (
+	// maxMCPBSize bounds the bundle downloaded to verify its hash and manifest
+	maxMCPBSize = 200 << 20
+	// maxMCPBManifestSize bounds the manifest.json read from a bundle
+	maxMCPBManifestSize = 1 << 20
+)
+
+// MCPBManifest represents the parts of an MCPB manifest.json used for validation
+type MCPBManifest struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	MCPName string `json:"mcp_name"`
+}
+
+// MCPBSignatureVerifier verifies a signature over message with the keys the publisher registered
+// for the namespace of the server name
+type MCPBSignatureVerifier func(ctx context.Context, serverName string, message, signature []byte) error
+
+// MCPBValidator validates MCPB bundles
+type MCPBValidator struct {
+	registries registrySet
+	signatures MCPBSignatureVerifier
+}
+
+// NewMCPBValidator returns a validator for MCPB bundles
+func NewMCPBValidator(cfg Config) *MCPBValidator {
+	return &MCPBValidator{
+		registries: newRegistrySet(model.RegistryTypeMCPB, "", cfg),
+		signatures: cfg.MCPBSignatureVerifier,
+	}
+}
+
+// ValidateMCPB validates an MCPB bundle without a signature verifier, see MCPBValidator.ValidatePackage
+func ValidateMCPB(ctx context.Context, pkg model.Package, serverName, serverVersion string) error {
+	return NewMCPBValidator(Config{}).ValidatePackage(ctx, pkg, serverName, serverVersion)
+}
+
+// ValidatePackage validates that an MCPB bundle is hosted on an allowed provider, matches its
+// fileSha256 hash, has a manifest.json whose name and version match the server, and is owned
+// by the publisher: either the manifest declares the server name as mcp_name, or a detached
+// signature at '<url>.sig' verifies with the publisher's registered key
+func (v *MCPBValidator) ValidatePackage(ctx context.Context, pkg model.Package, serverName, serverVersion string) error {
 	// MCPB packages must include a file hash for integrity verification
 	if pkg.FileSHA256 == "" {
 		return ErrMissingFileSHA256ForMCPB
@@ -55,28 +103,165 @@ func ValidateMCPB(ctx context.Context, pkg model.Package, _ string) error {
 		return fmt.Errorf("MCPB package URL must contain 'mcp': %s", pkg.Identifier)
 	}
 
-	// Verify the file exists and is publicly accessible
-	client := &http.Client{Timeout: 10 * time.Second}
-	req, err := http.NewRequestWithContext(ctx, http.MethodHead, pkg.Identifier, nil)
+	// Download the bundle to verify its hash and manifest, allowing for large bundles
+	return v.verifyBundle(ctx, v.bundleClient(), pkg.Identifier, pkg.FileSHA256, serverName, serverVersion)
+}
+
+// VerifyIntegrity checks that an MCPB bundle is still reachable and matches its fileSha256 hash,
+// without the manifest and ownership checks of ValidatePackage. It re-validates bundles published
+// before those checks applied.
+func (v *MCPBValidator) VerifyIntegrity(ctx context.Context, pkg model.Package) error {
+	if pkg.FileSHA256 == "" {
+		return ErrMissingFileSHA256ForMCPB
+	}
+	if pkg.Identifier == "" {
+		return ErrMissingIdentifierForMCPB
+	}
+
+	return downloadBundle(ctx, v.bundleClient(), pkg.Identifier, pkg.FileSHA256, nil)
+}
+
+// bundleClient returns the validator's HTTP client with a timeout allowing for large bundles
+func (v *MCPBValidator) bundleClient() *http.Client {
+	client := v.registries.httpClient()
+	client.Timeout = max(client.Timeout, 2*time.Minute)
+	return client
+}
+
+// verifyBundle downloads a bundle, checks its SHA-256 hash, checks that its manifest.json
+// is consistent with the server name and version, and checks ownership
+func (v *MCPBValidator) verifyBundle(ctx context.Context, client *http.Client, bundleURL, fileSHA256, serverName, serverVersion string) error {
+	return downloadBundle(ctx, client, bundleURL, fileSHA256, func(bundle *os.File, size int64, digest string) error {
+		manifest, err := readMCPBManifest(bundle, size)
+		if err != nil {
+			return err
+		}
+
+		// The manifest name is usually the last part of the server name, e.g. "weather" for "com.acme/weather"
+		if manifest.Name != serverName && manifest.Name != path.Base(serverName) {
+			return fmt.Errorf("MCPB manifest name '%s' does not match server name '%s'", manifest.Name, serverName)
+		}
+		if strings.TrimPrefix(manifest.Version, "v") != strings.TrimPrefix(serverVersion, "v") {
+			return fmt.Errorf("MCPB manifest version '%s' does not match server version '%s'", manifest.Version, serverVersion)
+		}
+
+		// Check the declared server name, like mcpName in package.json
+		if manifest.MCPName != "" {
+			if manifest.MCPName != serverName {
+				return fmt.Errorf("MCPB package ownership validation failed. Expected manifest.json 'mcp_name' '%s', got '%s'", serverName, manifest.MCPName)
+			}
+			return nil
+		}
+
+		return v.verifySignature(ctx, client, bundleURL, digest, serverName)
+	})
+}
+
+// downloadBundle downloads a bundle to a temporary file, checks its SHA-256 hash, and then passes
+// the file, its size and its hex digest to check, if set
+func downloadBundle(ctx context.Context, client *http.Client, bundleURL, fileSHA256 string, check func(bundle *os.File, size int64, digest string) error) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, bundleURL, nil)
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", err)
 	}
 
-	req.Header.Set("User-Agent", "MCP-Registry-Validator/1.0")
-
 	resp, err := client.Do(req)
 	if err != nil {
-		return fmt.Errorf("failed to verify MCPB package accessibility: %w", err)
+		return fmt.Errorf("failed to download MCPB package: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("MCPB package '%s' is not publicly accessible (status: %d)", pkg.Identifier, resp.StatusCode)
+		return fmt.Errorf("MCPB package '%s' is not publicly accessible (status: %d)", bundleURL, resp.StatusCode)
 	}
 
+	// Stream the bundle to a temporary file while hashing it, so large bundles are not held in memory
+	file, err := os.CreateTemp("", "mcpb-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(file, hash), io.LimitReader(resp.Body, maxMCPBSize+1))
+	if err != nil {
+		return fmt.Errorf("failed to download MCPB package: %w", err)
+	}
+	if size > maxMCPBSize {
+		return fmt.Errorf("MCPB package '%s' exceeds the maximum size of %d MB", bundleURL, maxMCPBSize>>20)
+	}
+
+	digest := hex.EncodeToString(hash.Sum(nil))
+	if !strings.EqualFold(digest, fileSHA256) {
+		return fmt.Errorf("MCPB package file hash mismatch: fileSha256 is '%s' but the downloaded file hashes to '%s'", fileSHA256, digest)
+	}
+
+	if check == nil {
+		return nil
+	}
+	return check(file, size, digest)
+}
+
+// mcpbSignaturePrefix starts the message signed for a bundle, so a signature cannot be replayed
+// as a login signature or for another server
+const mcpbSignaturePrefix = "mcp-registry-mcpb-v1:"
+
+// verifySignature checks the detached signature published next to the bundle. The signature is
+// over "mcp-registry-mcpb-v1:<server name>:<lowercase hex SHA-256 of the bundle>", hex-encoded like
+// the signatures used to log in.
+func (v *MCPBValidator) verifySignature(ctx context.Context, client *http.Client, bundleURL, digest, serverName string) error {
+	ownershipErr := fmt.Errorf("MCPB package ownership validation failed. Add \"mcp_name\": \"%s\" to manifest.json, or publish a signature of 'mcp-registry-mcpb-v1:%s:<bundle SHA-256>' made with your registered key at '%s.sig'", serverName, serverName, bundleURL)
+
+	sigData, err := fetchLimited(ctx, client, PrivateRegistry{}, bundleURL+".sig", 4<<10)
+	if err != nil {
+		return ownershipErr
+	}
+	signature, err := hex.DecodeString(strings.Join(strings.Fields(string(sigData)), ""))
+	if err != nil {
+		return fmt.Errorf("invalid MCPB signature at '%s.sig', must be hex: %w", bundleURL, err)
+	}
+
+	if v.signatures == nil {
+		return ownershipErr
+	}
+
+	message := mcpbSignaturePrefix + serverName + ":" + digest
+	if err := v.signatures(ctx, serverName, []byte(message), signature); err != nil {
+		return fmt.Errorf("MCPB package ownership validation failed. Signature at '%s.sig' could not be verified: %w", bundleURL, err)
+	}
 	return nil
 }
 
+// readMCPBManifest reads manifest.json from the root of a bundle
+func readMCPBManifest(r io.ReaderAt, size int64) (MCPBManifest, error) {
+	var manifest MCPBManifest
+
+	archive, err := zip.NewReader(r, size)
+	if err != nil {
+		return manifest, fmt.Errorf("MCPB package is not a valid zip archive: %w", err)
+	}
+
+	f, err := archive.Open("manifest.json")
+	if err != nil {
+		return manifest, fmt.Errorf("MCPB package must contain a manifest.json at its root")
+	}
+	defer f.Close()
+
+	data, err := io.ReadAll(io.LimitReader(f, maxMCPBManifestSize))
+	if err != nil {
+		return manifest, fmt.Errorf("failed to read MCPB manifest.json: %w", err)
+	}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return manifest, fmt.Errorf("failed to parse MCPB manifest.json: %w", err)
+	}
+	if manifest.Name == "" || manifest.Version == "" {
+		return manifest, fmt.Errorf("MCPB manifest.json must include 'name' and 'version'")
+	}
+
+	return manifest, nil
+}
+
 func validateMCPBUrl(fullURL string) error {
 	parsedURL, err := url.Parse(fullURL)
 	if err != nil {
@@ -0,0 +1,263 @@
+package registries_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/validators/registries"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// mcpbBundle builds a bundle with the given files and returns it with its SHA-256 hash
+func mcpbBundle(t *testing.T, files map[string]string) ([]byte, string) {
+	t.Helper()
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for name, content := range files {
+		f, err := w.Create(name)
+		require.NoError(t, err)
+		_, err = f.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	sum := sha256.Sum256(buf.Bytes())
+	return buf.Bytes(), hex.EncodeToString(sum[:])
+}
+
+func TestVerifyMCPBBundle(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	valid, validHash := mcpbBundle(t, map[string]string{
+		"manifest.json":   `{"manifest_version":"0.2","name":"weather","version":"1.0.0","mcp_name":"com.acme/weather"}`,
+		"server/index.js": "console.log('weather')",
+	})
+	fullName, fullNameHash := mcpbBundle(t, map[string]string{
+		"manifest.json": `{"name":"com.acme/weather","version":"v1.0.0","mcp_name":"com.acme/weather"}`,
+	})
+	otherOwner, otherOwnerHash := mcpbBundle(t, map[string]string{
+		"manifest.json": `{"name":"weather","version":"1.0.0","mcp_name":"com.other/weather"}`,
+	})
+	signed, signedHash := mcpbBundle(t, map[string]string{
+		"manifest.json": `{"name":"weather","version":"1.0.0"}`,
+	})
+	unsigned, unsignedHash := mcpbBundle(t, map[string]string{
+		"manifest.json": `{"name":"weather","version":"2.0.0"}`,
+	})
+	badlySigned, badlySignedHash := mcpbBundle(t, map[string]string{
+		"manifest.json": `{"name":"weather","version":"3.0.0"}`,
+	})
+
+	// Detached signatures are over the server name and the hex SHA-256 digest of the bundle
+	signatureOf := func(key ed25519.PrivateKey, serverName, hash string) []byte {
+		return []byte(hex.EncodeToString(ed25519.Sign(key, []byte("mcp-registry-mcpb-v1:"+serverName+":"+hash))))
+	}
+	publicKey, privateKey, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	_, otherKey, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	verifySignature := func(_ context.Context, serverName string, message, signature []byte) error {
+		if serverName != "com.acme/weather" || !ed25519.Verify(publicKey, message, signature) {
+			return errors.New("signature verification failed")
+		}
+		return nil
+	}
+	noManifest, noManifestHash := mcpbBundle(t, map[string]string{
+		"server/manifest.json": `{"name":"weather","version":"1.0.0"}`,
+	})
+	noVersion, noVersionHash := mcpbBundle(t, map[string]string{
+		"manifest.json": `{"name":"weather"}`,
+	})
+	notZip := []byte("not a zip archive")
+	notZipSum := sha256.Sum256(notZip)
+
+	bundles := map[string][]byte{
+		"/valid.mcpb":            valid,
+		"/full-name.mcpb":        fullName,
+		"/no-manifest.mcpb":      noManifest,
+		"/no-version.mcpb":       noVersion,
+		"/not-zip.mcpb":          notZip,
+		"/other-owner.mcpb":      otherOwner,
+		"/signed.mcpb":           signed,
+		"/signed.mcpb.sig":       append(signatureOf(privateKey, "com.acme/weather", signedHash), '\n'),
+		"/unsigned.mcpb":         unsigned,
+		"/badly-signed.mcpb":     badlySigned,
+		"/badly-signed.mcpb.sig": signatureOf(otherKey, "com.acme/weather", badlySignedHash),
+		"/bare-hash.mcpb":        unsigned,
+		"/bare-hash.mcpb.sig":    []byte(hex.EncodeToString(ed25519.Sign(privateKey, []byte(unsignedHash)))),
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, ok := bundles[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(data)
+	}))
+	t.Cleanup(server.Close)
+	validator := registries.NewMCPBValidator(registries.Config{Client: server.Client(), MCPBSignatureVerifier: verifySignature})
+
+	tests := []struct {
+		name          string
+		path          string
+		fileSHA256    string
+		serverName    string
+		serverVersion string
+		errorMessage  string
+	}{
+		{
+			name:          "matching hash and manifest should pass",
+			path:          "/valid.mcpb",
+			fileSHA256:    validHash,
+			serverName:    "com.acme/weather",
+			serverVersion: "1.0.0",
+		},
+		{
+			name:          "manifest with full server name should pass",
+			path:          "/full-name.mcpb",
+			fileSHA256:    fullNameHash,
+			serverName:    "com.acme/weather",
+			serverVersion: "1.0.0",
+		},
+		{
+			name:          "different mcp_name in manifest should fail",
+			path:          "/other-owner.mcpb",
+			fileSHA256:    otherOwnerHash,
+			serverName:    "com.acme/weather",
+			serverVersion: "1.0.0",
+			errorMessage:  "Expected manifest.json 'mcp_name' 'com.acme/weather', got 'com.other/weather'",
+		},
+		{
+			name:          "detached signature with registered key should pass",
+			path:          "/signed.mcpb",
+			fileSHA256:    signedHash,
+			serverName:    "com.acme/weather",
+			serverVersion: "1.0.0",
+		},
+		{
+			name:          "detached signature for another namespace should fail",
+			path:          "/signed.mcpb",
+			fileSHA256:    signedHash,
+			serverName:    "com.other/weather",
+			serverVersion: "1.0.0",
+			errorMessage:  "could not be verified",
+		},
+		{
+			name:          "detached signature with another key should fail",
+			path:          "/badly-signed.mcpb",
+			fileSHA256:    badlySignedHash,
+			serverName:    "com.acme/weather",
+			serverVersion: "3.0.0",
+			errorMessage:  "could not be verified",
+		},
+		{
+			name:          "signature over the bare hash should fail",
+			path:          "/bare-hash.mcpb",
+			fileSHA256:    unsignedHash,
+			serverName:    "com.acme/weather",
+			serverVersion: "2.0.0",
+			errorMessage:  "could not be verified",
+		},
+		{
+			name:          "bundle without mcp_name or signature should fail",
+			path:          "/unsigned.mcpb",
+			fileSHA256:    unsignedHash,
+			serverName:    "com.acme/weather",
+			serverVersion: "2.0.0",
+			errorMessage:  "Add \"mcp_name\": \"com.acme/weather\" to manifest.json",
+		},
+		{
+			name:          "wrong hash should fail",
+			path:          "/valid.mcpb",
+			fileSHA256:    fullNameHash,
+			serverName:    "com.acme/weather",
+			serverVersion: "1.0.0",
+			errorMessage:  "file hash mismatch",
+		},
+		{
+			name:          "different manifest name should fail",
+			path:          "/valid.mcpb",
+			fileSHA256:    validHash,
+			serverName:    "com.acme/forecast",
+			serverVersion: "1.0.0",
+			errorMessage:  "MCPB manifest name 'weather' does not match server name 'com.acme/forecast'",
+		},
+		{
+			name:          "different manifest version should fail",
+			path:          "/valid.mcpb",
+			fileSHA256:    validHash,
+			serverName:    "com.acme/weather",
+			serverVersion: "1.0.1",
+			errorMessage:  "MCPB manifest version '1.0.0' does not match server version '1.0.1'",
+		},
+		{
+			name:          "manifest outside bundle root should fail",
+			path:          "/no-manifest.mcpb",
+			fileSHA256:    noManifestHash,
+			serverName:    "com.acme/weather",
+			serverVersion: "1.0.0",
+			errorMessage:  "must contain a manifest.json at its root",
+		},
+		{
+			name:          "manifest without version should fail",
+			path:          "/no-version.mcpb",
+			fileSHA256:    noVersionHash,
+			serverName:    "com.acme/weather",
+			serverVersion: "1.0.0",
+			errorMessage:  "must include 'name' and 'version'",
+		},
+		{
+			name:          "file that is not a zip archive should fail",
+			path:          "/not-zip.mcpb",
+			fileSHA256:    hex.EncodeToString(notZipSum[:]),
+			serverName:    "com.acme/weather",
+			serverVersion: "1.0.0",
+			errorMessage:  "not a valid zip archive",
+		},
+		{
+			name:          "missing file should fail",
+			path:          "/missing.mcpb",
+			fileSHA256:    validHash,
+			serverName:    "com.acme/weather",
+			serverVersion: "1.0.0",
+			errorMessage:  "not publicly accessible",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := validator.VerifyBundle(ctx, server.URL+tt.path, tt.fileSHA256, tt.serverName, tt.serverVersion)
+			if tt.errorMessage != "" {
+				assert.ErrorContains(t, err, tt.errorMessage)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+
+	t.Run("integrity checks skip the manifest and ownership", func(t *testing.T) {
+		t.Parallel()
+
+		bundle := func(path, fileSHA256 string) model.Package {
+			return model.Package{RegistryType: model.RegistryTypeMCPB, Identifier: server.URL + path, FileSHA256: fileSHA256}
+		}
+		assert.NoError(t, validator.VerifyIntegrity(ctx, bundle("/unsigned.mcpb", unsignedHash)))
+		assert.NoError(t, validator.VerifyIntegrity(ctx, bundle("/not-zip.mcpb", hex.EncodeToString(notZipSum[:]))))
+		assert.ErrorContains(t, validator.VerifyIntegrity(ctx, bundle("/unsigned.mcpb", validHash)), "hash mismatch")
+		assert.ErrorContains(t, validator.VerifyIntegrity(ctx, bundle("/missing.mcpb", validHash)), "not publicly accessible")
+	})
+}
@@ -45,18 +45,20 @@ func TestValidateMCPB(t *testing.T) {
 			errorMessage: "must include a fileSha256 hash for integrity verification",
 		},
 		{
-			name:        "valid MCPB package should pass",
-			packageName: "https://github.com/domdomegg/airtable-mcp-server/releases/download/v1.7.2/airtable-mcp-server.mcpb",
-			serverName:  "io.github.domdomegg/airtable-mcp-server",
-			fileSHA256:  "fe333e598595000ae021bd27117db32ec69af6987f507ba7a63c90638ff633ce",
-			expectError: false,
+			name:         "MCPB package without mcp_name or signature should fail ownership check",
+			packageName:  "https://github.com/domdomegg/airtable-mcp-server/releases/download/v1.7.2/airtable-mcp-server.mcpb",
+			serverName:   "io.github.domdomegg/airtable-mcp-server",
+			fileSHA256:   "fe333e598595000ae021bd27117db32ec69af6987f507ba7a63c90638ff633ce",
+			expectError:  true,
+			errorMessage: "ownership validation failed",
 		},
 		{
-			name:        "valid MCPB package should pass",
-			packageName: "https://github.com/microsoft/playwright-mcp/releases/download/v0.0.36/playwright-mcp-extension-v0.0.36.zip",
-			serverName:  "com.microsoft/playwright-mcp",
-			fileSHA256:  "abc123ef4567890abcdef1234567890abcdef1234567890abcdef1234567890",
-			expectError: false,
+			name:         "MCPB package with wrong file hash should fail",
+			packageName:  "https://github.com/microsoft/playwright-mcp/releases/download/v0.0.36/playwright-mcp-extension-v0.0.36.zip",
+			serverName:   "com.microsoft/playwright-mcp",
+			fileSHA256:   "abc123ef4567890abcdef1234567890abcdef1234567890abcdef1234567890",
+			expectError:  true,
+			errorMessage: "file hash mismatch",
 		},
 		{
 			name:         "MCPB package without file hash should fail",
@@ -108,7 +110,7 @@ func TestValidateMCPB(t *testing.T) {
 				FileSHA256:   tt.fileSHA256,
 			}
 
-			err := registries.ValidateMCPB(ctx, pkg, tt.serverName)
+			err := registries.ValidateMCPB(ctx, pkg, tt.serverName, "1.7.2")
 
 			if tt.expectError {
 				assert.Error(t, err)
@@ -172,7 +174,7 @@ func TestValidateMCPB_RejectsExtraFields(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := registries.ValidateMCPB(ctx, tt.pkg, "com.example/test")
+			err := registries.ValidateMCPB(ctx, tt.pkg, "com.example/test", "1.0.0")
 
 			if tt.errorMessage != "" {
 				assert.Error(t, err)
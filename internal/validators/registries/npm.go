@@ -2,12 +2,17 @@ package registries
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
-	"time"
+	"strings"
+
+	"github.com/sigstore/sigstore-go/pkg/bundle"
+	"github.com/sigstore/sigstore-go/pkg/root"
+	"github.com/sigstore/sigstore-go/pkg/verify"
 
 	"github.com/modelcontextprotocol/registry/pkg/model"
 )
@@ -22,8 +27,27 @@ type NPMPackageResponse struct {
 	MCPName string `json:"mcpName"`
 }
 
-// ValidateNPM validates that an NPM package contains the correct MCP server name
+// NPMValidator validates NPM packages and verifies their provenance
+type NPMValidator struct {
+	registries registrySet
+	sigstore   root.TrustedMaterial
+}
+
+// NewNPMValidator returns a validator for NPM packages
+func NewNPMValidator(cfg Config) *NPMValidator {
+	return &NPMValidator{
+		registries: newRegistrySet(model.RegistryTypeNPM, model.RegistryURLNPM, cfg),
+		sigstore:   cfg.SigstoreTrustedMaterial,
+	}
+}
+
+// ValidateNPM validates an NPM package on the public registry, see NPMValidator.ValidatePackage
 func ValidateNPM(ctx context.Context, pkg model.Package, serverName string) error {
+	return NewNPMValidator(Config{}).ValidatePackage(ctx, pkg, serverName, "")
+}
+
+// ValidatePackage validates that an NPM package contains the correct MCP server name
+func (v *NPMValidator) ValidatePackage(ctx context.Context, pkg model.Package, serverName, _ string) error {
 	// Set default registry base URL if empty
 	if pkg.RegistryBaseURL == "" {
 		pkg.RegistryBaseURL = model.RegistryURLNPM
@@ -46,21 +70,21 @@ func ValidateNPM(ctx context.Context, pkg model.Package, serverName string) erro
 		return fmt.Errorf("NPM packages must not have 'fileSha256' field")
 	}
 
-	// Validate that the registry base URL matches NPM exactly
-	if pkg.RegistryBaseURL != model.RegistryURLNPM {
-		return fmt.Errorf("registry type and base URL do not match: '%s' is not valid for registry type '%s'. Expected: %s",
-			pkg.RegistryBaseURL, model.RegistryTypeNPM, model.RegistryURLNPM)
+	// Validate that the registry base URL is the public registry or an allowed private registry
+	registry, err := v.registries.resolve(pkg.RegistryBaseURL)
+	if err != nil {
+		return err
 	}
 
-	client := &http.Client{Timeout: 10 * time.Second}
+	client := v.registries.httpClient()
 
-	requestURL := pkg.RegistryBaseURL + "/" + url.PathEscape(pkg.Identifier) + "/" + url.PathEscape(pkg.Version)
+	requestURL := registry.BaseURL + "/" + url.PathEscape(pkg.Identifier) + "/" + url.PathEscape(pkg.Version)
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", err)
 	}
 
-	req.Header.Set("User-Agent", "MCP-Registry-Validator/1.0")
+	registry.authorize(req)
 	req.Header.Set("Accept", "application/json")
 
 	resp, err := client.Do(req)
@@ -88,3 +112,80 @@ func ValidateNPM(ctx context.Context, pkg model.Package, serverName string) erro
 
 	return nil
 }
+
+// npmDistResponse holds the integrity of a package version's tarball from the NPM registry API
+type npmDistResponse struct {
+	Dist struct {
+		Integrity string `json:"integrity"`
+	} `json:"dist"`
+}
+
+// npmAttestationsResponse represents the structure returned by the NPM attestations API
+type npmAttestationsResponse struct {
+	Attestations []struct {
+		PredicateType string          `json:"predicateType"`
+		Bundle        json.RawMessage `json:"bundle"`
+	} `json:"attestations"`
+}
+
+// VerifyProvenance verifies that an NPM package version has a SLSA provenance attestation,
+// signed through Sigstore, for its tarball being built by a workflow in repositoryURL
+func (v *NPMValidator) VerifyProvenance(ctx context.Context, pkg model.Package, repositoryURL string) error {
+	// Set default registry base URL if empty
+	if pkg.RegistryBaseURL == "" {
+		pkg.RegistryBaseURL = model.RegistryURLNPM
+	}
+
+	registry, err := v.registries.resolve(pkg.RegistryBaseURL)
+	if err != nil {
+		return err
+	}
+
+	client := v.registries.httpClient()
+	packageURL := registry.BaseURL + "/" + url.PathEscape(pkg.Identifier) + "/" + url.PathEscape(pkg.Version)
+
+	// Attestations name the tarball by its SHA-512 digest, which the registry publishes as its integrity
+	data, err := fetchLimited(ctx, client, registry, packageURL, maxProvenanceSize)
+	if err != nil {
+		return fmt.Errorf("failed to fetch NPM package metadata: %w", err)
+	}
+	var distResp npmDistResponse
+	if err := json.Unmarshal(data, &distResp); err != nil {
+		return fmt.Errorf("failed to parse NPM package metadata: %w", err)
+	}
+	algorithm, encodedDigest, _ := strings.Cut(distResp.Dist.Integrity, "-")
+	tarballDigest, err := base64.StdEncoding.DecodeString(encodedDigest)
+	if algorithm != "sha512" || err != nil {
+		return fmt.Errorf("NPM package '%s@%s' has no SHA-512 integrity", pkg.Identifier, pkg.Version)
+	}
+
+	attestationsURL := registry.BaseURL + "/-/npm/v1/attestations/" + url.PathEscape(pkg.Identifier) + "@" + url.PathEscape(pkg.Version)
+	data, err = fetchLimited(ctx, client, registry, attestationsURL, maxProvenanceSize)
+	if err != nil {
+		return fmt.Errorf("NPM package '%s@%s' has no provenance attestations: %w", pkg.Identifier, pkg.Version, err)
+	}
+	var attestationsResp npmAttestationsResponse
+	if err := json.Unmarshal(data, &attestationsResp); err != nil {
+		return fmt.Errorf("failed to parse NPM attestations: %w", err)
+	}
+
+	verifyErr := errors.New("no SLSA provenance attestation")
+	for _, attestation := range attestationsResp.Attestations {
+		if !strings.HasPrefix(attestation.PredicateType, "https://slsa.dev/provenance/") {
+			continue
+		}
+		var b bundle.Bundle
+		if err := b.UnmarshalJSON(attestation.Bundle); err != nil {
+			verifyErr = fmt.Errorf("invalid provenance bundle: %w", err)
+			continue
+		}
+		// The attestation must name the tarball by its digest
+		if _, err := verifySigstoreEntity(v.sigstore, &b, verify.WithArtifactDigest("sha512", tarballDigest), repositoryURL); err != nil {
+			verifyErr = err
+			continue
+		}
+		return nil
+	}
+
+	return fmt.Errorf("NPM package '%s@%s' provenance verification failed: %w", pkg.Identifier, pkg.Version, verifyErr)
+}
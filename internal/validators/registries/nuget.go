@@ -7,7 +7,6 @@ import (
 	"io"
 	"net/http"
 	"strings"
-	"time"
 
 	"github.com/modelcontextprotocol/registry/pkg/model"
 )
@@ -17,8 +16,23 @@ var (
 	ErrMissingVersionForNuget    = errors.New("package version is required for NuGet packages")
 )
 
-// ValidateNuGet validates that a NuGet package contains the correct MCP server name
+// NuGetValidator validates NuGet packages
+type NuGetValidator struct {
+	registries registrySet
+}
+
+// NewNuGetValidator returns a validator for NuGet packages
+func NewNuGetValidator(cfg Config) *NuGetValidator {
+	return &NuGetValidator{registries: newRegistrySet(model.RegistryTypeNuGet, model.RegistryURLNuGet, cfg)}
+}
+
+// ValidateNuGet validates a NuGet package on the public registry, see NuGetValidator.ValidatePackage
 func ValidateNuGet(ctx context.Context, pkg model.Package, serverName string) error {
+	return NewNuGetValidator(Config{}).ValidatePackage(ctx, pkg, serverName, "")
+}
+
+// ValidatePackage validates that a NuGet package contains the correct MCP server name
+func (v *NuGetValidator) ValidatePackage(ctx context.Context, pkg model.Package, serverName, _ string) error {
 	// Set default registry base URL if empty
 	if pkg.RegistryBaseURL == "" {
 		pkg.RegistryBaseURL = model.RegistryURLNuGet
@@ -33,13 +47,13 @@ func ValidateNuGet(ctx context.Context, pkg model.Package, serverName string) er
 		return fmt.Errorf("NuGet packages must not have 'fileSha256' field - this is only for MCPB packages")
 	}
 
-	// Validate that the registry base URL matches NuGet exactly
-	if pkg.RegistryBaseURL != model.RegistryURLNuGet {
-		return fmt.Errorf("registry type and base URL do not match: '%s' is not valid for registry type '%s'. Expected: %s",
-			pkg.RegistryBaseURL, model.RegistryTypeNuGet, model.RegistryURLNuGet)
+	// Validate that the registry base URL is the public registry or an allowed private registry
+	registry, err := v.registries.resolve(pkg.RegistryBaseURL)
+	if err != nil {
+		return err
 	}
 
-	client := &http.Client{Timeout: 10 * time.Second}
+	client := v.registries.httpClient()
 
 	lowerID := strings.ToLower(pkg.Identifier)
 	lowerVersion := strings.ToLower(pkg.Version)
@@ -48,13 +62,13 @@ func ValidateNuGet(ctx context.Context, pkg model.Package, serverName string) er
 	}
 
 	// Try to get README from the package
-	readmeURL := fmt.Sprintf("%s/v3-flatcontainer/%s/%s/readme", pkg.RegistryBaseURL, lowerID, lowerVersion)
+	readmeURL := fmt.Sprintf("%s/v3-flatcontainer/%s/%s/readme", registry.BaseURL, lowerID, lowerVersion)
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, readmeURL, nil)
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", err)
 	}
 
-	req.Header.Set("User-Agent", "MCP-Registry-Validator/1.0")
+	registry.authorize(req)
 
 	resp, err := client.Do(req)
 	if err != nil {
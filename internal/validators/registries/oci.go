@@ -2,12 +2,19 @@ package registries
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
-	"time"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/sigstore/sigstore-go/pkg/root"
 
 	"github.com/modelcontextprotocol/registry/pkg/model"
 )
@@ -17,47 +24,84 @@ var (
 	ErrMissingVersionForOCI    = errors.New("package version is required for OCI packages")
 )
 
-const (
-	dockerIoAPIBaseURL = "https://registry-1.docker.io"
-	ghcrAPIBaseURL     = "https://ghcr.io"
-)
+const dockerIoAPIBaseURL = "https://registry-1.docker.io"
+
+// maxManifestSize bounds manifests read from registries, which limit them to 4 MiB
+const maxManifestSize = 4 << 20
+
+// publicOCIRegistries are the registry hosts accepted without configuration, as path.Match patterns.
+// Other hosts, such as self-hosted registries, are allowed through the package registries config.
+var publicOCIRegistries = []string{
+	"docker.io",
+	"ghcr.io",
+	"quay.io",
+	"public.ecr.aws",
+	"gcr.io",
+	"*.gcr.io",
+	"*-docker.pkg.dev",
+}
 
 // ErrRateLimited is returned when a registry rate limits our requests
 var ErrRateLimited = errors.New("rate limited by registry")
 
-// OCIAuthResponse represents an OCI registry authentication response
+// OCIAuthResponse represents an OCI registry token response. Registries return
+// the token as either token or access_token.
 type OCIAuthResponse struct {
-	Token string `json:"token"`
+	Token       string `json:"token"`
+	AccessToken string `json:"access_token"`
 }
 
-// RegistryConfig holds configuration for different OCI registries
+// RegistryConfig holds the connection details for an OCI Distribution v2 registry.
+// Authentication is discovered from the registry's WWW-Authenticate challenge.
 type RegistryConfig struct {
 	APIBaseURL string
-	AuthURL    string
-	Service    string
-	Scope      string
+	// Scope is the token scope requested if the challenge does not name one
+	Scope string
+
+	credentials PrivateRegistry
+	// authorization is the Authorization header that satisfied the last challenge
+	authorization string
+}
+
+// OCIValidator validates OCI images and verifies their signatures
+type OCIValidator struct {
+	registries registrySet
+	sigstore   root.TrustedMaterial
 }
 
-// getRegistryConfig returns the configuration for a specific registry
-func getRegistryConfig(registryBaseURL, namespace, repo string) *RegistryConfig {
-	switch registryBaseURL {
-	case model.RegistryURLDocker:
-		return &RegistryConfig{
-			APIBaseURL: dockerIoAPIBaseURL,
-			AuthURL:    "https://auth.docker.io/token",
-			Service:    "registry.docker.io",
-			Scope:      fmt.Sprintf("repository:%s/%s:pull", namespace, repo),
+// NewOCIValidator returns a validator for OCI images
+func NewOCIValidator(cfg Config) *OCIValidator {
+	return &OCIValidator{
+		registries: newRegistrySet(model.RegistryTypeOCI, model.RegistryURLDocker, cfg),
+		sigstore:   cfg.SigstoreTrustedMaterial,
+	}
+}
+
+// registryConfig returns the configuration for the registry of the reference,
+// or nil if the registry is not allowed
+func (v *OCIValidator) registryConfig(ref *OCIReference) *RegistryConfig {
+	scope := fmt.Sprintf("repository:%s:pull", ref.Repository())
+
+	if ref.GetRegistryBaseURL() == model.RegistryURLDocker {
+		return &RegistryConfig{APIBaseURL: dockerIoAPIBaseURL, Scope: scope}
+	}
+
+	// Configured registries come first, so that credentials are used for private repositories
+	// on hosts that also serve public images, such as Artifact Registry or Quay
+	for _, registry := range v.registries.private {
+		// Self-hosted registries may be served over plain HTTP, so the configured base URL is used as is
+		if u, err := url.Parse(registry.BaseURL); err == nil && u.Host == ref.Registry {
+			return &RegistryConfig{APIBaseURL: registry.BaseURL, Scope: scope, credentials: registry}
 		}
-	case model.RegistryURLGHCR:
-		return &RegistryConfig{
-			APIBaseURL: ghcrAPIBaseURL,
-			AuthURL:    fmt.Sprintf("%s/token", ghcrAPIBaseURL),
-			Service:    "ghcr.io",
-			Scope:      fmt.Sprintf("repository:%s/%s:pull", namespace, repo),
+	}
+
+	for _, pattern := range publicOCIRegistries {
+		if matched, _ := path.Match(pattern, ref.Registry); matched {
+			return &RegistryConfig{APIBaseURL: "https://" + ref.Registry, Scope: scope}
 		}
-	default:
-		return nil
 	}
+
+	return nil
 }
 
 // OCIManifest represents an OCI image manifest
@@ -68,6 +112,14 @@ type OCIManifest struct {
 	Config struct {
 		Digest string `json:"digest"`
 	} `json:"config,omitempty"`
+	Layers []OCIDescriptor `json:"layers,omitempty"`
+}
+
+// OCIDescriptor describes content in an OCI registry, such as an image layer
+type OCIDescriptor struct {
+	MediaType   string            `json:"mediaType"`
+	Digest      string            `json:"digest"`
+	Annotations map[string]string `json:"annotations,omitempty"`
 }
 
 // OCIImageConfig represents an OCI image configuration
@@ -77,13 +129,18 @@ type OCIImageConfig struct {
 	} `json:"config"`
 }
 
-// ValidateOCI validates that an OCI image contains the correct MCP server name annotation.
+// ValidateOCI validates an OCI image on a public registry, see OCIValidator.ValidatePackage
+func ValidateOCI(ctx context.Context, pkg model.Package, serverName string) error {
+	return NewOCIValidator(Config{}).ValidatePackage(ctx, pkg, serverName, "")
+}
+
+// ValidatePackage validates that an OCI image contains the correct MCP server name annotation.
 // Supports canonical OCI references including:
 //   - registry/namespace/image:tag
 //   - registry/namespace/image@sha256:digest
 //   - registry/namespace/image:tag@sha256:digest
 //   - namespace/image:tag (defaults to docker.io)
-func ValidateOCI(ctx context.Context, pkg model.Package, serverName string) error {
+func (v *OCIValidator) ValidatePackage(ctx context.Context, pkg model.Package, serverName, _ string) error {
 	if pkg.Identifier == "" {
 		return ErrMissingIdentifierForOCI
 	}
@@ -106,19 +163,14 @@ func ValidateOCI(ctx context.Context, pkg model.Package, serverName string) erro
 	}
 
 	// Validate that the registry is supported
-	registryBaseURL := ociRef.GetRegistryBaseURL()
-	if err := validateRegistryURL(registryBaseURL); err != nil {
-		return err
-	}
-
-	client := &http.Client{Timeout: 10 * time.Second}
-
-	// Get registry configuration
-	registryConfig := getRegistryConfig(registryBaseURL, ociRef.Namespace, ociRef.Image)
+	registryConfig := v.registryConfig(ociRef)
 	if registryConfig == nil {
-		return fmt.Errorf("unsupported registry: %s", registryBaseURL)
+		return unsupportedOCIRegistryError(ociRef)
 	}
 
+	client := v.registries.httpClient()
+	repository := ociRef.Repository()
+
 	// Determine what to use for manifest lookup: digest if available (most secure), otherwise tag
 	manifestRef := ociRef.Tag
 	if ociRef.Digest != "" {
@@ -126,7 +178,7 @@ func ValidateOCI(ctx context.Context, pkg model.Package, serverName string) erro
 	}
 
 	// Get the image manifest
-	manifest, err := fetchImageManifest(ctx, client, registryConfig, ociRef.Namespace, ociRef.Image, manifestRef)
+	manifest, _, err := fetchImageManifest(ctx, client, registryConfig, repository, manifestRef)
 	if err != nil {
 		// Handle rate limiting explicitly - skip validation
 		if errors.Is(err, ErrRateLimited) {
@@ -137,76 +189,88 @@ func ValidateOCI(ctx context.Context, pkg model.Package, serverName string) erro
 	}
 
 	// Get config digest from manifest
-	configDigest, err := getConfigDigestFromManifest(ctx, client, registryConfig, ociRef.Namespace, ociRef.Image, manifest)
+	configDigest, err := getConfigDigestFromManifest(ctx, client, registryConfig, repository, manifest)
 	if err != nil {
 		return err
 	}
 
 	// Validate server name annotation
-	return validateServerNameAnnotation(ctx, client, registryConfig, ociRef.Namespace, ociRef.Image, ociRef.Tag, configDigest, serverName)
+	return validateServerNameAnnotation(ctx, client, registryConfig, repository, ociRef.Tag, configDigest, serverName)
 }
 
-// validateRegistryURL validates that the registry base URL is supported
-func validateRegistryURL(registryURL string) error {
-	if registryURL != model.RegistryURLDocker && registryURL != model.RegistryURLGHCR {
-		return fmt.Errorf("registry type and base URL do not match: '%s' is not valid for registry type '%s'. Expected: %s or %s",
-			registryURL, model.RegistryTypeOCI, model.RegistryURLDocker, model.RegistryURLGHCR)
-	}
-	return nil
+// ResolveOCIDigest resolves a reference to an image on a public registry, see OCIValidator.ResolveDigest
+func ResolveOCIDigest(ctx context.Context, identifier string) (string, error) {
+	return NewOCIValidator(Config{}).ResolveDigest(ctx, identifier)
 }
 
-// fetchImageManifest fetches the OCI manifest for an image
-func fetchImageManifest(ctx context.Context, client *http.Client, registryConfig *RegistryConfig, namespace, repo, tag string) (*OCIManifest, error) {
-	manifestURL := fmt.Sprintf("%s/v2/%s/%s/manifests/%s", registryConfig.APIBaseURL, namespace, repo, tag)
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, manifestURL, nil)
+// ResolveDigest resolves an OCI package reference to the digest of its manifest. References
+// already pinned to a digest resolve to that digest without contacting the registry.
+func (v *OCIValidator) ResolveDigest(ctx context.Context, identifier string) (string, error) {
+	ociRef, err := ParseOCIReference(identifier)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create manifest request: %w", err)
+		return "", fmt.Errorf("invalid OCI reference: %w", err)
+	}
+	if ociRef.Digest != "" {
+		return ociRef.Digest, nil
 	}
 
-	// Get auth token if registry requires it
-	if registryConfig.AuthURL != "" {
-		token, err := getRegistryAuthToken(ctx, client, registryConfig)
-		if err != nil {
-			return nil, fmt.Errorf("failed to authenticate with registry: %w", err)
-		}
-		req.Header.Set("Authorization", "Bearer "+token)
+	registryConfig := v.registryConfig(ociRef)
+	if registryConfig == nil {
+		return "", unsupportedOCIRegistryError(ociRef)
 	}
 
-	req.Header.Set("Accept", "application/vnd.oci.image.index.v1+json,application/vnd.docker.distribution.manifest.list.v2+json,application/vnd.docker.distribution.manifest.v2+json,application/vnd.oci.image.manifest.v1+json")
-	req.Header.Set("User-Agent", "MCP-Registry-Validator/1.0")
+	client := v.registries.httpClient()
+	_, digest, err := fetchImageManifest(ctx, client, registryConfig, ociRef.Repository(), ociRef.Tag)
+	return digest, err
+}
 
-	resp, err := client.Do(req)
+func unsupportedOCIRegistryError(ociRef *OCIReference) error {
+	return fmt.Errorf("registry type and base URL do not match: '%s' is not valid for registry type '%s'. Expected one of: %s, or a registry allowed by the registry operator",
+		ociRef.GetRegistryBaseURL(), model.RegistryTypeOCI, strings.Join(publicOCIRegistries, ", "))
+}
+
+// fetchImageManifest fetches the OCI manifest or index for an image, along with its digest
+func fetchImageManifest(ctx context.Context, client *http.Client, registryConfig *RegistryConfig, repository, tag string) (*OCIManifest, string, error) {
+	resp, err := registryConfig.get(ctx, client, "/v2/"+repository+"/manifests/"+tag,
+		"application/vnd.oci.image.index.v1+json,application/vnd.docker.distribution.manifest.list.v2+json,application/vnd.docker.distribution.manifest.v2+json,application/vnd.oci.image.manifest.v1+json")
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch OCI manifest: %w", err)
+		return nil, "", fmt.Errorf("failed to fetch OCI manifest: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusUnauthorized {
-		return nil, fmt.Errorf("OCI image '%s/%s:%s' not found (status: %d)", namespace, repo, tag, resp.StatusCode)
+		return nil, "", fmt.Errorf("OCI image '%s:%s' not found (status: %d)", repository, tag, resp.StatusCode)
 	}
 	if resp.StatusCode == http.StatusTooManyRequests {
 		// Rate limited, return explicit error
-		log.Printf("Rate limited when accessing OCI image '%s/%s:%s'", namespace, repo, tag)
-		return nil, fmt.Errorf("%w: %s/%s:%s", ErrRateLimited, namespace, repo, tag)
+		log.Printf("Rate limited when accessing OCI image '%s:%s'", repository, tag)
+		return nil, "", fmt.Errorf("%w: %s:%s", ErrRateLimited, repository, tag)
 	}
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to fetch OCI manifest (status: %d)", resp.StatusCode)
+		return nil, "", fmt.Errorf("failed to fetch OCI manifest (status: %d)", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxManifestSize))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read OCI manifest: %w", err)
 	}
 
 	var manifest OCIManifest
-	if err := json.NewDecoder(resp.Body).Decode(&manifest); err != nil {
-		return nil, fmt.Errorf("failed to parse OCI manifest: %w", err)
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, "", fmt.Errorf("failed to parse OCI manifest: %w", err)
 	}
 
-	return &manifest, nil
+	// The digest of a manifest is the digest of its exact bytes
+	sum := sha256.Sum256(data)
+	return &manifest, "sha256:" + hex.EncodeToString(sum[:]), nil
 }
 
 // getConfigDigestFromManifest extracts the config digest from an OCI manifest
-func getConfigDigestFromManifest(ctx context.Context, client *http.Client, registryConfig *RegistryConfig, namespace, repo string, manifest *OCIManifest) (string, error) {
+func getConfigDigestFromManifest(ctx context.Context, client *http.Client, registryConfig *RegistryConfig, repository string, manifest *OCIManifest) (string, error) {
 	// Handle multi-arch images by using first manifest
 	if len(manifest.Manifests) > 0 {
 		// This is a multi-arch image, get the specific manifest
-		specificManifest, err := getSpecificManifest(ctx, client, registryConfig, namespace, repo, manifest.Manifests[0].Digest)
+		specificManifest, err := getSpecificManifest(ctx, client, registryConfig, repository, manifest.Manifests[0].Digest)
 		if err != nil {
 			return "", fmt.Errorf("failed to get specific manifest: %w", err)
 		}
@@ -222,16 +286,16 @@ func getConfigDigestFromManifest(ctx context.Context, client *http.Client, regis
 }
 
 // validateServerNameAnnotation validates the MCP server name annotation in the image config
-func validateServerNameAnnotation(ctx context.Context, client *http.Client, registryConfig *RegistryConfig, namespace, repo, tag, configDigest, serverName string) error {
+func validateServerNameAnnotation(ctx context.Context, client *http.Client, registryConfig *RegistryConfig, repository, tag, configDigest, serverName string) error {
 	// Get image config (contains labels)
-	config, err := getImageConfig(ctx, client, registryConfig, namespace, repo, configDigest)
+	config, err := getImageConfig(ctx, client, registryConfig, repository, configDigest)
 	if err != nil {
 		return fmt.Errorf("failed to get image config: %w", err)
 	}
 
 	mcpName, exists := config.Config.Labels["io.modelcontextprotocol.server.name"]
 	if !exists {
-		return fmt.Errorf("OCI image '%s/%s:%s' is missing required annotation. Add this to your Dockerfile: LABEL io.modelcontextprotocol.server.name=\"%s\"", namespace, repo, tag, serverName)
+		return fmt.Errorf("OCI image '%s:%s' is missing required annotation. Add this to your Dockerfile: LABEL io.modelcontextprotocol.server.name=\"%s\"", repository, tag, serverName)
 	}
 
 	if mcpName != serverName {
@@ -241,18 +305,99 @@ func validateServerNameAnnotation(ctx context.Context, client *http.Client, regi
 	return nil
 }
 
-// getRegistryAuthToken retrieves an authentication token from a registry
-func getRegistryAuthToken(ctx context.Context, client *http.Client, config *RegistryConfig) (string, error) {
-	if config.AuthURL == "" {
-		return "", nil // No auth required
+// get performs a GET request against the registry API. If the registry answers with an
+// auth challenge, it authenticates as the challenge asks and retries the request once.
+func (c *RegistryConfig) get(ctx context.Context, client *http.Client, apiPath, accept string) (*http.Response, error) {
+	resp, err := c.doGet(ctx, client, apiPath, accept)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+
+	challenge := resp.Header.Get("WWW-Authenticate")
+	if challenge == "" {
+		return resp, nil
+	}
+	resp.Body.Close()
+
+	if err := c.authenticate(ctx, client, challenge); err != nil {
+		return nil, fmt.Errorf("failed to authenticate with registry: %w", err)
+	}
+	return c.doGet(ctx, client, apiPath, accept)
+}
+
+func (c *RegistryConfig) doGet(ctx context.Context, client *http.Client, apiPath, accept string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.APIBaseURL+apiPath, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	if c.authorization != "" {
+		req.Header.Set("Authorization", c.authorization)
+	}
+	req.Header.Set("Accept", accept)
+
+	return client.Do(req)
+}
+
+// authenticate satisfies a WWW-Authenticate challenge, storing the Authorization header to use
+func (c *RegistryConfig) authenticate(ctx context.Context, client *http.Client, challenge string) error {
+	scheme, params := parseAuthChallenge(challenge)
+	switch strings.ToLower(scheme) {
+	case "bearer":
+		token, err := getRegistryAuthToken(ctx, client, c, params)
+		if err != nil {
+			return err
+		}
+		c.authorization = "Bearer " + token
+		return nil
+	case "basic":
+		if c.credentials.Username == "" {
+			return fmt.Errorf("registry requires credentials")
+		}
+		req := &http.Request{Header: http.Header{}}
+		req.SetBasicAuth(c.credentials.Username, c.credentials.Password)
+		c.authorization = req.Header.Get("Authorization")
+		return nil
+	default:
+		return fmt.Errorf("unsupported auth challenge %q", scheme)
+	}
+}
+
+// getRegistryAuthToken retrieves a bearer token from the realm named in an auth challenge
+func getRegistryAuthToken(ctx context.Context, client *http.Client, config *RegistryConfig, params map[string]string) (string, error) {
+	realm, err := url.Parse(params["realm"])
+	if err != nil || (realm.Scheme != "https" && realm.Scheme != "http") {
+		return "", fmt.Errorf("invalid auth realm %q", params["realm"])
 	}
 
-	authURL := fmt.Sprintf("%s?service=%s&scope=%s", config.AuthURL, config.Service, config.Scope)
+	query := realm.Query()
+	if service := params["service"]; service != "" {
+		query.Set("service", service)
+	}
+	scope := params["scope"]
+	if scope == "" {
+		scope = config.Scope
+	}
+	query.Set("scope", scope)
+	realm.RawQuery = query.Encode()
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, authURL, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, realm.String(), nil)
 	if err != nil {
 		return "", fmt.Errorf("failed to create auth request: %w", err)
 	}
+	if config.credentials.Token != "" || config.credentials.Username != "" {
+		// The realm comes from the registry's response, so credentials only go to hosts the operator trusts
+		if !config.trustsRealm(realm) {
+			return "", fmt.Errorf("auth realm %q is not trusted with registry credentials: it must use https and be the registry host or one of its auth_hosts", realm.Scheme+"://"+realm.Host)
+		}
+		// Token services accept the registry credentials as basic auth
+		username := config.credentials.Username
+		password := config.credentials.Password
+		if config.credentials.Token != "" {
+			username, password = "token", config.credentials.Token
+		}
+		req.SetBasicAuth(username, password)
+	}
 
 	resp, err := client.Do(req)
 	if err != nil {
@@ -269,30 +414,68 @@ func getRegistryAuthToken(ctx context.Context, client *http.Client, config *Regi
 		return "", fmt.Errorf("failed to parse auth response: %w", err)
 	}
 
-	return authResp.Token, nil
+	if authResp.Token != "" {
+		return authResp.Token, nil
+	}
+	if authResp.AccessToken != "" {
+		return authResp.AccessToken, nil
+	}
+	return "", fmt.Errorf("auth response did not contain a token")
 }
 
-// getSpecificManifest retrieves a specific manifest for multi-arch images
-func getSpecificManifest(ctx context.Context, client *http.Client, registryConfig *RegistryConfig, namespace, repo, digest string) (*OCIManifest, error) {
-	manifestURL := fmt.Sprintf("%s/v2/%s/%s/manifests/%s", registryConfig.APIBaseURL, namespace, repo, digest)
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, manifestURL, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create specific manifest request: %w", err)
+// trustsRealm reports whether the registry's credentials may be sent to a token realm:
+// it must use https, and be on the registry host or one of the registry's auth hosts
+func (c *RegistryConfig) trustsRealm(realm *url.URL) bool {
+	if realm.Scheme != "https" {
+		return false
+	}
+	if base, err := url.Parse(c.APIBaseURL); err == nil && strings.EqualFold(realm.Hostname(), base.Hostname()) {
+		return true
 	}
+	for _, host := range c.credentials.AuthHosts {
+		if strings.EqualFold(realm.Hostname(), host) {
+			return true
+		}
+	}
+	return false
+}
 
-	// Get auth token if registry requires it
-	if registryConfig.AuthURL != "" {
-		token, err := getRegistryAuthToken(ctx, client, registryConfig)
-		if err != nil {
-			return nil, fmt.Errorf("failed to authenticate with registry: %w", err)
+// parseAuthChallenge parses a WWW-Authenticate header such as
+// Bearer realm="https://auth.docker.io/token",service="registry.docker.io",scope="repository:library/nginx:pull"
+func parseAuthChallenge(header string) (string, map[string]string) {
+	scheme, rest, _ := strings.Cut(strings.TrimSpace(header), " ")
+	params := make(map[string]string)
+
+	for rest != "" {
+		var key string
+		key, rest, _ = strings.Cut(strings.TrimLeft(rest, " ,"), "=")
+		key = strings.ToLower(strings.TrimSpace(key))
+
+		var value string
+		if strings.HasPrefix(rest, `"`) {
+			// Quoted values may contain commas, e.g. scope="repository:x:pull,push"
+			end := strings.Index(rest[1:], `"`)
+			if end < 0 {
+				value, rest = rest[1:], ""
+			} else {
+				value, rest = rest[1:end+1], rest[end+2:]
+			}
+		} else {
+			value, rest, _ = strings.Cut(rest, ",")
+		}
+
+		if key != "" {
+			params[key] = strings.TrimSpace(value)
 		}
-		req.Header.Set("Authorization", "Bearer "+token)
 	}
 
-	req.Header.Set("Accept", "application/vnd.oci.image.manifest.v1+json")
-	req.Header.Set("User-Agent", "MCP-Registry-Validator/1.0")
+	return scheme, params
+}
 
-	resp, err := client.Do(req)
+// getSpecificManifest retrieves a specific manifest for multi-arch images
+func getSpecificManifest(ctx context.Context, client *http.Client, registryConfig *RegistryConfig, repository, digest string) (*OCIManifest, error) {
+	resp, err := registryConfig.get(ctx, client, "/v2/"+repository+"/manifests/"+digest,
+		"application/vnd.oci.image.manifest.v1+json,application/vnd.docker.distribution.manifest.v2+json")
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch specific manifest: %w", err)
 	}
@@ -311,26 +494,9 @@ func getSpecificManifest(ctx context.Context, client *http.Client, registryConfi
 }
 
 // getImageConfig retrieves the image configuration containing labels
-func getImageConfig(ctx context.Context, client *http.Client, registryConfig *RegistryConfig, namespace, repo, configDigest string) (*OCIImageConfig, error) {
-	configURL := fmt.Sprintf("%s/v2/%s/%s/blobs/%s", registryConfig.APIBaseURL, namespace, repo, configDigest)
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, configURL, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create config request: %w", err)
-	}
-
-	// Get auth token if registry requires it
-	if registryConfig.AuthURL != "" {
-		token, err := getRegistryAuthToken(ctx, client, registryConfig)
-		if err != nil {
-			return nil, fmt.Errorf("failed to authenticate with registry: %w", err)
-		}
-		req.Header.Set("Authorization", "Bearer "+token)
-	}
-
-	req.Header.Set("Accept", "application/vnd.docker.distribution.manifest.v2+json")
-	req.Header.Set("User-Agent", "MCP-Registry-Validator/1.0")
-
-	resp, err := client.Do(req)
+func getImageConfig(ctx context.Context, client *http.Client, registryConfig *RegistryConfig, repository, configDigest string) (*OCIImageConfig, error) {
+	resp, err := registryConfig.get(ctx, client, "/v2/"+repository+"/blobs/"+configDigest,
+		"application/vnd.oci.image.config.v1+json,application/vnd.docker.container.image.v1+json")
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch image config: %w", err)
 	}
@@ -15,7 +15,7 @@ const (
 // OCIReference represents a parsed OCI image reference
 type OCIReference struct {
 	Registry  string // e.g., "ghcr.io", "docker.io"
-	Namespace string // e.g., "owner", "library"
+	Namespace string // e.g., "owner", "library" (empty for top-level repositories outside docker.io)
 	Image     string // e.g., "repo"
 	Tag       string // e.g., "v1.0.0", "latest" (optional)
 	Digest    string // e.g., "sha256:abc..." (optional)
@@ -50,8 +50,10 @@ func ParseOCIReference(ref string) (*OCIReference, error) {
 
 	// Parse namespace and image from path
 	if len(parts) == 1 {
-		// Single part: library/image (docker.io default namespace)
-		result.Namespace = defaultOCINamespace
+		// Single part: library/image on docker.io, or a top-level repository on other registries
+		if result.Registry == "docker.io" {
+			result.Namespace = defaultOCINamespace
+		}
 		result.Image = parts[0]
 	} else {
 		// Multiple parts: namespace/image or org/team/image
@@ -89,9 +91,7 @@ func (r *OCIReference) String() string {
 
 	sb.WriteString(r.Registry)
 	sb.WriteString("/")
-	sb.WriteString(r.Namespace)
-	sb.WriteString("/")
-	sb.WriteString(r.Image)
+	sb.WriteString(r.Repository())
 
 	if r.Tag != "" {
 		sb.WriteString(":")
@@ -106,6 +106,14 @@ func (r *OCIReference) String() string {
 	return sb.String()
 }
 
+// Repository returns the repository path within the registry, e.g. "owner/repo"
+func (r *OCIReference) Repository() string {
+	if r.Namespace == "" {
+		return r.Image
+	}
+	return r.Namespace + "/" + r.Image
+}
+
 // GetRegistryBaseURL returns the full registry URL (e.g., "https://docker.io" or "https://ghcr.io")
 func (r *OCIReference) GetRegistryBaseURL() string {
 	switch r.Registry {
@@ -90,6 +90,17 @@ func TestParseOCIReference(t *testing.T) {
 				Digest:    "",
 			},
 		},
+		{
+			name:  "top-level repository outside docker.io",
+			input: "quay.io/repo:v1.0.0",
+			want: &registries.OCIReference{
+				Registry:  "quay.io",
+				Namespace: "",
+				Image:     "repo",
+				Tag:       "v1.0.0",
+				Digest:    "",
+			},
+		},
 		{
 			name:      "empty reference",
 			input:     "",
@@ -184,6 +195,15 @@ func TestOCIReference_String(t *testing.T) {
 			},
 			want: "docker.io/library/postgres:16",
 		},
+		{
+			name: "top-level repository",
+			ref: &registries.OCIReference{
+				Registry: "quay.io",
+				Image:    "repo",
+				Tag:      "v1.0.0",
+			},
+			want: "quay.io/repo:v1.0.0",
+		},
 	}
 
 	for _, tt := range tests {
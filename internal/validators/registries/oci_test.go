@@ -147,7 +147,7 @@ func TestValidateOCI_UnsupportedRegistry(t *testing.T) {
 	err := registries.ValidateOCI(ctx, pkg, "com.example/test")
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "registry type and base URL do not match")
-	assert.Contains(t, err.Error(), "Expected: https://docker.io or https://ghcr.io")
+	assert.Contains(t, err.Error(), "Expected one of: docker.io, ghcr.io")
 }
 
 func TestValidateOCI_SupportedRegistries(t *testing.T) {
@@ -169,8 +169,23 @@ func TestValidateOCI_SupportedRegistries(t *testing.T) {
 			expected:   true,
 		},
 		{
-			name:       "Unsupported registry should fail",
+			name:       "Quay should be supported",
 			identifier: "quay.io/test/image:latest",
+			expected:   true,
+		},
+		{
+			name:       "ECR Public should be supported",
+			identifier: "public.ecr.aws/test/image:latest",
+			expected:   true,
+		},
+		{
+			name:       "Artifact Registry should be supported",
+			identifier: "us-docker.pkg.dev/project/repo/image:latest",
+			expected:   true,
+		},
+		{
+			name:       "Unsupported registry should fail",
+			identifier: "registry.example.com/test/image:latest",
 			expected:   false,
 		},
 	}
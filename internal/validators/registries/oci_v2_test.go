@@ -0,0 +1,195 @@
+package registries_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/validators/registries"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// newOCIRegistry serves a multi-arch image acme/weather:1.0.0 labelled with serverName.
+// With bearer set, clients must get a token from the realm named in the challenge,
+// otherwise they must use basic auth with alice:hunter2.
+func newOCIRegistry(t *testing.T, serverName string, bearer bool) *httptest.Server {
+	t.Helper()
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			if r.URL.Query().Get("scope") != "repository:acme/weather:pull" || r.URL.Query().Get("service") != "fake-registry" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]string{"access_token": "registry-token"})
+			return
+		}
+
+		username, password, _ := r.BasicAuth()
+		authorized := r.Header.Get("Authorization") == "Bearer registry-token"
+		challenge := fmt.Sprintf(`Bearer realm="%s/token",service="fake-registry",scope="repository:acme/weather:pull"`, server.URL)
+		if !bearer {
+			authorized = username == "alice" && password == "hunter2"
+			challenge = `Basic realm="fake-registry"`
+		}
+		if !authorized {
+			w.Header().Set("WWW-Authenticate", challenge)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/v2/acme/weather/manifests/1.0.0":
+			_, _ = w.Write([]byte(`{"manifests":[{"digest":"sha256:amd64"}]}`))
+		case "/v2/acme/weather/manifests/sha256:amd64":
+			_, _ = w.Write([]byte(`{"config":{"digest":"sha256:config"}}`))
+		case "/v2/acme/weather/blobs/sha256:config":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"config": map[string]any{"Labels": map[string]string{"io.modelcontextprotocol.server.name": serverName}},
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestValidateOCI_GenericRegistry(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	serverName := "com.acme/weather"
+
+	bearerServer := newOCIRegistry(t, serverName, true)
+	basicServer := newOCIRegistry(t, serverName, false)
+	credentialedServer := newOCIRegistry(t, serverName, true)
+	bearerHost := strings.TrimPrefix(bearerServer.URL, "http://")
+	basicHost := strings.TrimPrefix(basicServer.URL, "http://")
+	credentialedHost := strings.TrimPrefix(credentialedServer.URL, "http://")
+
+	validator := registries.NewOCIValidator(registries.Config{PrivateRegistries: []registries.PrivateRegistry{
+		{RegistryType: model.RegistryTypeOCI, BaseURL: bearerServer.URL},
+		{RegistryType: model.RegistryTypeOCI, BaseURL: basicServer.URL, Username: "alice", Password: "hunter2"},
+		{RegistryType: model.RegistryTypeOCI, BaseURL: credentialedServer.URL, Token: "secret-token", AuthHosts: []string{"127.0.0.1"}},
+	}})
+
+	pkg := func(identifier string) model.Package {
+		return model.Package{RegistryType: model.RegistryTypeOCI, Identifier: identifier}
+	}
+
+	t.Run("token auth discovered from challenge", func(t *testing.T) {
+		t.Parallel()
+
+		require.NoError(t, validator.ValidatePackage(ctx, pkg(bearerHost+"/acme/weather:1.0.0"), serverName, ""))
+
+		err := validator.ValidatePackage(ctx, pkg(bearerHost+"/acme/weather:1.0.0"), "com.other/server", "")
+		assert.ErrorContains(t, err, "ownership validation failed")
+	})
+
+	t.Run("basic auth with configured credentials", func(t *testing.T) {
+		t.Parallel()
+
+		assert.NoError(t, validator.ValidatePackage(ctx, pkg(basicHost+"/acme/weather:1.0.0"), serverName, ""))
+	})
+
+	t.Run("credentials are not sent to plain http token realms", func(t *testing.T) {
+		t.Parallel()
+
+		err := validator.ValidatePackage(ctx, pkg(credentialedHost+"/acme/weather:1.0.0"), serverName, "")
+		assert.ErrorContains(t, err, "is not trusted with registry credentials")
+	})
+
+	t.Run("missing image", func(t *testing.T) {
+		t.Parallel()
+
+		err := validator.ValidatePackage(ctx, pkg(bearerHost+"/acme/missing:1.0.0"), serverName, "")
+		assert.ErrorContains(t, err, "not found")
+	})
+
+	t.Run("unlisted registry is rejected", func(t *testing.T) {
+		t.Parallel()
+
+		unlisted := newOCIRegistry(t, serverName, true)
+		err := validator.ValidatePackage(ctx, pkg(strings.TrimPrefix(unlisted.URL, "http://")+"/acme/weather:1.0.0"), serverName, "")
+		assert.ErrorContains(t, err, "registry type and base URL do not match")
+	})
+}
+
+func TestValidateOCI_PrivateRepositoryOnPublicHost(t *testing.T) {
+	t.Parallel()
+
+	serverName := "com.acme/weather"
+	server := newOCIRegistry(t, serverName, false)
+
+	// Requests for the Artifact Registry host reach the fake registry
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = func(ctx context.Context, network, _ string) (net.Conn, error) {
+		return (&net.Dialer{}).DialContext(ctx, network, server.Listener.Addr().String())
+	}
+	cfg := registries.Config{
+		Client: &http.Client{Transport: transport},
+		PrivateRegistries: []registries.PrivateRegistry{
+			{RegistryType: model.RegistryTypeOCI, BaseURL: "http://europe-docker.pkg.dev", Username: "alice", Password: "hunter2"},
+		},
+	}
+
+	pkg := model.Package{RegistryType: model.RegistryTypeOCI, Identifier: "europe-docker.pkg.dev/acme/weather:1.0.0"}
+	assert.NoError(t, registries.NewOCIValidator(cfg).ValidatePackage(context.Background(), pkg, serverName, ""))
+}
+
+func TestResolveOCIDigest(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	server := newOCIRegistry(t, "com.acme/weather", true)
+	host := strings.TrimPrefix(server.URL, "http://")
+
+	validator := registries.NewOCIValidator(registries.Config{PrivateRegistries: []registries.PrivateRegistry{
+		{RegistryType: model.RegistryTypeOCI, BaseURL: server.URL},
+	}})
+
+	t.Run("tag resolves to the digest of its manifest", func(t *testing.T) {
+		t.Parallel()
+
+		digest, err := validator.ResolveDigest(ctx, host+"/acme/weather:1.0.0")
+		require.NoError(t, err)
+		// sha256 of the index served for the tag
+		sum := sha256.Sum256([]byte(`{"manifests":[{"digest":"sha256:amd64"}]}`))
+		assert.Equal(t, "sha256:"+hex.EncodeToString(sum[:]), digest)
+	})
+
+	t.Run("pinned reference resolves without contacting the registry", func(t *testing.T) {
+		t.Parallel()
+
+		pinned := "sha256:" + strings.Repeat("a", 64)
+		digest, err := validator.ResolveDigest(ctx, "registry.example.com/acme/weather:1.0.0@"+pinned)
+		require.NoError(t, err)
+		assert.Equal(t, pinned, digest)
+	})
+
+	t.Run("missing tag", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := validator.ResolveDigest(ctx, host+"/acme/weather:2.0.0")
+		assert.ErrorContains(t, err, "not found")
+	})
+
+	t.Run("unlisted registry is rejected", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := validator.ResolveDigest(ctx, "registry.example.com/acme/weather:1.0.0")
+		assert.ErrorContains(t, err, "registry type and base URL do not match")
+	})
+}
@@ -0,0 +1,112 @@
+package registries
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/sigstore/sigstore-go/pkg/root"
+)
+
+// PrivateRegistry is an operator-allowed package registry host, accepted in addition
+// to the public registry for its type. Credentials are only sent to this host.
+type PrivateRegistry struct {
+	RegistryType string
+	BaseURL      string
+	// Username and Password configure basic auth, Token configures bearer auth
+	Username string
+	Password string
+	Token    string
+	// AuthHosts are the hosts, other than the registry's own, that OCI token realms may be on
+	// to receive the credentials
+	AuthHosts []string
+}
+
+// Config configures a package validator
+type Config struct {
+	// BaseURL is where requests for packages on the public registry are sent instead, such as a
+	// mirror or a test server. OCI and MCPB packages name their host, so their validators ignore it.
+	BaseURL string
+	// Client sends the requests to registries. Nil uses a client configured with DefaultHTTPClientConfig.
+	Client *http.Client
+	// PrivateRegistries are the registries allowed in addition to the public registry. Those of
+	// other registry types are ignored.
+	PrivateRegistries []PrivateRegistry
+	// SigstoreTrustedMaterial is the Sigstore trusted root that package provenance is verified
+	// against. Nil uses the public-good Sigstore instance.
+	SigstoreTrustedMaterial root.TrustedMaterial
+	// MCPBSignatureVerifier verifies detached MCPB signatures. Without one, only bundles declaring
+	// mcp_name in their manifest.json pass ownership validation.
+	MCPBSignatureVerifier MCPBSignatureVerifier
+}
+
+// registrySet holds the registries a validator sends requests to: the public registry of its
+// type, at its configured endpoint, and the allowed private registries of its type
+type registrySet struct {
+	registryType string
+	publicURL    string
+	endpoint     string
+	private      []PrivateRegistry
+	client       *http.Client
+}
+
+func newRegistrySet(registryType, publicURL string, cfg Config) registrySet {
+	s := registrySet{
+		registryType: registryType,
+		publicURL:    publicURL,
+		endpoint:     strings.TrimSuffix(cfg.BaseURL, "/"),
+		client:       cfg.Client,
+	}
+	if s.endpoint == "" {
+		s.endpoint = publicURL
+	}
+	if s.client == nil {
+		s.client = defaultHTTPClient
+	}
+	for _, registry := range cfg.PrivateRegistries {
+		if registry.RegistryType == registryType {
+			s.private = append(s.private, registry)
+		}
+	}
+	return s
+}
+
+// resolve checks that baseURL is the public registry for the type or an allowed private
+// registry. It returns the registry to send requests to: the private registry, or the public
+// registry without credentials at its configured endpoint.
+func (s registrySet) resolve(baseURL string) (PrivateRegistry, error) {
+	if baseURL == s.publicURL {
+		return PrivateRegistry{RegistryType: s.registryType, BaseURL: s.endpoint}, nil
+	}
+
+	for _, registry := range s.private {
+		if registry.BaseURL == baseURL {
+			return registry, nil
+		}
+	}
+
+	return PrivateRegistry{}, fmt.Errorf("registry type and base URL do not match: '%s' is not valid for registry type '%s'. Expected: %s",
+		baseURL, s.registryType, s.publicURL)
+}
+
+// httpClient returns a copy of the validator's HTTP client, so callers may adjust its timeout.
+// Requests made with it are recorded under the validator's registry type.
+func (s registrySet) httpClient() *http.Client {
+	client := *s.client
+	if transport, ok := client.Transport.(*retryTransport); ok {
+		labeled := *transport
+		labeled.registryType = s.registryType
+		client.Transport = &labeled
+	}
+	return &client
+}
+
+// authorize adds the registry's credentials, if any, to the request
+func (r PrivateRegistry) authorize(req *http.Request) {
+	switch {
+	case r.Token != "":
+		req.Header.Set("Authorization", "Bearer "+r.Token)
+	case r.Username != "":
+		req.SetBasicAuth(r.Username, r.Password)
+	}
+}
@@ -0,0 +1,157 @@
+package registries_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/validators/registries"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// newPrivateRegistry serves npm, PyPI and NuGet metadata for one package, requiring the given Authorization header
+func newPrivateRegistry(t *testing.T, authorization, serverName string) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{name}/1.0.0", func(w http.ResponseWriter, r *http.Request) {
+		// Scoped npm names are requested with an escaped slash
+		if r.PathValue("name") != "@acme/weather" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{"mcpName": serverName})
+	})
+	mux.HandleFunc("GET /pypi/acme-weather/1.0.0/json", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{"info": map[string]string{"description": "# Weather\n\nmcp-name: " + serverName}})
+	})
+	mux.HandleFunc("GET /v3-flatcontainer/acme.weather/1.0.0/readme", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("mcp-name: " + serverName))
+	})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != authorization {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		mux.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestPrivateRegistries(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	serverName := "com.acme/weather"
+
+	tokenServer := newPrivateRegistry(t, "Bearer secret-token", serverName)
+	basicServer := newPrivateRegistry(t, "Basic YWxpY2U6aHVudGVyMg==", serverName) // alice:hunter2
+	unlistedServer := newPrivateRegistry(t, "", serverName)
+
+	cfg := registries.Config{PrivateRegistries: []registries.PrivateRegistry{
+		{RegistryType: model.RegistryTypeNPM, BaseURL: tokenServer.URL, Token: "secret-token"},
+		{RegistryType: model.RegistryTypePyPI, BaseURL: tokenServer.URL, Token: "secret-token"},
+		{RegistryType: model.RegistryTypeNuGet, BaseURL: basicServer.URL, Username: "alice", Password: "hunter2"},
+	}}
+	npmValidator := registries.NewNPMValidator(cfg)
+	pypiValidator := registries.NewPyPIValidator(cfg)
+	nugetValidator := registries.NewNuGetValidator(cfg)
+
+	npm := model.Package{RegistryType: model.RegistryTypeNPM, Identifier: "@acme/weather", Version: "1.0.0"}
+	pypi := model.Package{RegistryType: model.RegistryTypePyPI, Identifier: "acme-weather", Version: "1.0.0"}
+	nuget := model.Package{RegistryType: model.RegistryTypeNuGet, Identifier: "Acme.Weather", Version: "1.0.0"}
+
+	t.Run("allowed registries use credentials and ownership checks", func(t *testing.T) {
+		t.Parallel()
+
+		npm, pypi, nuget := npm, pypi, nuget
+		npm.RegistryBaseURL = tokenServer.URL
+		pypi.RegistryBaseURL = tokenServer.URL
+		nuget.RegistryBaseURL = basicServer.URL
+
+		assert.NoError(t, npmValidator.ValidatePackage(ctx, npm, serverName, ""))
+		assert.NoError(t, pypiValidator.ValidatePackage(ctx, pypi, serverName, ""))
+		assert.NoError(t, nugetValidator.ValidatePackage(ctx, nuget, serverName, ""))
+
+		assert.ErrorContains(t, npmValidator.ValidatePackage(ctx, npm, "com.other/server", ""), "ownership validation failed")
+		assert.ErrorContains(t, pypiValidator.ValidatePackage(ctx, pypi, "com.other/server", ""), "ownership validation failed")
+		assert.ErrorContains(t, nugetValidator.ValidatePackage(ctx, nuget, "com.other/server", ""), "ownership validation failed")
+	})
+
+	t.Run("registry is only allowed for its type", func(t *testing.T) {
+		t.Parallel()
+
+		nuget := nuget
+		nuget.RegistryBaseURL = tokenServer.URL
+		err := nugetValidator.ValidatePackage(ctx, nuget, serverName, "")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "registry type and base URL do not match")
+	})
+
+	t.Run("unlisted registry is rejected", func(t *testing.T) {
+		t.Parallel()
+
+		npm := npm
+		npm.RegistryBaseURL = unlistedServer.URL
+		err := npmValidator.ValidatePackage(ctx, npm, serverName, "")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "registry type and base URL do not match")
+	})
+}
+
+func TestRegistryEndpoint(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	serverName := "com.acme/weather"
+
+	// Public registry requests carry no credentials
+	mirror := newPrivateRegistry(t, "", serverName)
+	cfg := registries.Config{BaseURL: mirror.URL}
+	npmValidator := registries.NewNPMValidator(cfg)
+
+	tests := []struct {
+		name      string
+		validator interface {
+			ValidatePackage(ctx context.Context, pkg model.Package, serverName, publisherToken string) error
+		}
+		pkg model.Package
+	}{
+		{
+			name:      "npm package on the public registry",
+			validator: npmValidator,
+			pkg:       model.Package{RegistryType: model.RegistryTypeNPM, RegistryBaseURL: model.RegistryURLNPM, Identifier: "@acme/weather", Version: "1.0.0"},
+		},
+		{
+			name:      "PyPI package with default base URL",
+			validator: registries.NewPyPIValidator(cfg),
+			pkg:       model.Package{RegistryType: model.RegistryTypePyPI, Identifier: "acme-weather", Version: "1.0.0"},
+		},
+		{
+			name:      "NuGet package on the public registry",
+			validator: registries.NewNuGetValidator(cfg),
+			pkg:       model.Package{RegistryType: model.RegistryTypeNuGet, RegistryBaseURL: model.RegistryURLNuGet, Identifier: "Acme.Weather", Version: "1.0.0"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.NoError(t, tt.validator.ValidatePackage(ctx, tt.pkg, serverName, ""))
+		})
+	}
+
+	t.Run("mirror URL is not accepted as the package base URL", func(t *testing.T) {
+		t.Parallel()
+
+		pkg := model.Package{RegistryType: model.RegistryTypeNPM, RegistryBaseURL: mirror.URL, Identifier: "@acme/weather", Version: "1.0.0"}
+		assert.ErrorContains(t, npmValidator.ValidatePackage(ctx, pkg, serverName, ""), "registry type and base URL do not match")
+	})
+}
@@ -0,0 +1,637 @@
+package registries_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	ct "github.com/google/certificate-transparency-go"
+	cttls "github.com/google/certificate-transparency-go/tls"
+	ctx509 "github.com/google/certificate-transparency-go/x509"
+	"github.com/google/certificate-transparency-go/x509util"
+	"github.com/secure-systems-lab/go-securesystemslib/dsse"
+	protobundle "github.com/sigstore/protobuf-specs/gen/pb-go/bundle/v1"
+	protocommon "github.com/sigstore/protobuf-specs/gen/pb-go/common/v1"
+	protodsse "github.com/sigstore/protobuf-specs/gen/pb-go/dsse"
+	protorekor "github.com/sigstore/protobuf-specs/gen/pb-go/rekor/v1"
+	"github.com/sigstore/sigstore-go/pkg/root"
+	"github.com/sigstore/sigstore-go/pkg/testing/ca"
+	"github.com/sigstore/sigstore-go/pkg/tlog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/modelcontextprotocol/registry/internal/validators/registries"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+const testRepositoryURL = "https://github.com/acme/weather"
+
+// githubIssuer is the OIDC issuer of GitHub Actions workflows
+const githubIssuer = "https://token.actions.githubusercontent.com"
+
+// fakeSigstore is a Sigstore stand-in: a Fulcio CA issuing short-lived signing certificates to
+// workflows, a CT log whose SCTs are embedded in them, and a Rekor log from sigstore-go's virtual Sigstore
+type fakeSigstore struct {
+	fulcio    *x509.Certificate
+	fulcioKey *ecdsa.PrivateKey
+	ctKey     *ecdsa.PrivateKey
+	ctLogID   [32]byte
+	rekor     *ca.VirtualSigstore
+	material  root.TrustedMaterial
+}
+
+func newFakeSigstore(t *testing.T) *fakeSigstore {
+	t.Helper()
+	rootCert, rootKey, err := ca.GenerateRootCa()
+	require.NoError(t, err)
+	fulcio, fulcioKey, err := ca.GenerateFulcioIntermediate(rootCert, rootKey)
+	require.NoError(t, err)
+	ctKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	ctPublicKey, err := x509.MarshalPKIXPublicKey(&ctKey.PublicKey)
+	require.NoError(t, err)
+	ctLogID := sha256.Sum256(ctPublicKey)
+	rekor, err := ca.NewVirtualSigstore()
+	require.NoError(t, err)
+
+	material, err := root.NewTrustedRoot(root.TrustedRootMediaType01,
+		[]root.CertificateAuthority{&root.FulcioCertificateAuthority{
+			Root:                rootCert,
+			Intermediates:       []*x509.Certificate{fulcio},
+			ValidityPeriodStart: time.Now().Add(-time.Hour),
+		}},
+		map[string]*root.TransparencyLog{hex.EncodeToString(ctLogID[:]): {
+			ID:                  ctLogID[:],
+			HashFunc:            crypto.SHA256,
+			PublicKey:           &ctKey.PublicKey,
+			SignatureHashFunc:   crypto.SHA256,
+			ValidityPeriodStart: time.Now().Add(-time.Hour),
+		}},
+		nil,
+		rekor.RekorLogs(),
+	)
+	require.NoError(t, err)
+
+	return &fakeSigstore{fulcio: fulcio, fulcioKey: fulcioKey, ctKey: ctKey, ctLogID: ctLogID, rekor: rekor, material: material}
+}
+
+// issue issues a signing certificate, with an embedded SCT, to a workflow in repositoryURL
+// authenticated by issuer
+func (s *fakeSigstore) issue(t *testing.T, issuer, repositoryURL string) (*x509.Certificate, *ecdsa.PrivateKey) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	issuerExtension, err := asn1.MarshalWithParams(issuer, "utf8")
+	require.NoError(t, err)
+	repositoryExtension, err := asn1.MarshalWithParams(repositoryURL, "utf8")
+	require.NoError(t, err)
+	workflow, err := url.Parse(repositoryURL + "/.github/workflows/release.yml@refs/heads/main")
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		NotBefore:    time.Now().Add(-time.Minute),
+		NotAfter:     time.Now().Add(10 * time.Minute),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+		URIs:         []*url.URL{workflow},
+		ExtraExtensions: []pkix.Extension{
+			{Id: asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 8}, Value: issuerExtension},
+			{Id: asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 12}, Value: repositoryExtension},
+		},
+	}
+	// The CT log signs the certificate without its SCT list, so the list is issued with a placeholder first
+	sctExtension := pkix.Extension{Id: asn1.ObjectIdentifier(ctx509.OIDExtensionCTSCT), Value: []byte{asn1.TagOctetString, 0}}
+	template.ExtraExtensions = append(template.ExtraExtensions, sctExtension)
+	placeholderDER, err := x509.CreateCertificate(rand.Reader, template, s.fulcio, &key.PublicKey, s.fulcioKey)
+	require.NoError(t, err)
+	template.ExtraExtensions[len(template.ExtraExtensions)-1].Value = s.sctList(t, placeholderDER)
+	certDER, err := x509.CreateCertificate(rand.Reader, template, s.fulcio, &key.PublicKey, s.fulcioKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(certDER)
+	require.NoError(t, err)
+	return cert, key
+}
+
+// sctList returns the SCT list extension value for a certificate logged in the CT log
+func (s *fakeSigstore) sctList(t *testing.T, certDER []byte) []byte {
+	t.Helper()
+	// The placeholder list does not parse, which is not fatal
+	cert, err := ctx509.ParseCertificate(certDER)
+	require.False(t, ctx509.IsFatal(err), err)
+	issuer, err := ctx509.ParseCertificate(s.fulcio.Raw)
+	require.NoError(t, err)
+
+	sct := &ct.SignedCertificateTimestamp{
+		SCTVersion: ct.V1,
+		LogID:      ct.LogID{KeyID: s.ctLogID},
+		Timestamp:  uint64(time.Now().UnixMilli()),
+	}
+	leaf, err := ct.MerkleTreeLeafForEmbeddedSCT([]*ctx509.Certificate{cert, issuer}, sct.Timestamp)
+	require.NoError(t, err)
+	input, err := ct.SerializeSCTSignatureInput(*sct, ct.LogEntry{Leaf: *leaf})
+	require.NoError(t, err)
+	digest := sha256.Sum256(input)
+	signature, err := ecdsa.SignASN1(rand.Reader, s.ctKey, digest[:])
+	require.NoError(t, err)
+	sct.Signature = ct.DigitallySigned{
+		Algorithm: cttls.SignatureAndHashAlgorithm{Hash: cttls.SHA256, Signature: cttls.ECDSA},
+		Signature: signature,
+	}
+
+	list, err := x509util.MarshalSCTsIntoSCTList([]*ct.SignedCertificateTimestamp{sct})
+	require.NoError(t, err)
+	listBytes, err := cttls.Marshal(*list)
+	require.NoError(t, err)
+	value, err := asn1.Marshal(listBytes)
+	require.NoError(t, err)
+	return value
+}
+
+// testAttestation is a DSSE-signed in-toto statement with its signing certificate and Rekor entry
+type testAttestation struct {
+	cert      []byte
+	statement []byte
+	signature []byte
+	tlogEntry *protorekor.TransparencyLogEntry
+}
+
+// attest signs an in-toto statement about a subject and logs the signature in Rekor
+func (s *fakeSigstore) attest(t *testing.T, issuer, repositoryURL, subjectName, algorithm, digest string) testAttestation {
+	t.Helper()
+	statement := mustJSON(t, map[string]any{
+		"_type":         "https://in-toto.io/Statement/v1",
+		"subject":       []map[string]any{{"name": subjectName, "digest": map[string]string{algorithm: digest}}},
+		"predicateType": "https://slsa.dev/provenance/v1",
+		"predicate":     map[string]any{},
+	})
+	cert, key := s.issue(t, issuer, repositoryURL)
+
+	payloadType := "application/vnd.in-toto+json"
+	pae := sha256.Sum256([]byte(fmt.Sprintf("DSSEv1 %d %s %d %s", len(payloadType), payloadType, len(statement), statement)))
+	signature, err := ecdsa.SignASN1(rand.Reader, key, pae[:])
+	require.NoError(t, err)
+
+	envelope := &dsse.Envelope{
+		PayloadType: payloadType,
+		Payload:     base64.StdEncoding.EncodeToString(statement),
+		Signatures:  []dsse.Signature{{Sig: base64.StdEncoding.EncodeToString(signature)}},
+	}
+	entry, err := s.rekor.GenerateTlogEntry(cert, envelope, signature, time.Now().Unix(), true)
+	require.NoError(t, err)
+
+	return testAttestation{cert: cert.Raw, statement: statement, signature: signature, tlogEntry: s.promise(t, entry.TransparencyLogEntry())}
+}
+
+// promise completes a generated log entry with its kind and an inclusion promise, as Rekor returns it
+func (s *fakeSigstore) promise(t *testing.T, entry *protorekor.TransparencyLogEntry) *protorekor.TransparencyLogEntry {
+	t.Helper()
+	var body struct {
+		Kind       string `json:"kind"`
+		APIVersion string `json:"apiVersion"`
+	}
+	require.NoError(t, json.Unmarshal(entry.CanonicalizedBody, &body))
+	set, err := s.rekor.RekorSignPayload(tlog.RekorPayload{
+		Body:           base64.StdEncoding.EncodeToString(entry.CanonicalizedBody),
+		IntegratedTime: entry.IntegratedTime,
+		LogIndex:       entry.LogIndex,
+		LogID:          hex.EncodeToString(entry.LogId.KeyId),
+	})
+	require.NoError(t, err)
+
+	entry.KindVersion = &protorekor.KindVersion{Kind: body.Kind, Version: body.APIVersion}
+	entry.InclusionPromise = &protorekor.InclusionPromise{SignedEntryTimestamp: set}
+	return entry
+}
+
+// bundle returns the attestation as a Sigstore bundle in its JSON encoding
+func (a testAttestation) bundle(t *testing.T) json.RawMessage {
+	t.Helper()
+	var entries []*protorekor.TransparencyLogEntry
+	if a.tlogEntry != nil {
+		entries = append(entries, a.tlogEntry)
+	}
+	data, err := protojson.Marshal(&protobundle.Bundle{
+		MediaType: "application/vnd.dev.sigstore.bundle.v0.3+json",
+		VerificationMaterial: &protobundle.VerificationMaterial{
+			Content:     &protobundle.VerificationMaterial_Certificate{Certificate: &protocommon.X509Certificate{RawBytes: a.cert}},
+			TlogEntries: entries,
+		},
+		Content: &protobundle.Bundle_DsseEnvelope{DsseEnvelope: &protodsse.Envelope{
+			Payload:     a.statement,
+			PayloadType: "application/vnd.in-toto+json",
+			Signatures:  []*protodsse.Signature{{Sig: a.signature}},
+		}},
+	})
+	require.NoError(t, err)
+	return data
+}
+
+// sign signs a cosign payload and logs the signature in Rekor, returning the signing certificate,
+// the signature, and the Rekor bundle cosign attaches to the signature
+func (s *fakeSigstore) sign(t *testing.T, issuer, repositoryURL string, payload []byte) (cert *x509.Certificate, signature, rekorBundle []byte) {
+	t.Helper()
+	cert, key := s.issue(t, issuer, repositoryURL)
+	digest := sha256.Sum256(payload)
+	signature, err := ecdsa.SignASN1(rand.Reader, key, digest[:])
+	require.NoError(t, err)
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})
+	body := mustJSON(t, map[string]any{
+		"apiVersion": "0.0.1",
+		"kind":       "hashedrekord",
+		"spec": map[string]any{
+			"data":      map[string]any{"hash": map[string]string{"algorithm": "sha256", "value": hex.EncodeToString(digest[:])}},
+			"signature": map[string]any{"content": signature, "publicKey": map[string]any{"content": certPEM}},
+		},
+	})
+	logID, err := s.rekor.RekorLogID()
+	require.NoError(t, err)
+	payloadJSON := tlog.RekorPayload{Body: base64.StdEncoding.EncodeToString(body), IntegratedTime: time.Now().Unix(), LogIndex: 1, LogID: logID}
+	set, err := s.rekor.RekorSignPayload(payloadJSON)
+	require.NoError(t, err)
+
+	rekorBundle = mustJSON(t, map[string]any{"SignedEntryTimestamp": set, "Payload": payloadJSON})
+	return cert, signature, rekorBundle
+}
+
+// standIn serves fixed responses by path
+func standIn(t *testing.T, responses map[string][]byte) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, ok := responses[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(data)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func mustJSON(t *testing.T, v any) []byte {
+	t.Helper()
+	data, err := json.Marshal(v)
+	require.NoError(t, err)
+	return data
+}
+
+func TestVerifyNPMProvenance(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	sigstore := newFakeSigstore(t)
+	untrusted := newFakeSigstore(t)
+
+	tarball := sha512.Sum512([]byte("weather tarball"))
+	npmBundle := func(attestation testAttestation) map[string]any {
+		return map[string]any{"predicateType": "https://slsa.dev/provenance/v1", "bundle": attestation.bundle(t)}
+	}
+	attest := func(s *fakeSigstore, issuer, repositoryURL, digest string) testAttestation {
+		return s.attest(t, issuer, repositoryURL, "pkg:npm/acme-weather@1.0.0", "sha512", digest)
+	}
+	unlogged := attest(sigstore, githubIssuer, testRepositoryURL, hex.EncodeToString(tarball[:]))
+	unlogged.tlogEntry = nil
+
+	responses := map[string][]byte{}
+	for _, name := range []string{"acme-weather", "acme-fork", "acme-untrusted", "acme-issuer", "acme-unlogged", "acme-other", "acme-unattested"} {
+		responses["/"+name+"/1.0.0"] = mustJSON(t, map[string]any{
+			"dist": map[string]string{"integrity": "sha512-" + base64.StdEncoding.EncodeToString(tarball[:])},
+		})
+	}
+	attestations := map[string]map[string]any{
+		"acme-weather":   npmBundle(attest(sigstore, githubIssuer, testRepositoryURL, hex.EncodeToString(tarball[:]))),
+		"acme-fork":      npmBundle(attest(sigstore, githubIssuer, "https://github.com/someone/weather", hex.EncodeToString(tarball[:]))),
+		"acme-untrusted": npmBundle(attest(untrusted, githubIssuer, testRepositoryURL, hex.EncodeToString(tarball[:]))),
+		"acme-issuer":    npmBundle(attest(sigstore, "https://gitlab.com", testRepositoryURL, hex.EncodeToString(tarball[:]))),
+		"acme-unlogged":  npmBundle(unlogged),
+		"acme-other":     npmBundle(attest(sigstore, githubIssuer, testRepositoryURL, strings.Repeat("0", 128))),
+	}
+	for name, attestation := range attestations {
+		responses["/-/npm/v1/attestations/"+name+"@1.0.0"] = mustJSON(t, map[string]any{"attestations": []any{attestation}})
+	}
+	server := standIn(t, responses)
+
+	validator := registries.NewNPMValidator(registries.Config{
+		PrivateRegistries:       []registries.PrivateRegistry{{RegistryType: model.RegistryTypeNPM, BaseURL: server.URL}},
+		SigstoreTrustedMaterial: sigstore.material,
+	})
+
+	tests := []struct {
+		name          string
+		identifier    string
+		repositoryURL string
+		errorMessage  string
+	}{
+		{
+			name:          "attestation from the server repository should pass",
+			identifier:    "acme-weather",
+			repositoryURL: testRepositoryURL,
+		},
+		{
+			name:          "repository URL is compared loosely",
+			identifier:    "acme-weather",
+			repositoryURL: "git+https://github.com/Acme/weather.git",
+		},
+		{
+			name:          "attestation from another repository should fail",
+			identifier:    "acme-fork",
+			repositoryURL: testRepositoryURL,
+			errorMessage:  "signed by a workflow in 'https://github.com/someone/weather'",
+		},
+		{
+			name:          "attestation from an untrusted Sigstore instance should fail",
+			identifier:    "acme-untrusted",
+			repositoryURL: testRepositoryURL,
+			errorMessage:  "signature verification failed",
+		},
+		{
+			name:          "attestation from another OIDC issuer should fail",
+			identifier:    "acme-issuer",
+			repositoryURL: testRepositoryURL,
+			errorMessage:  "expected issuer value",
+		},
+		{
+			name:          "attestation without transparency log entry should fail",
+			identifier:    "acme-unlogged",
+			repositoryURL: testRepositoryURL,
+			errorMessage:  "not enough verified log entries",
+		},
+		{
+			name:          "attestation for another tarball should fail",
+			identifier:    "acme-other",
+			repositoryURL: testRepositoryURL,
+			errorMessage:  "artifact digest does not match",
+		},
+		{
+			name:          "package without attestations should fail",
+			identifier:    "acme-unattested",
+			repositoryURL: testRepositoryURL,
+			errorMessage:  "has no provenance attestations",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			pkg := model.Package{
+				RegistryType:    model.RegistryTypeNPM,
+				RegistryBaseURL: server.URL,
+				Identifier:      tt.identifier,
+				Version:         "1.0.0",
+			}
+
+			err := validator.VerifyProvenance(ctx, pkg, tt.repositoryURL)
+			if tt.errorMessage != "" {
+				assert.ErrorContains(t, err, tt.errorMessage)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestVerifyPyPIProvenance(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	sigstore := newFakeSigstore(t)
+
+	wheel := sha256.Sum256([]byte("weather wheel"))
+	sdist := sha256.Sum256([]byte("weather sdist"))
+	const wheelName, sdistName = "acme_weather-1.0.0-py3-none-any.whl", "acme_weather-1.0.0.tar.gz"
+	provenance := func(repositoryURL, filename string, digest [32]byte) []byte {
+		attestation := sigstore.attest(t, githubIssuer, repositoryURL, filename, "sha256", hex.EncodeToString(digest[:]))
+		entry, err := protojson.Marshal(attestation.tlogEntry)
+		require.NoError(t, err)
+		return mustJSON(t, map[string]any{
+			"version": 1,
+			"attestation_bundles": []map[string]any{{
+				"publisher": map[string]any{"kind": "GitHub", "repository": "acme/weather"},
+				"attestations": []map[string]any{{
+					"version": 1,
+					"verification_material": map[string]any{
+						"certificate":          attestation.cert,
+						"transparency_entries": []json.RawMessage{entry},
+					},
+					"envelope": map[string]any{"statement": attestation.statement, "signature": attestation.signature},
+				}},
+			}},
+		})
+	}
+	files := func(name string) []byte {
+		return mustJSON(t, map[string]any{"urls": []map[string]any{
+			{"filename": strings.ReplaceAll(wheelName, "acme_weather", name), "digests": map[string]string{"sha256": hex.EncodeToString(wheel[:])}},
+			{"filename": strings.ReplaceAll(sdistName, "acme_weather", name), "digests": map[string]string{"sha256": hex.EncodeToString(sdist[:])}},
+		}})
+	}
+
+	server := standIn(t, map[string][]byte{
+		"/pypi/acme-weather/1.0.0/json":                              files("acme_weather"),
+		"/integrity/acme-weather/1.0.0/" + wheelName + "/provenance": provenance(testRepositoryURL, wheelName, wheel),
+		"/integrity/acme-weather/1.0.0/" + sdistName + "/provenance": provenance(testRepositoryURL, sdistName, sdist),
+		// acme-partial only attests its wheel
+		"/pypi/acme-partial/1.0.0/json": files("acme_partial"),
+		"/integrity/acme-partial/1.0.0/acme_partial-1.0.0-py3-none-any.whl/provenance": provenance(testRepositoryURL, "acme_partial-1.0.0-py3-none-any.whl", wheel),
+		// acme-swapped attests its wheel under the name of its sdist
+		"/pypi/acme-swapped/1.0.0/json": files("acme_swapped"),
+		"/integrity/acme-swapped/1.0.0/acme_swapped-1.0.0-py3-none-any.whl/provenance": provenance(testRepositoryURL, "acme_swapped-1.0.0.tar.gz", wheel),
+		"/pypi/acme-empty/1.0.0/json": mustJSON(t, map[string]any{"urls": []any{}}),
+	})
+
+	validator := registries.NewPyPIValidator(registries.Config{
+		PrivateRegistries:       []registries.PrivateRegistry{{RegistryType: model.RegistryTypePyPI, BaseURL: server.URL}},
+		SigstoreTrustedMaterial: sigstore.material,
+	})
+
+	tests := []struct {
+		name          string
+		identifier    string
+		repositoryURL string
+		errorMessage  string
+	}{
+		{
+			name:          "attested distribution files should pass",
+			identifier:    "acme-weather",
+			repositoryURL: testRepositoryURL,
+		},
+		{
+			name:          "attestations from another repository should fail",
+			identifier:    "acme-weather",
+			repositoryURL: "https://github.com/someone/weather",
+			errorMessage:  "expected the server repository 'https://github.com/someone/weather'",
+		},
+		{
+			name:          "file without attestation should fail",
+			identifier:    "acme-partial",
+			repositoryURL: testRepositoryURL,
+			errorMessage:  "PyPI file 'acme_partial-1.0.0.tar.gz' provenance verification failed",
+		},
+		{
+			name:          "attestation of another file should fail",
+			identifier:    "acme-swapped",
+			repositoryURL: testRepositoryURL,
+			errorMessage:  "attestation does not cover the distribution file",
+		},
+		{
+			name:          "release without files should fail",
+			identifier:    "acme-empty",
+			repositoryURL: testRepositoryURL,
+			errorMessage:  "has no distribution files",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			pkg := model.Package{
+				RegistryType:    model.RegistryTypePyPI,
+				RegistryBaseURL: server.URL,
+				Identifier:      tt.identifier,
+				Version:         "1.0.0",
+			}
+
+			err := validator.VerifyProvenance(ctx, pkg, tt.repositoryURL)
+			if tt.errorMessage != "" {
+				assert.ErrorContains(t, err, tt.errorMessage)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestVerifyOCISignature(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	sigstore := newFakeSigstore(t)
+
+	imageDigest := "sha256:" + strings.Repeat("a", 64)
+	otherDigest := "sha256:" + strings.Repeat("b", 64)
+
+	responses := map[string][]byte{}
+	// signatureManifest stores a cosign signature of the payload for digest in the image's repository
+	signatureManifest := func(repository, signedDigest, repositoryURL string, keyless, logged bool) {
+		payload := mustJSON(t, map[string]any{
+			"critical": map[string]any{
+				"identity": map[string]string{"docker-reference": repository},
+				"image":    map[string]string{"docker-manifest-digest": signedDigest},
+				"type":     "cosign container image signature",
+			},
+			"optional": nil,
+		})
+		sum := sha256.Sum256(payload)
+		payloadDigest := "sha256:" + hex.EncodeToString(sum[:])
+		cert, signature, rekorBundle := sigstore.sign(t, githubIssuer, repositoryURL, payload)
+
+		annotations := map[string]string{"dev.cosignproject.cosign/signature": base64.StdEncoding.EncodeToString(signature)}
+		if keyless {
+			annotations["dev.sigstore.cosign/certificate"] = string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}))
+		}
+		if logged {
+			annotations["dev.sigstore.cosign/bundle"] = string(rekorBundle)
+		}
+		responses["/v2/"+repository+"/blobs/"+payloadDigest] = payload
+		responses["/v2/"+repository+"/manifests/"+strings.Replace(imageDigest, ":", "-", 1)+".sig"] = mustJSON(t, map[string]any{
+			"schemaVersion": 2,
+			"mediaType":     "application/vnd.oci.image.manifest.v1+json",
+			"config":        map[string]any{"digest": "sha256:config"},
+			"layers": []map[string]any{{
+				"mediaType":   "application/vnd.dev.cosign.simplesigning.v1+json",
+				"digest":      payloadDigest,
+				"annotations": annotations,
+			}},
+		})
+	}
+	signatureManifest("acme/weather", imageDigest, testRepositoryURL, true, true)
+	signatureManifest("acme/fork", imageDigest, "https://github.com/someone/weather", true, true)
+	signatureManifest("acme/other", otherDigest, testRepositoryURL, true, true)
+	signatureManifest("acme/keyed", imageDigest, testRepositoryURL, false, true)
+	signatureManifest("acme/unlogged", imageDigest, testRepositoryURL, true, false)
+
+	server := standIn(t, responses)
+	host := strings.TrimPrefix(server.URL, "http://")
+
+	validator := registries.NewOCIValidator(registries.Config{
+		PrivateRegistries:       []registries.PrivateRegistry{{RegistryType: model.RegistryTypeOCI, BaseURL: server.URL}},
+		SigstoreTrustedMaterial: sigstore.material,
+	})
+
+	tests := []struct {
+		name         string
+		identifier   string
+		errorMessage string
+	}{
+		{
+			name:       "keyless signature from the server repository should pass",
+			identifier: host + "/acme/weather:1.0.0@" + imageDigest,
+		},
+		{
+			name:         "reference without digest should fail",
+			identifier:   host + "/acme/weather:1.0.0",
+			errorMessage: "must be referenced by digest",
+		},
+		{
+			name:         "signature from another repository should fail",
+			identifier:   host + "/acme/fork@" + imageDigest,
+			errorMessage: "signed by a workflow in 'https://github.com/someone/weather'",
+		},
+		{
+			name:         "signature of another image should fail",
+			identifier:   host + "/acme/other@" + imageDigest,
+			errorMessage: "signature is for image '" + otherDigest + "'",
+		},
+		{
+			name:         "key-based signature should fail",
+			identifier:   host + "/acme/keyed@" + imageDigest,
+			errorMessage: "only keyless signatures can be tied to a repository",
+		},
+		{
+			name:         "signature without transparency log entry should fail",
+			identifier:   host + "/acme/unlogged@" + imageDigest,
+			errorMessage: "signature has no transparency log entry",
+		},
+		{
+			name:         "unsigned image should fail",
+			identifier:   host + "/acme/unsigned@" + imageDigest,
+			errorMessage: "has no cosign signature",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := validator.VerifyProvenance(ctx, model.Package{RegistryType: model.RegistryTypeOCI, Identifier: tt.identifier}, testRepositoryURL)
+			if tt.errorMessage != "" {
+				assert.ErrorContains(t, err, tt.errorMessage)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
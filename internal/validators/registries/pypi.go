@@ -2,12 +2,22 @@ package registries
 
 import (
 	"context"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strings"
-	"time"
+
+	protobundle "github.com/sigstore/protobuf-specs/gen/pb-go/bundle/v1"
+	protocommon "github.com/sigstore/protobuf-specs/gen/pb-go/common/v1"
+	protodsse "github.com/sigstore/protobuf-specs/gen/pb-go/dsse"
+	protorekor "github.com/sigstore/protobuf-specs/gen/pb-go/rekor/v1"
+	"github.com/sigstore/sigstore-go/pkg/bundle"
+	"github.com/sigstore/sigstore-go/pkg/root"
+	"github.com/sigstore/sigstore-go/pkg/verify"
+	"google.golang.org/protobuf/encoding/protojson"
 
 	"github.com/modelcontextprotocol/registry/pkg/model"
 )
@@ -24,8 +34,27 @@ type PyPIPackageResponse struct {
 	} `json:"info"`
 }
 
-// ValidatePyPI validates that a PyPI package contains the correct MCP server name
+// PyPIValidator validates PyPI packages and verifies their provenance
+type PyPIValidator struct {
+	registries registrySet
+	sigstore   root.TrustedMaterial
+}
+
+// NewPyPIValidator returns a validator for PyPI packages
+func NewPyPIValidator(cfg Config) *PyPIValidator {
+	return &PyPIValidator{
+		registries: newRegistrySet(model.RegistryTypePyPI, model.RegistryURLPyPI, cfg),
+		sigstore:   cfg.SigstoreTrustedMaterial,
+	}
+}
+
+// ValidatePyPI validates a PyPI package on the public registry, see PyPIValidator.ValidatePackage
 func ValidatePyPI(ctx context.Context, pkg model.Package, serverName string) error {
+	return NewPyPIValidator(Config{}).ValidatePackage(ctx, pkg, serverName, "")
+}
+
+// ValidatePackage validates that a PyPI package contains the correct MCP server name
+func (v *PyPIValidator) ValidatePackage(ctx context.Context, pkg model.Package, serverName, _ string) error {
 	// Set default registry base URL if empty
 	if pkg.RegistryBaseURL == "" {
 		pkg.RegistryBaseURL = model.RegistryURLPyPI
@@ -44,21 +73,21 @@ func ValidatePyPI(ctx context.Context, pkg model.Package, serverName string) err
 		return fmt.Errorf("PyPI packages must not have 'fileSha256' field - this is only for MCPB packages")
 	}
 
-	// Validate that the registry base URL matches PyPI exactly
-	if pkg.RegistryBaseURL != model.RegistryURLPyPI {
-		return fmt.Errorf("registry type and base URL do not match: '%s' is not valid for registry type '%s'. Expected: %s",
-			pkg.RegistryBaseURL, model.RegistryTypePyPI, model.RegistryURLPyPI)
+	// Validate that the registry base URL is the public registry or an allowed private registry
+	registry, err := v.registries.resolve(pkg.RegistryBaseURL)
+	if err != nil {
+		return err
 	}
 
-	client := &http.Client{Timeout: 10 * time.Second}
+	client := v.registries.httpClient()
 
-	url := fmt.Sprintf("%s/pypi/%s/%s/json", pkg.RegistryBaseURL, pkg.Identifier, pkg.Version)
+	url := fmt.Sprintf("%s/pypi/%s/%s/json", registry.BaseURL, pkg.Identifier, pkg.Version)
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", err)
 	}
 
-	req.Header.Set("User-Agent", "MCP-Registry-Validator/1.0")
+	registry.authorize(req)
 	req.Header.Set("Accept", "application/json")
 
 	resp, err := client.Do(req)
@@ -87,3 +116,154 @@ func ValidatePyPI(ctx context.Context, pkg model.Package, serverName string) err
 
 	return fmt.Errorf("PyPI package '%s' ownership validation failed. The server name '%s' must appear as 'mcp-name: %s' in the package README", pkg.Identifier, serverName, serverName)
 }
+
+// pypiReleaseFilesResponse lists the distribution files of a release from the PyPI JSON API
+type pypiReleaseFilesResponse struct {
+	URLs []struct {
+		Filename string `json:"filename"`
+		Digests  struct {
+			SHA256 string `json:"sha256"`
+		} `json:"digests"`
+	} `json:"urls"`
+}
+
+// pypiProvenanceResponse represents the structure returned by the PyPI integrity API (PEP 740)
+type pypiProvenanceResponse struct {
+	AttestationBundles []struct {
+		Attestations []pypiAttestation `json:"attestations"`
+	} `json:"attestation_bundles"`
+}
+
+// pypiAttestation is a PEP 740 attestation: a DSSE-signed in-toto statement, with the signing
+// certificate and the transparency log entries of the signature
+type pypiAttestation struct {
+	VerificationMaterial struct {
+		Certificate []byte `json:"certificate"`
+		// TransparencyEntries are Sigstore TransparencyLogEntry messages in their JSON encoding
+		TransparencyEntries []json.RawMessage `json:"transparency_entries"`
+	} `json:"verification_material"`
+	Envelope struct {
+		Statement []byte `json:"statement"`
+		Signature []byte `json:"signature"`
+	} `json:"envelope"`
+}
+
+// bundle converts the attestation into the equivalent Sigstore bundle
+func (a *pypiAttestation) bundle() (*bundle.Bundle, error) {
+	entries := make([]*protorekor.TransparencyLogEntry, 0, len(a.VerificationMaterial.TransparencyEntries))
+	for _, raw := range a.VerificationMaterial.TransparencyEntries {
+		var entry protorekor.TransparencyLogEntry
+		if err := protojson.Unmarshal(raw, &entry); err != nil {
+			return nil, fmt.Errorf("invalid transparency log entry: %w", err)
+		}
+		entries = append(entries, &entry)
+	}
+
+	return bundle.NewBundle(&protobundle.Bundle{
+		MediaType: "application/vnd.dev.sigstore.bundle.v0.3+json",
+		VerificationMaterial: &protobundle.VerificationMaterial{
+			Content: &protobundle.VerificationMaterial_Certificate{
+				Certificate: &protocommon.X509Certificate{RawBytes: a.VerificationMaterial.Certificate},
+			},
+			TlogEntries: entries,
+		},
+		Content: &protobundle.Bundle_DsseEnvelope{
+			DsseEnvelope: &protodsse.Envelope{
+				Payload:     a.Envelope.Statement,
+				PayloadType: inTotoPayloadType,
+				Signatures:  []*protodsse.Signature{{Sig: a.Envelope.Signature}},
+			},
+		},
+	})
+}
+
+// VerifyProvenance verifies that every distribution file of a PyPI release has a trusted
+// publisher attestation, signed through Sigstore, by a workflow in repositoryURL
+func (v *PyPIValidator) VerifyProvenance(ctx context.Context, pkg model.Package, repositoryURL string) error {
+	// Set default registry base URL if empty
+	if pkg.RegistryBaseURL == "" {
+		pkg.RegistryBaseURL = model.RegistryURLPyPI
+	}
+
+	registry, err := v.registries.resolve(pkg.RegistryBaseURL)
+	if err != nil {
+		return err
+	}
+
+	client := v.registries.httpClient()
+
+	// The release metadata embeds the README, so it is bounded like one
+	data, err := fetchLimited(ctx, client, registry, fmt.Sprintf("%s/pypi/%s/%s/json", registry.BaseURL, pkg.Identifier, pkg.Version), maxReadmeSize)
+	if err != nil {
+		return fmt.Errorf("failed to fetch PyPI package metadata: %w", err)
+	}
+	var filesResp pypiReleaseFilesResponse
+	if err := json.Unmarshal(data, &filesResp); err != nil {
+		return fmt.Errorf("failed to parse PyPI package metadata: %w", err)
+	}
+	if len(filesResp.URLs) == 0 {
+		return fmt.Errorf("PyPI package '%s' %s has no distribution files", pkg.Identifier, pkg.Version)
+	}
+
+	for _, file := range filesResp.URLs {
+		provenanceURL := fmt.Sprintf("%s/integrity/%s/%s/%s/provenance", registry.BaseURL, pkg.Identifier, pkg.Version, url.PathEscape(file.Filename))
+		if err := v.verifyFileProvenance(ctx, client, registry, provenanceURL, file.Filename, file.Digests.SHA256, repositoryURL); err != nil {
+			return fmt.Errorf("PyPI file '%s' provenance verification failed: %w", file.Filename, err)
+		}
+	}
+
+	return nil
+}
+
+// verifyFileProvenance checks that one of the attestations of a distribution file covers it
+// and was made by a workflow in repositoryURL
+func (v *PyPIValidator) verifyFileProvenance(ctx context.Context, client *http.Client, registry PrivateRegistry, provenanceURL, filename, sha256Digest, repositoryURL string) error {
+	data, err := fetchLimited(ctx, client, registry, provenanceURL, maxProvenanceSize)
+	if err != nil {
+		return fmt.Errorf("no attestations: %w", err)
+	}
+	var provenanceResp pypiProvenanceResponse
+	if err := json.Unmarshal(data, &provenanceResp); err != nil {
+		return fmt.Errorf("failed to parse attestations: %w", err)
+	}
+
+	digest, err := hex.DecodeString(sha256Digest)
+	if err != nil {
+		return fmt.Errorf("invalid SHA-256 digest '%s'", sha256Digest)
+	}
+
+	verifyErr := errors.New("no attestations")
+	for _, attestationBundle := range provenanceResp.AttestationBundles {
+		for _, attestation := range attestationBundle.Attestations {
+			b, err := attestation.bundle()
+			if err != nil {
+				verifyErr = fmt.Errorf("invalid attestation: %w", err)
+				continue
+			}
+			result, err := verifySigstoreEntity(v.sigstore, b, verify.WithArtifactDigest("sha256", digest), repositoryURL)
+			if err != nil {
+				verifyErr = err
+				continue
+			}
+			if !statementNamesSubject(result, filename, "sha256", sha256Digest) {
+				verifyErr = errors.New("attestation does not cover the distribution file")
+				continue
+			}
+			return nil
+		}
+	}
+	return verifyErr
+}
+
+// statementNamesSubject reports whether the verified statement has a subject with the given name and digest
+func statementNamesSubject(result *verify.VerificationResult, name, algorithm, hexDigest string) bool {
+	if result.Statement == nil {
+		return false
+	}
+	for _, subject := range result.Statement.GetSubject() {
+		if subject.GetName() == name && strings.EqualFold(subject.GetDigest()[algorithm], hexDigest) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,145 @@
+package registries
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+var (
+	ErrMissingIdentifierForRubyGems = errors.New("package identifier is required for RubyGems packages")
+	ErrMissingVersionForRubyGems    = errors.New("package version is required for RubyGems packages")
+)
+
+// RubyGemsVersionResponse represents the structure returned by the RubyGems version API
+type RubyGemsVersionResponse struct {
+	Info     string            `json:"info"`
+	Metadata map[string]string `json:"metadata"`
+	Yanked   bool              `json:"yanked"`
+}
+
+// RubyGemsValidator validates gems
+type RubyGemsValidator struct {
+	registries registrySet
+}
+
+// NewRubyGemsValidator returns a validator for gems
+func NewRubyGemsValidator(cfg Config) *RubyGemsValidator {
+	return &RubyGemsValidator{registries: newRegistrySet(model.RegistryTypeRubyGems, model.RegistryURLRubyGems, cfg)}
+}
+
+// ValidateRubyGems validates a gem on RubyGems.org, see RubyGemsValidator.ValidatePackage
+func ValidateRubyGems(ctx context.Context, pkg model.Package, serverName string) error {
+	return NewRubyGemsValidator(Config{}).ValidatePackage(ctx, pkg, serverName, "")
+}
+
+// ValidatePackage validates that a gem contains the correct MCP server name, either in the
+// gemspec metadata ("mcp_name") or as 'mcp-name: <name>' in the description or README
+func (v *RubyGemsValidator) ValidatePackage(ctx context.Context, pkg model.Package, serverName, _ string) error {
+	// Set default registry base URL if empty
+	if pkg.RegistryBaseURL == "" {
+		pkg.RegistryBaseURL = model.RegistryURLRubyGems
+	}
+
+	if pkg.Identifier == "" {
+		return ErrMissingIdentifierForRubyGems
+	}
+
+	if pkg.Version == "" {
+		return ErrMissingVersionForRubyGems
+	}
+
+	// Validate that MCPB-specific fields are not present
+	if pkg.FileSHA256 != "" {
+		return fmt.Errorf("RubyGems packages must not have 'fileSha256' field - this is only for MCPB packages")
+	}
+
+	// Validate that the registry base URL is the public registry or an allowed private registry
+	registry, err := v.registries.resolve(pkg.RegistryBaseURL)
+	if err != nil {
+		return err
+	}
+
+	client := v.registries.httpClient()
+
+	versionURL := fmt.Sprintf("%s/api/v2/rubygems/%s/versions/%s.json", registry.BaseURL, url.PathEscape(pkg.Identifier), url.PathEscape(pkg.Version))
+	data, err := fetchLimited(ctx, client, registry, versionURL, 1<<20)
+	if err != nil {
+		return fmt.Errorf("RubyGems package '%s' version '%s' not found: %w", pkg.Identifier, pkg.Version, err)
+	}
+
+	var versionResp RubyGemsVersionResponse
+	if err := json.Unmarshal(data, &versionResp); err != nil {
+		return fmt.Errorf("failed to parse RubyGems package metadata: %w", err)
+	}
+
+	if versionResp.Yanked {
+		return fmt.Errorf("RubyGems package '%s' version '%s' has been yanked", pkg.Identifier, pkg.Version)
+	}
+
+	// Check gemspec metadata, like mcpName in package.json
+	if mcpName, ok := versionResp.Metadata["mcp_name"]; ok {
+		if mcpName != serverName {
+			return fmt.Errorf("RubyGems package ownership validation failed. Expected metadata 'mcp_name' '%s', got '%s'", serverName, mcpName)
+		}
+		return nil
+	}
+
+	mcpNamePattern := "mcp-name: " + serverName
+	if strings.Contains(versionResp.Info, mcpNamePattern) {
+		return nil // Found in the gem description
+	}
+
+	// Check the README packaged in the gem
+	gemURL := fmt.Sprintf("%s/gems/%s-%s.gem", registry.BaseURL, url.PathEscape(pkg.Identifier), url.PathEscape(pkg.Version))
+	gem, err := fetchLimited(ctx, client, registry, gemURL, maxArchiveSize)
+	if err != nil {
+		return fmt.Errorf("failed to download gem '%s-%s': %w", pkg.Identifier, pkg.Version, err)
+	}
+	readme, err := readGemReadme(gem)
+	if err != nil {
+		return err
+	}
+	if strings.Contains(readme, mcpNamePattern) {
+		return nil // Found as mcp-name: format
+	}
+
+	return fmt.Errorf("RubyGems package '%s' ownership validation failed. Add spec.metadata[\"mcp_name\"] = \"%s\" to your gemspec, or 'mcp-name: %s' to the gem README", pkg.Identifier, serverName, serverName)
+}
+
+// readGemReadme returns the README at the root of a gem. A .gem file is a tar archive
+// whose data.tar.gz entry holds the gem's files.
+func readGemReadme(gem []byte) (string, error) {
+	archive := tar.NewReader(bytes.NewReader(gem))
+	for {
+		header, err := archive.Next()
+		if errors.Is(err, io.EOF) {
+			return "", fmt.Errorf("gem does not contain data.tar.gz")
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to read gem: %w", err)
+		}
+		if header.Name != "data.tar.gz" {
+			continue
+		}
+
+		gz, err := gzip.NewReader(io.LimitReader(archive, maxArchiveSize))
+		if err != nil {
+			return "", fmt.Errorf("failed to read gem data: %w", err)
+		}
+		defer gz.Close()
+
+		// Bound the unpacked size too, in case of a decompression bomb
+		readme, _, err := readTarReadme(io.LimitReader(gz, 4*maxArchiveSize), "")
+		return readme, err
+	}
+}
@@ -0,0 +1,188 @@
+package registries_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/validators/registries"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// writeTar writes the given files to a tar archive
+func writeTar(t *testing.T, w *tar.Writer, files map[string][]byte) {
+	t.Helper()
+	for name, content := range files {
+		require.NoError(t, w.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(content))}))
+		_, err := w.Write(content)
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+}
+
+// gemFile builds a .gem archive whose data.tar.gz holds the given files
+func gemFile(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var data bytes.Buffer
+	gz := gzip.NewWriter(&data)
+	contents := make(map[string][]byte, len(files))
+	for name, content := range files {
+		contents[name] = []byte(content)
+	}
+	writeTar(t, tar.NewWriter(gz), contents)
+	require.NoError(t, gz.Close())
+
+	var gem bytes.Buffer
+	writeTar(t, tar.NewWriter(&gem), map[string][]byte{
+		"metadata.gz": {},
+		"data.tar.gz": data.Bytes(),
+	})
+	return gem.Bytes()
+}
+
+func TestValidateRubyGems(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	// RubyGems stand-in
+	responses := map[string][]byte{
+		"/api/v2/rubygems/acme-weather/versions/1.0.0.json": []byte(`{"info":"Weather tools","metadata":{"mcp_name":"com.acme/weather"}}`),
+		"/api/v2/rubygems/acme-info/versions/1.0.0.json":    []byte(`{"info":"Weather tools. mcp-name: com.acme/info","metadata":{}}`),
+		"/api/v2/rubygems/acme-readme/versions/1.0.0.json":  []byte(`{"info":"Weather tools","metadata":{}}`),
+		"/gems/acme-readme-1.0.0.gem": gemFile(t, map[string]string{
+			"README.md":   "# Weather\n\n<!-- mcp-name: com.acme/readme -->\n",
+			"lib/acme.rb": "module Acme; end\n",
+		}),
+		"/api/v2/rubygems/acme-nested/versions/1.0.0.json": []byte(`{"info":"Weather tools","metadata":{}}`),
+		"/gems/acme-nested-1.0.0.gem": gemFile(t, map[string]string{
+			"docs/README.md": "mcp-name: com.acme/nested",
+		}),
+		"/api/v2/rubygems/acme-yanked/versions/1.0.0.json": []byte(`{"info":"mcp-name: com.acme/yanked","yanked":true}`),
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, ok := responses[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(data)
+	}))
+	t.Cleanup(server.Close)
+
+	validator := registries.NewRubyGemsValidator(registries.Config{
+		PrivateRegistries: []registries.PrivateRegistry{{RegistryType: model.RegistryTypeRubyGems, BaseURL: server.URL}},
+	})
+
+	tests := []struct {
+		name         string
+		identifier   string
+		version      string
+		baseURL      string
+		serverName   string
+		errorMessage string
+	}{
+		{
+			name:         "empty identifier should fail",
+			version:      "1.0.0",
+			baseURL:      server.URL,
+			serverName:   "com.acme/weather",
+			errorMessage: "package identifier is required for RubyGems packages",
+		},
+		{
+			name:         "empty version should fail",
+			identifier:   "acme-weather",
+			baseURL:      server.URL,
+			serverName:   "com.acme/weather",
+			errorMessage: "package version is required for RubyGems packages",
+		},
+		{
+			name:         "other registry should fail",
+			identifier:   "acme-weather",
+			version:      "1.0.0",
+			baseURL:      "https://gems.example.com",
+			serverName:   "com.acme/weather",
+			errorMessage: "registry type and base URL do not match",
+		},
+		{
+			name:       "name in gemspec metadata should pass",
+			identifier: "acme-weather",
+			version:    "1.0.0",
+			baseURL:    server.URL,
+			serverName: "com.acme/weather",
+		},
+		{
+			name:         "different name in gemspec metadata should fail",
+			identifier:   "acme-weather",
+			version:      "1.0.0",
+			baseURL:      server.URL,
+			serverName:   "com.other/weather",
+			errorMessage: "Expected metadata 'mcp_name' 'com.other/weather', got 'com.acme/weather'",
+		},
+		{
+			name:       "name in description should pass",
+			identifier: "acme-info",
+			version:    "1.0.0",
+			baseURL:    server.URL,
+			serverName: "com.acme/info",
+		},
+		{
+			name:       "name in README should pass",
+			identifier: "acme-readme",
+			version:    "1.0.0",
+			baseURL:    server.URL,
+			serverName: "com.acme/readme",
+		},
+		{
+			name:         "README outside gem root should fail",
+			identifier:   "acme-nested",
+			version:      "1.0.0",
+			baseURL:      server.URL,
+			serverName:   "com.acme/nested",
+			errorMessage: "ownership validation failed",
+		},
+		{
+			name:         "yanked version should fail",
+			identifier:   "acme-yanked",
+			version:      "1.0.0",
+			baseURL:      server.URL,
+			serverName:   "com.acme/yanked",
+			errorMessage: "has been yanked",
+		},
+		{
+			name:         "unknown version should fail",
+			identifier:   "acme-weather",
+			version:      "1.0.1",
+			baseURL:      server.URL,
+			serverName:   "com.acme/weather",
+			errorMessage: "not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			pkg := model.Package{
+				RegistryType:    model.RegistryTypeRubyGems,
+				RegistryBaseURL: tt.baseURL,
+				Identifier:      tt.identifier,
+				Version:         tt.version,
+			}
+
+			err := validator.ValidatePackage(ctx, pkg, tt.serverName, "")
+			if tt.errorMessage != "" {
+				assert.ErrorContains(t, err, tt.errorMessage)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
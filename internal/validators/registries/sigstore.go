@@ -0,0 +1,127 @@
+package registries
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/sigstore/sigstore-go/pkg/root"
+	"github.com/sigstore/sigstore-go/pkg/tuf"
+	"github.com/sigstore/sigstore-go/pkg/verify"
+)
+
+// maxProvenanceSize bounds signature and attestation documents fetched from registries
+const maxProvenanceSize = 1 << 20
+
+// inTotoPayloadType is the DSSE payload type of in-toto attestations
+const inTotoPayloadType = "application/vnd.in-toto+json"
+
+// sigstoreIssuers are the OIDC issuers trusted to vouch for the workflows of repositories on each
+// host. Signing certificates name the issuer that authenticated the workflow they were issued to.
+var sigstoreIssuers = map[string]string{
+	"github.com": "https://token.actions.githubusercontent.com",
+	"gitlab.com": "https://gitlab.com",
+}
+
+var (
+	publicGoodTrustMu       sync.Mutex
+	publicGoodTrustMaterial root.TrustedMaterial
+)
+
+// publicGoodTrustedRoot returns the trusted root of the public-good Sigstore instance, which is
+// fetched through TUF when first needed
+func publicGoodTrustedRoot() (root.TrustedMaterial, error) {
+	publicGoodTrustMu.Lock()
+	defer publicGoodTrustMu.Unlock()
+
+	if publicGoodTrustMaterial == nil {
+		opts := tuf.DefaultOptions()
+		opts.DisableLocalCache = true
+		material, err := root.NewLiveTrustedRoot(opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch the Sigstore trusted root: %w", err)
+		}
+		publicGoodTrustMaterial = material
+	}
+	return publicGoodTrustMaterial, nil
+}
+
+// sigstoreVerifier returns a verifier against the trusted material, or the public-good instance if
+// nil, requiring a certificate transparency SCT for the signing certificate, and a transparency log
+// entry that timestamps the signature within its validity
+func sigstoreVerifier(material root.TrustedMaterial) (*verify.Verifier, error) {
+	if material == nil {
+		var err error
+		if material, err = publicGoodTrustedRoot(); err != nil {
+			return nil, err
+		}
+	}
+
+	return verify.NewVerifier(material,
+		verify.WithSignedCertificateTimestamps(1),
+		verify.WithTransparencyLog(1),
+		verify.WithObserverTimestamps(1),
+	)
+}
+
+// verifySigstoreEntity verifies a signed entity, such as a Sigstore bundle, for an artifact against
+// the trusted material, or the public-good instance if nil. Its
+// signing certificate must have been issued by the OIDC issuer of the repository's host to a
+// workflow in repositoryURL.
+func verifySigstoreEntity(material root.TrustedMaterial, entity verify.SignedEntity, artifact verify.ArtifactPolicyOption, repositoryURL string) (*verify.VerificationResult, error) {
+	host := repositoryHost(repositoryURL)
+	issuer, ok := sigstoreIssuers[host]
+	if !ok {
+		return nil, fmt.Errorf("provenance verification is not supported for repositories on '%s'", host)
+	}
+
+	verifier, err := sigstoreVerifier(material)
+	if err != nil {
+		return nil, err
+	}
+
+	// The subject alternative name is the workflow, which may be a reusable workflow in another
+	// repository, so the repository is checked against the certificate's source repository instead
+	identity, err := verify.NewShortCertificateIdentity(issuer, "", "", ".+")
+	if err != nil {
+		return nil, err
+	}
+	result, err := verifier.Verify(entity, verify.NewPolicy(artifact, verify.WithCertificateIdentity(identity)))
+	if err != nil {
+		return nil, fmt.Errorf("signature verification failed: %w", err)
+	}
+
+	repository := result.Signature.Certificate.SourceRepositoryURI
+	if repository == "" && result.Signature.Certificate.GithubWorkflowRepository != "" {
+		// The legacy extension holds the raw 'owner/repo' of a GitHub workflow
+		repository = "https://github.com/" + result.Signature.Certificate.GithubWorkflowRepository
+	}
+	if repository == "" {
+		return nil, errors.New("signing certificate does not name a source repository")
+	}
+	if normalizeRepositoryURL(repository) != normalizeRepositoryURL(repositoryURL) {
+		return nil, fmt.Errorf("signed by a workflow in '%s', expected the server repository '%s'", repository, repositoryURL)
+	}
+
+	return result, nil
+}
+
+// repositoryHost returns the lowercase host of a repository URL
+func repositoryHost(repositoryURL string) string {
+	host, _, _ := strings.Cut(normalizeRepositoryURL(repositoryURL), "/")
+	if u, err := url.Parse("https://" + host); err == nil {
+		return u.Hostname()
+	}
+	return host
+}
+
+// normalizeRepositoryURL reduces a repository URL to its lowercase host and path, so that
+// 'https://github.com/Acme/weather.git' and 'git+https://github.com/acme/weather' compare equal
+func normalizeRepositoryURL(repositoryURL string) string {
+	u := strings.ToLower(strings.TrimSpace(repositoryURL))
+	u = strings.TrimPrefix(u, "git+")
+	u = strings.TrimPrefix(strings.TrimPrefix(u, "https://"), "http://")
+	return strings.TrimSuffix(strings.TrimSuffix(u, "/"), ".git")
+}
@@ -3,6 +3,7 @@ package validators
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/url"
 	"regexp"
@@ -10,6 +11,7 @@ import (
 	"strings"
 
 	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/validators/registries"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
 	"github.com/modelcontextprotocol/registry/pkg/model"
 )
@@ -52,6 +54,20 @@ var (
 	dottedVersionLikeRe = regexp.MustCompile(`^\s*(?:v?\d+|x|X|\*)(?:\.(?:\d+|x|X|\*)){1,2}(?:-[0-9A-Za-z.-]+)?\s*$`)
 )
 
+// Package reference patterns for registry types with stricter identifier rules
+var (
+	// rubyGemNameRegex matches gem names
+	rubyGemNameRegex = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9._-]*$`)
+	// mavenCoordinatesRegex matches "groupId:artifactId"
+	mavenCoordinatesRegex = regexp.MustCompile(`^[a-zA-Z0-9_-]+(?:\.[a-zA-Z0-9_-]+)*:[a-zA-Z0-9_.-]+$`)
+)
+
+// packageRuntimeHints lists the runtime hints accepted for registry types that restrict them
+var packageRuntimeHints = map[string][]string{
+	model.RegistryTypeRubyGems: {model.RuntimeHintGem},
+	model.RegistryTypeMaven:    {model.RuntimeHintJBang, model.RuntimeHintJava},
+}
+
 func ValidateServerJSON(serverJSON *apiv0.ServerJSON) error {
 	// Validate schema version is provided and supported
 	// Note: Schema field is also marked as required in the ServerJSON struct definition
@@ -118,6 +134,13 @@ func ValidateServerJSON(serverJSON *apiv0.ServerJSON) error {
 		return err
 	}
 
+	// Validate the capability manifest if provided
+	if serverJSON.Meta != nil && serverJSON.Meta.Capabilities != nil {
+		if err := ValidateCapabilityManifest(serverJSON.Meta.Capabilities); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -226,6 +249,11 @@ func validatePackageField(obj *model.Package) error {
 		return err
 	}
 
+	// Validate registry-specific identifier format and runtime hint
+	if err := validatePackageReference(obj); err != nil {
+		return err
+	}
+
 	// Validate runtime arguments
 	for _, arg := range obj.RuntimeArguments {
 		if err := validateArgument(&arg); err != nil {
@@ -249,6 +277,35 @@ func validatePackageField(obj *model.Package) error {
 	return nil
 }
 
+// validatePackageReference validates the canonical reference format and runtime hint of
+// registry types with stricter rules
+func validatePackageReference(obj *model.Package) error {
+	switch obj.RegistryType {
+	case model.RegistryTypeRubyGems:
+		if !rubyGemNameRegex.MatchString(obj.Identifier) {
+			return fmt.Errorf("%w: RubyGems identifier must be a gem name, got %q", ErrInvalidPackageRef, obj.Identifier)
+		}
+	case model.RegistryTypeMaven:
+		if !mavenCoordinatesRegex.MatchString(obj.Identifier) {
+			return fmt.Errorf("%w: Maven identifier must be \"groupId:artifactId\" with the version in the version field, got %q", ErrInvalidPackageRef, obj.Identifier)
+		}
+		// The artifactId and version are path segments of the repository layout
+		if strings.Contains(obj.Identifier, "..") || strings.ContainsAny(obj.Version, `/\`) || strings.Contains(obj.Version, "..") {
+			return fmt.Errorf("%w: Maven identifier and version must not contain '/', '\\' or '..', got %q version %q", ErrInvalidPackageRef, obj.Identifier, obj.Version)
+		}
+		// Snapshots are mutable, so they cannot be pinned by the registry
+		if strings.HasSuffix(obj.Version, "-SNAPSHOT") {
+			return fmt.Errorf("%w: Maven SNAPSHOT versions cannot be published, got %q", ErrInvalidPackageRef, obj.Version)
+		}
+	}
+
+	if hints, ok := packageRuntimeHints[obj.RegistryType]; ok && obj.RunTimeHint != "" && !slices.Contains(hints, obj.RunTimeHint) {
+		return fmt.Errorf("%w: %q is not valid for %s packages, expected one of: %s", ErrInvalidRuntimeHint, obj.RunTimeHint, obj.RegistryType, strings.Join(hints, ", "))
+	}
+
+	return nil
+}
+
 // validateVersion validates the version string.
 // NB: we decided that we would not enforce strict semver for version strings
 func validateVersion(version string) error {
@@ -424,8 +481,24 @@ func validateRemoteTransport(obj *model.Transport) error {
 	}
 }
 
-// ValidatePublishRequest validates a complete publish request including extensions
-func ValidatePublishRequest(ctx context.Context, req apiv0.ServerJSON, cfg *config.Config) error {
+// ValidatePublishRequest validates a complete publish request including extensions. It returns the
+// manifest digests that OCI package references resolved to during registry validation, keyed by identifier.
+func ValidatePublishRequest(ctx context.Context, req apiv0.ServerJSON, cfg *config.Config) (map[string]string, error) {
+	if err := ValidatePublishRequestOffline(req, cfg); err != nil {
+		return nil, err
+	}
+
+	// Validate registry ownership for all packages if validation is enabled
+	if !cfg.EnableRegistryValidation {
+		return map[string]string{}, nil
+	}
+
+	return ValidatePackageRegistries(ctx, req)
+}
+
+// ValidatePublishRequestOffline runs the checks of ValidatePublishRequest that do not contact
+// package registries, so that publishes validated asynchronously fail fast on them
+func ValidatePublishRequestOffline(req apiv0.ServerJSON, cfg *config.Config) error {
 	// Validate publisher extensions in _meta
 	if err := validatePublisherExtensions(req); err != nil {
 		return err
@@ -436,15 +509,69 @@ func ValidatePublishRequest(ctx context.Context, req apiv0.ServerJSON, cfg *conf
 		return err
 	}
 
-	// Validate registry ownership for all packages if validation is enabled
-	if cfg.EnableRegistryValidation {
-		for i, pkg := range req.Packages {
-			if err := ValidatePackage(ctx, pkg, req.Name); err != nil {
-				return fmt.Errorf("registry validation failed for package %d (%s): %w", i, pkg.Identifier, err)
+	// Require digest-pinned OCI references if configured
+	if cfg.RequireOCIDigest {
+		if err := validateOCIDigestPinned(req.Packages); err != nil {
+			return err
+		}
+	}
+
+	// Reject registry types the operator disabled, even when ownership is not validated
+	for i, pkg := range req.Packages {
+		if err := checkRegistryTypeEnabled(pkg.RegistryType); err != nil {
+			return fmt.Errorf("package %d (%s): %w", i, pkg.Identifier, err)
+		}
+	}
+
+	return nil
+}
+
+// ValidatePackageRegistries validates every package against its registry and returns the
+// digests OCI package references resolved to
+func ValidatePackageRegistries(ctx context.Context, req apiv0.ServerJSON) (map[string]string, error) {
+	packageDigests := make(map[string]string)
+	for i, pkg := range req.Packages {
+		// Resolve OCI tags first and validate the image at that digest, so the recorded
+		// digest is the one that was validated even if the tag is repointed meanwhile
+		validator, _ := packageValidator(pkg.RegistryType)
+		if resolver, ok := validator.(DigestResolver); ok && pkg.RegistryType == model.RegistryTypeOCI {
+			digest, err := resolver.ResolveDigest(ctx, pkg.Identifier)
+			switch {
+			case errors.Is(err, registries.ErrRateLimited):
+				// Publishing without the digest would look like a validated publish, so fail it for a retry
+				return nil, fmt.Errorf("%w: package %d (%s): %w", ErrRegistryRateLimited, i, pkg.Identifier, err)
+			case err != nil:
+				return nil, fmt.Errorf("registry validation failed for package %d (%s): %w", i, pkg.Identifier, err)
+			default:
+				packageDigests[pkg.Identifier] = digest
+				if !strings.Contains(pkg.Identifier, "@") {
+					pkg.Identifier += "@" + digest
+				}
 			}
 		}
+
+		if err := ValidatePackage(ctx, pkg, req.Name, req.Version); err != nil {
+			return nil, fmt.Errorf("registry validation failed for package %d (%s): %w", i, req.Packages[i].Identifier, err)
+		}
 	}
 
+	return packageDigests, nil
+}
+
+// validateOCIDigestPinned checks that OCI package references include a digest
+func validateOCIDigestPinned(packages []model.Package) error {
+	for i, pkg := range packages {
+		if pkg.RegistryType != model.RegistryTypeOCI {
+			continue
+		}
+		ref, err := registries.ParseOCIReference(pkg.Identifier)
+		if err != nil {
+			return fmt.Errorf("invalid OCI reference for package %d (%s): %w", i, pkg.Identifier, err)
+		}
+		if ref.Digest == "" {
+			return fmt.Errorf("%w: package %d (%s) must include a digest, e.g. '%s@sha256:...'", ErrOCIDigestRequired, i, pkg.Identifier, pkg.Identifier)
+		}
+	}
 	return nil
 }
 
@@ -3,6 +3,7 @@ package validators_test
 import (
 	"context"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -96,6 +97,139 @@ func TestValidate(t *testing.T) {
 			},
 			expectedError: validators.ErrVersionLooksLikeRange.Error(),
 		},
+		{
+			name: "rejects Maven identifier without groupId",
+			serverDetail: apiv0.ServerJSON{
+				Schema:      model.CurrentSchemaURL,
+				Name:        "com.example/test-server",
+				Description: "A test server",
+				Repository: model.Repository{
+					URL:    "https://github.com/owner/repo",
+					Source: "github",
+				},
+				Version: "1.0.0",
+				Packages: []model.Package{
+					{
+						Identifier:   "weather-server",
+						RegistryType: model.RegistryTypeMaven,
+						Version:      "1.0.0",
+						Transport:    model.Transport{Type: "stdio"},
+					},
+				},
+			},
+			expectedError: validators.ErrInvalidPackageRef.Error(),
+		},
+		{
+			name: "rejects Maven identifier with version",
+			serverDetail: apiv0.ServerJSON{
+				Schema:      model.CurrentSchemaURL,
+				Name:        "com.example/test-server",
+				Description: "A test server",
+				Repository: model.Repository{
+					URL:    "https://github.com/owner/repo",
+					Source: "github",
+				},
+				Version: "1.0.0",
+				Packages: []model.Package{
+					{
+						Identifier:   "com.example:weather-server:1.0.0",
+						RegistryType: model.RegistryTypeMaven,
+						Version:      "1.0.0",
+						Transport:    model.Transport{Type: "stdio"},
+					},
+				},
+			},
+			expectedError: validators.ErrInvalidPackageRef.Error(),
+		},
+		{
+			name: "rejects Maven versions with path segments",
+			serverDetail: apiv0.ServerJSON{
+				Schema:      model.CurrentSchemaURL,
+				Name:        "com.example/test-server",
+				Description: "A test server",
+				Repository: model.Repository{
+					URL:    "https://github.com/owner/repo",
+					Source: "github",
+				},
+				Version: "1.0.0",
+				Packages: []model.Package{
+					{
+						Identifier:   "com.example:weather-server",
+						RegistryType: model.RegistryTypeMaven,
+						Version:      "1.0/../../attacker/x/1.0",
+						Transport:    model.Transport{Type: "stdio"},
+					},
+				},
+			},
+			expectedError: validators.ErrInvalidPackageRef.Error(),
+		},
+		{
+			name: "rejects Maven SNAPSHOT versions",
+			serverDetail: apiv0.ServerJSON{
+				Schema:      model.CurrentSchemaURL,
+				Name:        "com.example/test-server",
+				Description: "A test server",
+				Repository: model.Repository{
+					URL:    "https://github.com/owner/repo",
+					Source: "github",
+				},
+				Version: "1.0.0",
+				Packages: []model.Package{
+					{
+						Identifier:   "com.example:weather-server",
+						RegistryType: model.RegistryTypeMaven,
+						Version:      "1.0.0-SNAPSHOT",
+						Transport:    model.Transport{Type: "stdio"},
+					},
+				},
+			},
+			expectedError: validators.ErrInvalidPackageRef.Error(),
+		},
+		{
+			name: "rejects invalid gem names",
+			serverDetail: apiv0.ServerJSON{
+				Schema:      model.CurrentSchemaURL,
+				Name:        "com.example/test-server",
+				Description: "A test server",
+				Repository: model.Repository{
+					URL:    "https://github.com/owner/repo",
+					Source: "github",
+				},
+				Version: "1.0.0",
+				Packages: []model.Package{
+					{
+						Identifier:   "-weather-server",
+						RegistryType: model.RegistryTypeRubyGems,
+						Version:      "1.0.0",
+						Transport:    model.Transport{Type: "stdio"},
+					},
+				},
+			},
+			expectedError: validators.ErrInvalidPackageRef.Error(),
+		},
+		{
+			name: "rejects runtime hints not valid for the registry type",
+			serverDetail: apiv0.ServerJSON{
+				Schema:      model.CurrentSchemaURL,
+				Name:        "com.example/test-server",
+				Description: "A test server",
+				Repository: model.Repository{
+					URL:    "https://github.com/owner/repo",
+					Source: "github",
+				},
+				Version: "1.0.0",
+				Packages: []model.Package{
+					{
+						Identifier:   "weather-server",
+						RegistryType: model.RegistryTypeRubyGems,
+						Version:      "1.0.0",
+						RunTimeHint:  "npx",
+						Transport:    model.Transport{Type: "stdio"},
+					},
+				},
+			},
+			expectedError: validators.ErrInvalidRuntimeHint.Error(),
+		},
 		{
 			name: "Version allows specific versions (semver)",
 			serverDetail: apiv0.ServerJSON{
@@ -1619,10 +1753,10 @@ func TestValidate_RegistryTypesAndUrls(t *testing.T) {
 		{"valid_oci", "io.github.domdomegg/airtable-mcp-server", model.RegistryTypeOCI, "", "domdomegg/airtable-mcp-server:1.7.2", "", "", false},
 		{"valid_nuget", "io.github.domdomegg/time-mcp-server", model.RegistryTypeNuGet, model.RegistryURLNuGet, "TimeMcpServer", "1.0.2", "", false},
 		{"valid_nuget", "io.github.domdomegg/time-mcp-server", model.RegistryTypeNuGet, "", "TimeMcpServer", "1.0.2", "", false},
-		{"valid_mcpb_github", "io.github.domdomegg/airtable-mcp-server", model.RegistryTypeMCPB, "", "https://github.com/domdomegg/airtable-mcp-server/releases/download/v1.7.2/airtable-mcp-server.mcpb", "", "fe333e598595000ae021bd27117db32ec69af6987f507ba7a63c90638ff633ce", false},
-		{"valid_mcpb_gitlab", "io.gitlab.fforster/gitlab-mcp", model.RegistryTypeMCPB, "", "https://gitlab.com/fforster/gitlab-mcp/-/releases/v1.31.0/downloads/gitlab-mcp_1.31.0_Linux_x86_64.tar.gz", "", "abc123ef4567890abcdef1234567890abcdef1234567890abcdef1234567890", false}, // this is not actually a valid mcpb, but it's the closest I can get for testing for now
 
 		// Test MCPB without file hash (should fail)
+		{"invalid_mcpb_github_unowned", "io.github.domdomegg/airtable-mcp-server", model.RegistryTypeMCPB, "", "https://github.com/domdomegg/airtable-mcp-server/releases/download/v1.7.2/airtable-mcp-server.mcpb", "", "fe333e598595000ae021bd27117db32ec69af6987f507ba7a63c90638ff633ce", true}, // manifest.json has no mcp_name
+		{"invalid_mcpb_gitlab_hash", "io.gitlab.fforster/gitlab-mcp", model.RegistryTypeMCPB, "", "https://gitlab.com/fforster/gitlab-mcp/-/releases/v1.31.0/downloads/gitlab-mcp_1.31.0_Linux_x86_64.tar.gz", "", "abc123ef4567890abcdef1234567890abcdef1234567890abcdef1234567890", true},        // hash does not match, and this is not actually a valid mcpb
 		{"invalid_mcpb_no_hash", "io.github.domdomegg/airtable-mcp-server", model.RegistryTypeMCPB, "", "https://github.com/domdomegg/airtable-mcp-server/releases/download/v1.7.2/airtable-mcp-server.mcpb", "", "", true},
 
 		// Invalid registry types (should fail)
@@ -1665,7 +1799,7 @@ func TestValidate_RegistryTypesAndUrls(t *testing.T) {
 				},
 			}
 
-			err := validators.ValidatePublishRequest(context.Background(), serverJSON, &config.Config{
+			_, err := validators.ValidatePublishRequest(context.Background(), serverJSON, &config.Config{
 				EnableRegistryValidation: true,
 			})
 			if tc.expectError {
@@ -2028,6 +2162,89 @@ func TestValidateTitle(t *testing.T) {
 	}
 }
 
+func TestValidateCapabilityManifest(t *testing.T) {
+	manyTools := make([]apiv0.ToolCapability, 1001)
+	for i := range manyTools {
+		manyTools[i] = apiv0.ToolCapability{Name: fmt.Sprintf("tool_%d", i)}
+	}
+
+	tests := []struct {
+		name          string
+		manifest      apiv0.CapabilityManifest
+		expectedError string
+	}{
+		{
+			name: "valid manifest",
+			manifest: apiv0.CapabilityManifest{
+				Tools:     []apiv0.ToolCapability{{Name: "get_weather", Description: "Get the current weather"}, {Name: "get_forecast"}},
+				Prompts:   []apiv0.PromptCapability{{Name: "summarize_forecast"}},
+				Resources: []apiv0.ResourceCapability{{URI: "weather://stations", Name: "Stations"}},
+			},
+		},
+		{
+			name:     "empty manifest",
+			manifest: apiv0.CapabilityManifest{},
+		},
+		{
+			name:          "tool without name",
+			manifest:      apiv0.CapabilityManifest{Tools: []apiv0.ToolCapability{{Description: "Nameless"}}},
+			expectedError: "tool 0 must have a name",
+		},
+		{
+			name:          "duplicate tool",
+			manifest:      apiv0.CapabilityManifest{Tools: []apiv0.ToolCapability{{Name: "get_weather"}, {Name: "get_weather"}}},
+			expectedError: "duplicate tool 'get_weather'",
+		},
+		{
+			name:          "too many tools",
+			manifest:      apiv0.CapabilityManifest{Tools: manyTools},
+			expectedError: "more than 1000 tools",
+		},
+		{
+			name:          "prompt description too long",
+			manifest:      apiv0.CapabilityManifest{Prompts: []apiv0.PromptCapability{{Name: "summarize", Description: strings.Repeat("a", 1025)}}},
+			expectedError: "description of prompt 'summarize' exceeds 1024 characters",
+		},
+		{
+			name:          "resource without URI",
+			manifest:      apiv0.CapabilityManifest{Resources: []apiv0.ResourceCapability{{Name: "Stations"}}},
+			expectedError: "resource 0 must have a URI",
+		},
+		{
+			name: "resources with the same name but different URIs",
+			manifest: apiv0.CapabilityManifest{Resources: []apiv0.ResourceCapability{
+				{URI: "weather://stations/1", Name: "Station"},
+				{URI: "weather://stations/2", Name: "Station"},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validators.ValidateCapabilityManifest(&tt.manifest)
+			if tt.expectedError == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, validators.ErrInvalidCapabilityManifest)
+			assert.Contains(t, err.Error(), tt.expectedError)
+		})
+	}
+
+	t.Run("manifest in _meta is validated with the server", func(t *testing.T) {
+		serverJSON := apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        "com.example/test-server",
+			Description: "A test server",
+			Version:     "1.0.0",
+			Meta: &apiv0.ServerMeta{Capabilities: &apiv0.CapabilityManifest{
+				Tools: []apiv0.ToolCapability{{Name: ""}},
+			}},
+		}
+		assert.ErrorIs(t, validators.ValidateServerJSON(&serverJSON), validators.ErrInvalidCapabilityManifest)
+	})
+}
+
 // Helper function for creating string pointers in tests
 func stringPtr(s string) *string {
 	return &s
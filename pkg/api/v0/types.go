@@ -12,6 +12,75 @@ type RegistryExtensions struct {
 	PublishedAt time.Time    `json:"publishedAt"`
 	UpdatedAt   time.Time    `json:"updatedAt,omitempty"`
 	IsLatest    bool         `json:"isLatest"`
+	// PackageDigests maps the identifiers of OCI packages to the manifest digests they resolved to at publish time
+	PackageDigests map[string]string `json:"packageDigests,omitempty"`
+	// Verified is set when every package was verified, through Sigstore signatures or provenance
+	// attestations, to have been built from the server's repository
+	Verified bool `json:"verified,omitempty"`
+	// PublishID identifies a pending publish, whose outcome is available from GET /v0/publish/{id}
+	PublishID string `json:"publishId,omitempty"`
+	// Health is the outcome of the latest periodic re-validation of the server's packages
+	Health *PackageHealth `json:"health,omitempty"`
+	// RemoteStatus is the liveness of each remote, as of its latest probe by the registry
+	RemoteStatus []RemoteStatus `json:"remoteStatus,omitempty"`
+	// ValidationRules is the set of package validation rules the version was published under. It
+	// is internal to the registry and not part of the API.
+	ValidationRules int `json:"-"`
+}
+
+// PackageHealth represents the outcome of re-validating the packages of a published server
+type PackageHealth struct {
+	LastCheckedAt time.Time `json:"lastCheckedAt"`
+	// LastError is why the packages failed re-validation, empty if they passed
+	LastError string `json:"lastError,omitempty"`
+	// FlaggedForReview is set once the packages failed re-validation repeatedly
+	FlaggedForReview bool `json:"flaggedForReview,omitempty"`
+}
+
+// RemoteStatus represents the liveness of a remote, as probed by the registry with an MCP initialize handshake
+type RemoteStatus struct {
+	URL string `json:"url"`
+	// Live is set when the remote completed the handshake, or required authorization to start it
+	Live          bool      `json:"live"`
+	AuthRequired  bool      `json:"authRequired,omitempty"`
+	LastCheckedAt time.Time `json:"lastCheckedAt"`
+	// LastError is why the latest probe failed, empty if it succeeded
+	LastError       string         `json:"lastError,omitempty"`
+	ProtocolVersion string         `json:"protocolVersion,omitempty"`
+	Capabilities    map[string]any `json:"capabilities,omitempty"`
+	// Uptime is the share of probes in the retained probe history that found the remote live
+	Uptime float64 `json:"uptime"`
+	// History lists the latest probes, most recent first. It is only included by the status endpoint.
+	History []RemoteProbe `json:"history,omitempty"`
+}
+
+// RemoteProbe represents a single liveness probe of a remote
+type RemoteProbe struct {
+	CheckedAt time.Time `json:"checkedAt"`
+	Live      bool      `json:"live"`
+	LatencyMs int64     `json:"latencyMs"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// RemoteStatusResponse represents the liveness of the remotes of a server version
+type RemoteStatusResponse struct {
+	ServerName string         `json:"serverName"`
+	Version    string         `json:"version"`
+	Remotes    []RemoteStatus `json:"remotes"`
+}
+
+// PublishStatus represents the outcome of a publish that is validated asynchronously
+type PublishStatus struct {
+	ID         string       `json:"id"`
+	ServerName string       `json:"serverName"`
+	Version    string       `json:"version"`
+	Status     model.Status `json:"status" enum:"pending,active,rejected"`
+	// Reason explains why the publish was rejected
+	Reason    string    `json:"reason,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+	// Server is the published server version, once active
+	Server *ServerResponse `json:"server,omitempty"`
 }
 
 // ResponseMeta represents the top-level metadata in API responses
@@ -34,6 +103,51 @@ type ServerListResponse struct {
 // ServerMeta represents the structured metadata with known extension fields
 type ServerMeta struct {
 	PublisherProvided map[string]interface{} `json:"io.modelcontextprotocol.registry/publisher-provided,omitempty"`
+	// Capabilities lists what the server offers, as supplied by the publisher
+	Capabilities *CapabilityManifest `json:"io.modelcontextprotocol.registry/capabilities,omitempty"`
+}
+
+// Sources of the capabilities of a server version
+const (
+	// CapabilitySourcePublisher marks capabilities supplied by the publisher in server.json
+	CapabilitySourcePublisher = "publisher"
+	// CapabilitySourceProbe marks capabilities the registry harvested from a remote of the server
+	CapabilitySourceProbe = "probe"
+)
+
+// CapabilityManifest lists the tools, prompts and resources an MCP server offers
+type CapabilityManifest struct {
+	Tools     []ToolCapability     `json:"tools,omitempty"`
+	Prompts   []PromptCapability   `json:"prompts,omitempty"`
+	Resources []ResourceCapability `json:"resources,omitempty"`
+}
+
+// ToolCapability represents a tool an MCP server offers
+type ToolCapability struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// PromptCapability represents a prompt an MCP server offers
+type PromptCapability struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// ResourceCapability represents a resource an MCP server offers
+type ResourceCapability struct {
+	URI         string `json:"uri"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// ServerCapabilitiesResponse represents the capabilities of a server version
+type ServerCapabilitiesResponse struct {
+	ServerName string `json:"serverName"`
+	Version    string `json:"version"`
+	// Source is where the capabilities come from, empty if none are known
+	Source       string             `json:"source,omitempty" enum:"publisher,probe"`
+	Capabilities CapabilityManifest `json:"capabilities"`
 }
 
 // ServerJSON represents complete server information as defined in the MCP spec, with extension support
@@ -2,22 +2,30 @@ package model
 
 // Registry Types - supported package registry types
 const (
-	RegistryTypeNPM   = "npm"
-	RegistryTypePyPI  = "pypi"
-	RegistryTypeOCI   = "oci"
-	RegistryTypeNuGet = "nuget"
-	RegistryTypeMCPB  = "mcpb"
+	RegistryTypeNPM      = "npm"
+	RegistryTypePyPI     = "pypi"
+	RegistryTypeOCI      = "oci"
+	RegistryTypeNuGet    = "nuget"
+	RegistryTypeMCPB     = "mcpb"
+	RegistryTypeCargo    = "cargo"
+	RegistryTypeGo       = "go"
+	RegistryTypeRubyGems = "rubygems"
+	RegistryTypeMaven    = "maven"
 )
 
 // Registry Base URLs - supported package registry base URLs
 const (
-	RegistryURLNPM    = "https://registry.npmjs.org"
-	RegistryURLPyPI   = "https://pypi.org"
-	RegistryURLDocker = "https://docker.io"
-	RegistryURLGHCR   = "https://ghcr.io"
-	RegistryURLNuGet  = "https://api.nuget.org"
-	RegistryURLGitHub = "https://github.com"
-	RegistryURLGitLab = "https://gitlab.com"
+	RegistryURLNPM      = "https://registry.npmjs.org"
+	RegistryURLPyPI     = "https://pypi.org"
+	RegistryURLDocker   = "https://docker.io"
+	RegistryURLGHCR     = "https://ghcr.io"
+	RegistryURLNuGet    = "https://api.nuget.org"
+	RegistryURLGitHub   = "https://github.com"
+	RegistryURLGitLab   = "https://gitlab.com"
+	RegistryURLCrates   = "https://crates.io"
+	RegistryURLGo       = "https://proxy.golang.org"
+	RegistryURLRubyGems = "https://rubygems.org"
+	RegistryURLMaven    = "https://repo.maven.apache.org/maven2"
 )
 
 // Transport Types - supported remote transport protocols
@@ -33,6 +41,11 @@ const (
 	RuntimeHintUVX    = "uvx"
 	RuntimeHintDocker = "docker"
 	RuntimeHintDNX    = "dnx"
+	RuntimeHintCargo  = "cargo"
+	RuntimeHintGo     = "go"
+	RuntimeHintGem    = "gem"
+	RuntimeHintJBang  = "jbang"
+	RuntimeHintJava   = "java"
 )
 
 // Schema versions
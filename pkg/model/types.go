@@ -7,6 +7,11 @@ const (
 	StatusActive     Status = "active"
 	StatusDeprecated Status = "deprecated"
 	StatusDeleted    Status = "deleted"
+
+	// Publishes validated asynchronously are pending until validated, and rejected if validation
+	// fails. Server versions are only stored once active.
+	StatusPending  Status = "pending"
+	StatusRejected Status = "rejected"
 )
 
 // Transport represents transport configuration with optional URL templating
@@ -21,19 +26,26 @@ type Transport struct {
 //   - NPM:   RegistryType, Identifier (package name), Version, RegistryBaseURL (optional)
 //   - PyPI:  RegistryType, Identifier (package name), Version, RegistryBaseURL (optional)
 //   - NuGet: RegistryType, Identifier (package ID), Version, RegistryBaseURL (optional)
+//   - Cargo: RegistryType, Identifier (crate name), Version, RegistryBaseURL (optional)
+//   - Go:    RegistryType, Identifier (module path), Version (e.g. "v1.2.3"), RegistryBaseURL (optional module proxy)
+//   - RubyGems: RegistryType, Identifier (gem name), Version, RegistryBaseURL (optional)
+//   - Maven: RegistryType, Identifier ("groupId:artifactId"), Version, RegistryBaseURL (optional)
 //   - OCI:   RegistryType, Identifier (full image reference like "ghcr.io/owner/repo:tag")
 //   - MCPB:  RegistryType, Identifier (download URL), FileSHA256 (required)
 type Package struct {
-	// RegistryType indicates how to download packages (e.g., "npm", "pypi", "oci", "nuget", "mcpb")
+	// RegistryType indicates how to download packages (e.g., "npm", "pypi", "oci", "nuget", "mcpb", "cargo", "go", "rubygems", "maven")
 	RegistryType string `json:"registryType" minLength:"1"`
-	// RegistryBaseURL is the base URL of the package registry (used by npm, pypi, nuget; not used by oci, mcpb)
+	// RegistryBaseURL is the base URL of the package registry (used by npm, pypi, nuget, cargo, go, rubygems, maven; not used by oci, mcpb)
 	RegistryBaseURL string `json:"registryBaseUrl,omitempty"`
 	// Identifier is the package identifier:
-	//   - For NPM/PyPI/NuGet: package name or ID
+	//   - For NPM/PyPI/NuGet/Cargo: package name or ID
+	//   - For Go: module path (e.g., "github.com/owner/repo")
+	//   - For RubyGems: gem name
+	//   - For Maven: "groupId:artifactId" (e.g., "io.github.owner:weather-mcp")
 	//   - For OCI: full image reference (e.g., "ghcr.io/owner/repo:v1.0.0")
 	//   - For MCPB: direct download URL
 	Identifier string `json:"identifier" minLength:"1"`
-	// Version is the package version (used by npm, pypi, nuget; not used by oci, mcpb where version is in the identifier)
+	// Version is the package version (used by npm, pypi, nuget, cargo, go, rubygems, maven; not used by oci, mcpb where version is in the identifier)
 	Version string `json:"version,omitempty" minLength:"1"`
 	// FileSHA256 is the SHA-256 hash for integrity verification (required for mcpb, optional for others)
 	FileSHA256 string `json:"fileSha256,omitempty"`